@@ -0,0 +1,643 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	featureFlagsConstants "github.com/checkmarx/ast-cli/internal/constants/feature-flags"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedListingGroups       = "Failed listing groups"
+	failedShowingAccess       = "Failed showing group assignments"
+	failedAssigningGroups     = "Failed assigning groups"
+	failedUnassigningGroups   = "Failed unassigning groups"
+	failedApplyingAccess      = "Failed applying group assignments"
+	failedExportingAccess     = "Failed exporting group assignments"
+	accessStatusAssigned      = "ASSIGNED"
+	accessStatusUnassigned    = "UNASSIGNED"
+	accessStatusUpdated       = "ROLES_UPDATED"
+	accessStatusUnchanged     = "UNCHANGED"
+	accessStatusWouldAssign   = "WOULD_ASSIGN"
+	accessStatusWouldRemove   = "WOULD_UNASSIGN"
+	accessStatusWouldUpdate   = "WOULD_UPDATE_ROLES"
+	accessMissingGroups       = "%s: Please provide groups"
+	accessMissingProject      = "%s: Please provide a project ID or name"
+	accessRolesNotSupported   = "roles can only be assigned when access management is enabled"
+	accessFileProjectMissing  = "every project in the file needs a projectId or a projectName"
+	accessFileGroupMissing    = "every group in the file needs an id or a name"
+	accessGroupEntityType     = "group"
+	accessGroupLeftUnassigned = "group %s was left unassigned from project %s"
+	accessRolesNotUpdated     = "roles of group %s were not updated"
+)
+
+// accessGrant is a group assigned to a project, nil roles leave the roles of an existing assignment as they are
+type accessGrant struct {
+	GroupID   string
+	GroupName string
+	Roles     []string
+}
+
+type accessChange struct {
+	grant         accessGrant
+	status        string
+	previousRoles []string
+}
+
+func NewAccessCommand(
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
+	accessCmd := &cobra.Command{
+		Use:   "access",
+		Short: "Manage the group assignments of projects",
+		Long:  "The access command lists groups and shows, assigns and audits the groups assigned to projects and their roles.",
+	}
+
+	groupsCmd := &cobra.Command{
+		Use:   "groups",
+		Short: "List the groups of the tenant",
+		Example: heredoc.Doc(
+			`
+			$ cx access groups --groups Dev
+		`,
+		),
+		RunE: runListGroupsCommand(groupsWrapper),
+	}
+	groupsCmd.PersistentFlags().String(commonParams.GroupList, "", "Only list the groups whose name contains this value")
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the group assignments of projects",
+		Long:  "The show command lists the groups and roles assigned to a project, or to every project when no project is given.",
+		Example: heredoc.Doc(
+			`
+			$ cx access show --project-name <Project Name>
+			$ cx access show --format json
+		`,
+		),
+		RunE: runShowAccessCommand(accessManagementWrapper, projectsWrapper, featureFlagsWrapper),
+	}
+
+	assignCmd := &cobra.Command{
+		Use:   "assign",
+		Short: "Assign groups to a project",
+		Example: heredoc.Doc(
+			`
+			$ cx access assign --project-id <project_id> --groups Dev,QA --roles ast-scanner,ast-viewer
+		`,
+		),
+		RunE: runAssignGroupsCommand(groupsWrapper, accessManagementWrapper, projectsWrapper, featureFlagsWrapper, true),
+	}
+	assignCmd.PersistentFlags().StringSlice(commonParams.RolesFlag, nil, "Roles of the groups in the project, ex: (ast-scanner,ast-viewer)")
+
+	unassignCmd := &cobra.Command{
+		Use:   "unassign",
+		Short: "Remove groups from a project",
+		Example: heredoc.Doc(
+			`
+			$ cx access unassign --project-name <Project Name> --groups QA
+		`,
+		),
+		RunE: runAssignGroupsCommand(groupsWrapper, accessManagementWrapper, projectsWrapper, featureFlagsWrapper, false),
+	}
+
+	for _, cmd := range []*cobra.Command{showCmd, assignCmd, unassignCmd} {
+		addProjectIDFlag(cmd, "Project ID")
+		cmd.PersistentFlags().String(commonParams.ProjectName, "", "Project name")
+	}
+	for _, cmd := range []*cobra.Command{assignCmd, unassignCmd} {
+		cmd.PersistentFlags().String(commonParams.GroupList, "", "List of groups, ex: (PowerUsers,etc)")
+		markFlagAsRequired(cmd, commonParams.GroupList)
+		cmd.PersistentFlags().Bool(commonParams.DryRunFlag, false, "Report the changes without applying them")
+	}
+
+	applyCmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply the group assignments of a YAML or JSON file",
+		Long: "The apply command assigns the groups and roles listed for each project in the file. " +
+			"With --prune the groups assigned to those projects but missing from the file are removed.",
+		Example: heredoc.Doc(
+			`
+			$ cx access apply --file access.yaml --dry-run
+			$ cx access apply --file access.json --prune
+		`,
+		),
+		RunE: runApplyAccessCommand(groupsWrapper, accessManagementWrapper, projectsWrapper, featureFlagsWrapper),
+	}
+	applyCmd.PersistentFlags().String(commonParams.TriageFileFlag, "", "YAML or JSON file with the group assignments")
+	markFlagAsRequired(applyCmd, commonParams.TriageFileFlag)
+	applyCmd.PersistentFlags().Bool(commonParams.PruneFlag, false, "Remove the groups that are not in the file")
+	applyCmd.PersistentFlags().Bool(commonParams.DryRunFlag, false, "Report the changes without applying them")
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the group assignments of every project to a file",
+		Example: heredoc.Doc(
+			`
+			$ cx access export --file access.yaml
+		`,
+		),
+		RunE: runExportAccessCommand(accessManagementWrapper, projectsWrapper, featureFlagsWrapper),
+	}
+	exportCmd.PersistentFlags().String(commonParams.TriageFileFlag, "", "YAML or JSON file to write")
+	markFlagAsRequired(exportCmd, commonParams.TriageFileFlag)
+
+	addFormatFlagToMultipleCommands(
+		[]*cobra.Command{groupsCmd, showCmd, assignCmd, unassignCmd, applyCmd},
+		printer.FormatTable,
+		printer.FormatJSON,
+		printer.FormatList,
+	)
+	accessCmd.AddCommand(groupsCmd, showCmd, assignCmd, unassignCmd, applyCmd, exportCmd)
+	return accessCmd
+}
+
+func runListGroupsCommand(groupsWrapper wrappers.GroupsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		groupName, _ := cmd.Flags().GetString(commonParams.GroupList)
+		groups, err := groupsWrapper.Get(groupName)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedListingGroups)
+		}
+		views := []groupView{}
+		for _, group := range groups {
+			views = append(views, groupView{ID: group.ID, Name: group.Name})
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func runShowAccessCommand(
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projects, err := getAccessProjects(cmd, projectsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedShowingAccess)
+		}
+		accessManagementEnabled := isAccessManagementEnabled(featureFlagsWrapper)
+		views := []accessAssignmentView{}
+		for i := range projects {
+			grants, grantsErr := getAccessGrants(&projects[i], accessManagementWrapper, accessManagementEnabled)
+			if grantsErr != nil {
+				return errors.Wrapf(grantsErr, "%s", failedShowingAccess)
+			}
+			for _, grant := range grants {
+				views = append(views, toAccessAssignmentView(&projects[i], grant, ""))
+			}
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func runAssignGroupsCommand(
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	assign bool,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		failure := failedAssigningGroups
+		if !assign {
+			failure = failedUnassigningGroups
+		}
+		groupList, _ := cmd.Flags().GetString(commonParams.GroupList)
+		dryRun, _ := cmd.Flags().GetBool(commonParams.DryRunFlag)
+		if strings.TrimSpace(groupList) == "" {
+			return errors.Errorf(accessMissingGroups, failure)
+		}
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		projectName, _ := cmd.Flags().GetString(commonParams.ProjectName)
+		if projectID == "" && projectName == "" {
+			return errors.Errorf(accessMissingProject, failure)
+		}
+		project, err := resolveAccessProject(projectsWrapper, projectID, projectName)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failure)
+		}
+		groups, err := services.CreateGroupsMap(groupList, groupsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failure)
+		}
+
+		var roles []string
+		if cmd.Flags().Changed(commonParams.RolesFlag) {
+			roles, _ = cmd.Flags().GetStringSlice(commonParams.RolesFlag)
+			roles = append([]string{}, roles...)
+		}
+		var assigned []accessGrant
+		var unassigned []string
+		for _, group := range groups {
+			if assign {
+				assigned = append(assigned, accessGrant{GroupID: group.ID, GroupName: group.Name, Roles: roles})
+			} else {
+				unassigned = append(unassigned, group.ID)
+			}
+		}
+
+		views, err := applyAccessGrants(
+			project, assigned, unassigned, false, dryRun, accessManagementWrapper, projectsWrapper, isAccessManagementEnabled(featureFlagsWrapper),
+		)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failure)
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func runApplyAccessCommand(
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString(commonParams.TriageFileFlag)
+		prune, _ := cmd.Flags().GetBool(commonParams.PruneFlag)
+		dryRun, _ := cmd.Flags().GetBool(commonParams.DryRunFlag)
+		accessFile, err := readAccessAssignmentFile(file)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedApplyingAccess)
+		}
+		accessManagementEnabled := isAccessManagementEnabled(featureFlagsWrapper)
+
+		// the groups of the file are resolved once, a name can appear in many projects
+		resolved := make(map[string]*wrappers.Group)
+		views := []accessAssignmentView{}
+		for i := range accessFile.Projects {
+			entry := &accessFile.Projects[i]
+			project, projectErr := resolveAccessProject(projectsWrapper, entry.ProjectID, entry.ProjectName)
+			if projectErr != nil {
+				return errors.Wrapf(projectErr, "%s", failedApplyingAccess)
+			}
+			var grants []accessGrant
+			for _, group := range entry.Groups {
+				grant, grantErr := resolveAccessGroup(groupsWrapper, group, resolved)
+				if grantErr != nil {
+					return errors.Wrapf(grantErr, "%s", failedApplyingAccess)
+				}
+				grants = append(grants, grant)
+			}
+			projectViews, applyErr := applyAccessGrants(project, grants, nil, prune, dryRun, accessManagementWrapper, projectsWrapper, accessManagementEnabled)
+			if applyErr != nil {
+				return errors.Wrapf(applyErr, "%s", failedApplyingAccess)
+			}
+			views = append(views, projectViews...)
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func runExportAccessCommand(
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString(commonParams.TriageFileFlag)
+		projects, err := getAllProjects(projectsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedExportingAccess)
+		}
+		accessManagementEnabled := isAccessManagementEnabled(featureFlagsWrapper)
+		accessFile := &wrappers.AccessAssignmentFile{Projects: []wrappers.AccessAssignmentEntry{}}
+		for i := range projects {
+			grants, grantsErr := getAccessGrants(&projects[i], accessManagementWrapper, accessManagementEnabled)
+			if grantsErr != nil {
+				return errors.Wrapf(grantsErr, "%s", failedExportingAccess)
+			}
+			entry := wrappers.AccessAssignmentEntry{ProjectID: projects[i].ID, ProjectName: projects[i].Name, Groups: []wrappers.AccessGroupEntry{}}
+			for _, grant := range grants {
+				entry.Groups = append(entry.Groups, wrappers.AccessGroupEntry{ID: grant.GroupID, Name: grant.GroupName, Roles: grant.Roles})
+			}
+			accessFile.Projects = append(accessFile.Projects, entry)
+		}
+		return writeAccessAssignmentFile(file, accessFile)
+	}
+}
+
+// applyAccessGrants assigns and unassigns groups of one project, returning a view per group of the project
+func applyAccessGrants(
+	project *wrappers.ProjectResponseModel,
+	assigned []accessGrant,
+	unassigned []string,
+	prune, dryRun bool,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	accessManagementEnabled bool,
+) ([]accessAssignmentView, error) {
+	if !accessManagementEnabled {
+		for _, grant := range assigned {
+			if len(grant.Roles) > 0 {
+				return nil, errors.New(accessRolesNotSupported)
+			}
+		}
+	}
+	current, err := getAccessGrants(project, accessManagementWrapper, accessManagementEnabled)
+	if err != nil {
+		return nil, err
+	}
+	changes := planAccessChanges(current, assigned, unassigned, prune)
+
+	views := []accessAssignmentView{}
+	changed := false
+	for _, change := range changes {
+		status := change.status
+		if status != accessStatusUnchanged {
+			changed = true
+		}
+		if dryRun {
+			status = map[string]string{
+				accessStatusAssigned:   accessStatusWouldAssign,
+				accessStatusUnassigned: accessStatusWouldRemove,
+				accessStatusUpdated:    accessStatusWouldUpdate,
+				accessStatusUnchanged:  accessStatusUnchanged,
+			}[status]
+		}
+		views = append(views, toAccessAssignmentView(project, change.grant, status))
+	}
+	if dryRun || !changed {
+		return views, nil
+	}
+	if !accessManagementEnabled {
+		return views, updateProjectGroupIDs(project, changes, projectsWrapper)
+	}
+	return views, executeAccessChanges(project, changes, accessManagementWrapper)
+}
+
+// planAccessChanges compares the current grants with the assigned ones, by group ID
+func planAccessChanges(current, assigned []accessGrant, unassigned []string, prune bool) []accessChange {
+	var changes []accessChange
+	wanted := make(map[string]bool)
+	for _, grant := range assigned {
+		wanted[grant.GroupID] = true
+	}
+	for _, grant := range current {
+		switch {
+		case slices.Contains(unassigned, grant.GroupID), prune && !wanted[grant.GroupID]:
+			changes = append(changes, accessChange{grant: grant, status: accessStatusUnassigned})
+		case wanted[grant.GroupID]:
+			continue
+		default:
+			changes = append(changes, accessChange{grant: grant, status: accessStatusUnchanged})
+		}
+	}
+	for _, grant := range assigned {
+		index := slices.IndexFunc(current, func(existing accessGrant) bool { return existing.GroupID == grant.GroupID })
+		if index < 0 {
+			changes = append(changes, accessChange{grant: grant, status: accessStatusAssigned})
+			continue
+		}
+		existing := current[index]
+		if grant.GroupName == "" {
+			grant.GroupName = existing.GroupName
+		}
+		if grant.Roles == nil || sameRoles(grant.Roles, existing.Roles) {
+			grant.Roles = existing.Roles
+			changes = append(changes, accessChange{grant: grant, status: accessStatusUnchanged})
+			continue
+		}
+		changes = append(changes, accessChange{grant: grant, status: accessStatusUpdated, previousRoles: existing.Roles})
+	}
+	return changes
+}
+
+// executeAccessChanges applies the plan through access management, roles are updated by assigning the group again.
+// When the new assignment fails the previous roles are assigned back, so the group does not silently lose its access
+func executeAccessChanges(project *wrappers.ProjectResponseModel, changes []accessChange, accessManagementWrapper wrappers.AccessManagementWrapper) error {
+	for _, change := range changes {
+		group := &wrappers.Group{ID: change.grant.GroupID, Name: change.grant.GroupName}
+		switch change.status {
+		case accessStatusUnassigned, accessStatusUpdated:
+			err := accessManagementWrapper.DeleteGroupsAssignment(project.ID, []*wrappers.Group{group})
+			if err != nil {
+				return err
+			}
+		}
+		switch change.status {
+		case accessStatusAssigned:
+			err := accessManagementWrapper.CreateGroupsAssignmentWithRoles(project.ID, project.Name, []*wrappers.Group{group}, change.grant.Roles)
+			if err != nil {
+				return err
+			}
+		case accessStatusUpdated:
+			err := accessManagementWrapper.CreateGroupsAssignmentWithRoles(project.ID, project.Name, []*wrappers.Group{group}, change.grant.Roles)
+			if err == nil {
+				continue
+			}
+			restoreErr := accessManagementWrapper.CreateGroupsAssignmentWithRoles(project.ID, project.Name, []*wrappers.Group{group}, change.previousRoles)
+			if restoreErr != nil {
+				return errors.Wrapf(err, accessGroupLeftUnassigned, accessGroupLabel(change.grant), project.Name)
+			}
+			return errors.Wrapf(err, accessRolesNotUpdated, accessGroupLabel(change.grant))
+		}
+	}
+	return nil
+}
+
+func accessGroupLabel(grant accessGrant) string {
+	if grant.GroupName != "" {
+		return grant.GroupName
+	}
+	return grant.GroupID
+}
+
+// updateProjectGroupIDs applies the plan to the groups of the project when access management is disabled
+func updateProjectGroupIDs(project *wrappers.ProjectResponseModel, changes []accessChange, projectsWrapper wrappers.ProjectsWrapper) error {
+	groups := []string{}
+	for _, change := range changes {
+		if change.status != accessStatusUnassigned {
+			groups = append(groups, change.grant.GroupID)
+		}
+	}
+	return projectsWrapper.Update(project.ID, &wrappers.Project{
+		Name:           project.Name,
+		RepoURL:        project.RepoURL,
+		MainBranch:     project.MainBranch,
+		Tags:           project.Tags,
+		Groups:         groups,
+		PrivatePackage: project.PrivatePackage,
+		ApplicationIds: project.ApplicationIds,
+	})
+}
+
+// getAccessGrants reads the groups of the project with their roles, from access management when it is enabled
+func getAccessGrants(
+	project *wrappers.ProjectResponseModel,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	accessManagementEnabled bool,
+) ([]accessGrant, error) {
+	var grants []accessGrant
+	if !accessManagementEnabled {
+		for _, groupID := range project.Groups {
+			grants = append(grants, accessGrant{GroupID: groupID})
+		}
+		return grants, nil
+	}
+	assignments, err := accessManagementWrapper.GetAssignments(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		if assignment.EntityType != accessGroupEntityType {
+			continue
+		}
+		grants = append(grants, accessGrant{GroupID: assignment.EntityID, GroupName: assignment.EntityName, Roles: assignment.EntityRoles})
+	}
+	return grants, nil
+}
+
+func getAccessProjects(cmd *cobra.Command, projectsWrapper wrappers.ProjectsWrapper) ([]wrappers.ProjectResponseModel, error) {
+	projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+	projectName, _ := cmd.Flags().GetString(commonParams.ProjectName)
+	if projectID == "" && projectName == "" {
+		return getAllProjects(projectsWrapper)
+	}
+	project, err := resolveAccessProject(projectsWrapper, projectID, projectName)
+	if err != nil {
+		return nil, err
+	}
+	return []wrappers.ProjectResponseModel{*project}, nil
+}
+
+func resolveAccessProject(projectsWrapper wrappers.ProjectsWrapper, projectID, projectName string) (*wrappers.ProjectResponseModel, error) {
+	var project *wrappers.ProjectResponseModel
+	var errorModel *wrappers.ErrorModel
+	var err error
+	switch {
+	case projectID != "":
+		project, errorModel, err = projectsWrapper.GetByID(projectID)
+	case projectName != "":
+		project, errorModel, err = projectsWrapper.GetByName(projectName)
+	default:
+		return nil, errors.New(accessFileProjectMissing)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, services.FailedGettingProj, errorModel.Code, errorModel.Message)
+	}
+	return project, nil
+}
+
+func resolveAccessGroup(groupsWrapper wrappers.GroupsWrapper, entry wrappers.AccessGroupEntry, resolved map[string]*wrappers.Group) (accessGrant, error) {
+	grant := accessGrant{GroupID: entry.ID, GroupName: entry.Name, Roles: entry.Roles}
+	if entry.ID != "" {
+		return grant, nil
+	}
+	if entry.Name == "" {
+		return grant, errors.New(accessFileGroupMissing)
+	}
+	group, found := resolved[entry.Name]
+	if !found {
+		groups, err := services.CreateGroupsMap(entry.Name, groupsWrapper)
+		if err != nil {
+			return grant, err
+		}
+		group = groups[0]
+		resolved[entry.Name] = group
+	}
+	grant.GroupID = group.ID
+	return grant, nil
+}
+
+func isAccessManagementEnabled(featureFlagsWrapper wrappers.FeatureFlagsWrapper) bool {
+	flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, featureFlagsConstants.AccessManagementEnabled)
+	return flagResponse.Status
+}
+
+func sameRoles(roles, other []string) bool {
+	sortedRoles := append([]string{}, roles...)
+	sortedOther := append([]string{}, other...)
+	sort.Strings(sortedRoles)
+	sort.Strings(sortedOther)
+	return slices.Equal(sortedRoles, sortedOther)
+}
+
+func readAccessAssignmentFile(file string) (*wrappers.AccessAssignmentFile, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	accessFile := &wrappers.AccessAssignmentFile{}
+	if strings.EqualFold(filepath.Ext(file), configFileExtensionJSON) {
+		err = json.Unmarshal(content, accessFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", file)
+		}
+		return accessFile, nil
+	}
+	v, err := readYAMLFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", file)
+	}
+	for _, project := range yamlList(v, "projects") {
+		entry := wrappers.AccessAssignmentEntry{
+			ProjectID:   project.GetString("projectId"),
+			ProjectName: project.GetString("projectName"),
+		}
+		for _, group := range yamlList(project, "groups") {
+			groupEntry := wrappers.AccessGroupEntry{ID: group.GetString("id"), Name: group.GetString("name")}
+			if group.IsSet("roles") {
+				groupEntry.Roles = group.GetStringSlice("roles")
+			}
+			entry.Groups = append(entry.Groups, groupEntry)
+		}
+		accessFile.Projects = append(accessFile.Projects, entry)
+	}
+	return accessFile, nil
+}
+
+func writeAccessAssignmentFile(file string, accessFile *wrappers.AccessAssignmentFile) error {
+	if strings.EqualFold(filepath.Ext(file), configFileExtensionJSON) {
+		return writeJSONReport(file, accessFile)
+	}
+	content, err := marshalYAML("projects", accessFile.Projects)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, content, 0600)
+}
+
+func toAccessAssignmentView(project *wrappers.ProjectResponseModel, grant accessGrant, status string) accessAssignmentView {
+	return accessAssignmentView{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		GroupID:     grant.GroupID,
+		GroupName:   grant.GroupName,
+		Roles:       grant.Roles,
+		Status:      status,
+	}
+}
+
+type groupView struct {
+	ID   string `format:"name:Group ID"`
+	Name string
+}
+
+type accessAssignmentView struct {
+	ProjectID   string `format:"name:Project ID"`
+	ProjectName string `format:"name:Project Name"`
+	GroupID     string `format:"name:Group ID"`
+	GroupName   string `format:"name:Group Name"`
+	Roles       []string
+	Status      string
+}
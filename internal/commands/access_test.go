@@ -0,0 +1,169 @@
+//go:build !integration
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+// assignedAccessWrapper serves fixed assignments and records the assignment changes
+type assignedAccessWrapper struct {
+	mock.AccessManagementMockWrapper
+	assignments []*wrappers.AssignmentResponse
+	created     map[string][]string
+	deleted     []string
+	failCreates int
+}
+
+func (a *assignedAccessWrapper) GetAssignments(string) ([]*wrappers.AssignmentResponse, error) {
+	return a.assignments, nil
+}
+
+func (a *assignedAccessWrapper) CreateGroupsAssignmentWithRoles(_, _ string, groups []*wrappers.Group, roles []string) error {
+	if a.failCreates > 0 {
+		a.failCreates--
+		return errors.New("assignment failed")
+	}
+	for _, group := range groups {
+		a.created[group.ID] = roles
+	}
+	return nil
+}
+
+func (a *assignedAccessWrapper) DeleteGroupsAssignment(_ string, groups []*wrappers.Group) error {
+	for _, group := range groups {
+		a.deleted = append(a.deleted, group.ID)
+	}
+	return nil
+}
+
+func newAssignedAccessWrapper() *assignedAccessWrapper {
+	return &assignedAccessWrapper{
+		created: make(map[string][]string),
+		assignments: []*wrappers.AssignmentResponse{
+			{EntityID: "G1", EntityName: "Dev", EntityType: "group", EntityRoles: []string{"ast-scanner"}},
+			{EntityID: "G2", EntityName: "QA", EntityType: "group", EntityRoles: []string{"ast-viewer"}},
+			{EntityID: "U1", EntityName: "admin", EntityType: "user", EntityRoles: []string{"ast-admin"}},
+		},
+	}
+}
+
+func accessStatuses(views []accessAssignmentView) map[string]string {
+	statuses := make(map[string]string)
+	for _, view := range views {
+		statuses[view.GroupID] = view.Status
+	}
+	return statuses
+}
+
+func TestApplyAccessGrants(t *testing.T) {
+	accessWrapper := newAssignedAccessWrapper()
+	project := &wrappers.ProjectResponseModel{ID: "P1", Name: "web"}
+	views, err := applyAccessGrants(project, []accessGrant{
+		{GroupID: "G1", Roles: []string{"ast-scanner", "ast-viewer"}},
+		{GroupID: "G3", GroupName: "Ops", Roles: []string{"ast-viewer"}},
+		{GroupID: "G2"},
+	}, nil, false, false, accessWrapper, &mock.ProjectsMockWrapper{}, true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, accessStatuses(views), map[string]string{
+		"G1": accessStatusUpdated,
+		"G2": accessStatusUnchanged,
+		"G3": accessStatusAssigned,
+	})
+	assert.DeepEqual(t, accessWrapper.deleted, []string{"G1"})
+	assert.DeepEqual(t, accessWrapper.created, map[string][]string{
+		"G1": {"ast-scanner", "ast-viewer"},
+		"G3": {"ast-viewer"},
+	})
+}
+
+func TestApplyAccessGrantsPruneDryRun(t *testing.T) {
+	accessWrapper := newAssignedAccessWrapper()
+	project := &wrappers.ProjectResponseModel{ID: "P1", Name: "web"}
+	views, err := applyAccessGrants(project, []accessGrant{{GroupID: "G1", Roles: []string{"ast-scanner"}}}, nil,
+		true, true, accessWrapper, &mock.ProjectsMockWrapper{}, true)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, accessStatuses(views), map[string]string{
+		"G1": accessStatusUnchanged,
+		"G2": accessStatusWouldRemove,
+	})
+	assert.Equal(t, len(accessWrapper.deleted)+len(accessWrapper.created), 0)
+
+	_, err = applyAccessGrants(project, []accessGrant{{GroupID: "G1", Roles: []string{"ast-scanner"}}}, nil,
+		false, false, accessWrapper, &mock.ProjectsMockWrapper{}, false)
+	assert.ErrorContains(t, err, accessRolesNotSupported)
+}
+
+func TestApplyAccessGrantsFailedRolesUpdate(t *testing.T) {
+	accessWrapper := newAssignedAccessWrapper()
+	accessWrapper.failCreates = 1
+	project := &wrappers.ProjectResponseModel{ID: "P1", Name: "web"}
+	_, err := applyAccessGrants(project, []accessGrant{{GroupID: "G1", GroupName: "Dev", Roles: []string{"ast-viewer"}}}, nil,
+		false, false, accessWrapper, &mock.ProjectsMockWrapper{}, true)
+	assert.Error(t, err, "roles of group Dev were not updated: assignment failed")
+	assert.DeepEqual(t, accessWrapper.created, map[string][]string{"G1": {"ast-scanner"}})
+
+	accessWrapper = newAssignedAccessWrapper()
+	accessWrapper.failCreates = 2
+	_, err = applyAccessGrants(project, []accessGrant{{GroupID: "G1", GroupName: "Dev", Roles: []string{"ast-viewer"}}}, nil,
+		false, false, accessWrapper, &mock.ProjectsMockWrapper{}, true)
+	assert.Error(t, err, "group Dev was left unassigned from project web: assignment failed")
+	assert.DeepEqual(t, accessWrapper.deleted, []string{"G1"})
+	assert.Equal(t, len(accessWrapper.created), 0)
+}
+
+func TestAccessGroupsAndShow(t *testing.T) {
+	execCmdNilAssertion(t, "access", "groups", "--format", "json")
+	execCmdNilAssertion(t, "access", "show", "--project-id", "MOCK")
+	execCmdNilAssertion(t, "access", "show", "--format", "list")
+}
+
+func TestAccessAssign(t *testing.T) {
+	execCmdNilAssertion(t, "access", "assign", "--project-id", "MOCK", "--groups", "group")
+	execCmdNilAssertion(t, "access", "unassign", "--project-name", "MOCK", "--groups", "group", "--dry-run")
+
+	err := execCmdNotNilAssertion(t, "access", "assign", "--groups", "group")
+	assert.ErrorContains(t, err, "Please provide a project ID or name")
+	err = execCmdNotNilAssertion(t, "access", "assign", "--project-id", "MOCK", "--groups", "fake-group-error")
+	assert.ErrorContains(t, err, failedAssigningGroups)
+}
+
+func TestAccessApplyAndExport(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "access.yaml")
+	assert.NilError(t, os.WriteFile(file, []byte(
+		"projects:\n  - projectName: web\n    groups:\n      - name: group\n      - id: G2\n"), 0600))
+	execCmdNilAssertion(t, "access", "apply", "--file", file, "--prune", "--dry-run")
+
+	assert.NilError(t, os.WriteFile(file, []byte(
+		"projects:\n  - projectName: web\n    groups:\n      - name: group\n      - id: G2\n        roles: [ast-viewer]\n"), 0600))
+	accessFile, err := readAccessAssignmentFile(file)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, accessFile.Projects, []wrappers.AccessAssignmentEntry{
+		{ProjectName: "web", Groups: []wrappers.AccessGroupEntry{{Name: "group"}, {ID: "G2", Roles: []string{"ast-viewer"}}}},
+	})
+
+	assert.NilError(t, os.WriteFile(file, []byte("projects:\n  - groups:\n      - name: group\n"), 0600))
+	err = execCmdNotNilAssertion(t, "access", "apply", "--file", file)
+	assert.ErrorContains(t, err, accessFileProjectMissing)
+
+	exported := filepath.Join(dir, "access.json")
+	execCmdNilAssertion(t, "access", "export", "--file", exported)
+	accessFile, err = readAccessAssignmentFile(exported)
+	assert.NilError(t, err)
+	assert.Equal(t, len(accessFile.Projects), 1)
+	assert.Equal(t, accessFile.Projects[0].ProjectID, "MOCK")
+
+	exported = filepath.Join(dir, "access-export.yaml")
+	execCmdNilAssertion(t, "access", "export", "--file", exported)
+	accessFile, err = readAccessAssignmentFile(exported)
+	assert.NilError(t, err)
+	assert.Equal(t, accessFile.Projects[0].ProjectID, "MOCK")
+}
@@ -0,0 +1,597 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedCreatingApplication       = "Failed creating an application"
+	failedUpdatingApplication       = "Failed updating an application"
+	failedDeletingApplication       = "Failed deleting an application"
+	failedGettingApplication        = "Failed getting an application"
+	failedListingApplications       = "Failed listing applications"
+	failedAssociatingProjects       = "Failed associating projects"
+	failedSummarizingApplication    = "Failed summarizing application results"
+	applicationDefaultCriticality   = 3
+	applicationMaxCriticality       = 3
+	applicationRuleSeparator        = "="
+	applicationSummaryTotal         = "Total"
+	applicationMissingIdentifier    = "%s: Please provide an application ID or name"
+	applicationMissingProjects      = "%s: Please provide project IDs or names"
+	applicationInvalidCriticality   = "%s: --%s should be between 1 and %d"
+	applicationInvalidRule          = "invalid rule %s, use type=value"
+	applicationProjectAssociated    = "ASSOCIATED"
+	applicationProjectDisassociated = "DISASSOCIATED"
+	applicationProjectUnchanged     = "UNCHANGED"
+)
+
+var filterApplicationsListFlagUsage = fmt.Sprintf(
+	"Filter the list of applications. Use ';' as the delimeter for arrays. Available filters are: %s",
+	strings.Join(
+		[]string{
+			commonParams.LimitQueryParam,
+			commonParams.OffsetQueryParam,
+			"name",
+			commonParams.TagsKeyQueryParam,
+			commonParams.TagsValueQueryParam,
+		}, ",",
+	),
+)
+
+func NewApplicationCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+) *cobra.Command {
+	applicationCmd := &cobra.Command{
+		Use:   "application",
+		Short: "Manage applications",
+		Long:  "The application command enables the ability to manage applications in Checkmarx One.",
+		Annotations: map[string]string{
+			"command:doc": heredoc.Doc(
+				`
+				https://checkmarx.com/resource/documents/en/34965-68620-application.html
+			`,
+			),
+		},
+	}
+
+	createApplicationCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application create --application-name <Application Name> --criticality 2 --rule project.tag.key.exists=team --tags team:payments
+		`,
+		),
+		RunE: runCreateApplicationCommand(applicationsWrapper),
+	}
+	createApplicationCmd.PersistentFlags().String(commonParams.ApplicationName, "", "Name of the application")
+	markFlagAsRequired(createApplicationCmd, commonParams.ApplicationName)
+	createApplicationCmd.PersistentFlags().String(commonParams.DescriptionFlag, "", "Description of the application")
+	createApplicationCmd.PersistentFlags().Int(commonParams.CriticalityFlag, applicationDefaultCriticality, "Criticality of the application, from 1 to 3")
+	createApplicationCmd.PersistentFlags().StringSlice(commonParams.RuleFlag, []string{},
+		"Rules adding projects to the application, ex: (project.tag.key.exists=team,project.name.starts-with=web-)")
+	createApplicationCmd.PersistentFlags().String(commonParams.TagList, "", "List of tags, ex: (tagA,tagB:val,etc)")
+
+	listApplicationsCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all applications in the system",
+		Example: heredoc.Doc(
+			`
+			$ cx application list --format list --filter "name=<Application Name>"
+		`,
+		),
+		RunE: runListApplicationsCommand(applicationsWrapper),
+	}
+	listApplicationsCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterApplicationsListFlagUsage)
+
+	showApplicationCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show information about an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application show --application-id <application_id>
+		`,
+		),
+		RunE: runShowApplicationCommand(applicationsWrapper),
+	}
+
+	updateApplicationCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the rules, criticality and tags of an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application update --application-name <Application Name> --criticality 1 --add-tags pci
+			$ cx application update --application-id <application_id> --add-rule project.name.in=web;api --remove-rule project.tag.key.exists=team
+		`,
+		),
+		RunE: runUpdateApplicationCommand(applicationsWrapper),
+	}
+	updateApplicationCmd.PersistentFlags().String(commonParams.DescriptionFlag, "", "Description of the application")
+	updateApplicationCmd.PersistentFlags().Int(commonParams.CriticalityFlag, applicationDefaultCriticality, "Criticality of the application, from 1 to 3")
+	updateApplicationCmd.PersistentFlags().StringSlice(commonParams.RuleFlag, []string{}, "Replace the rules of the application, ex: (project.tag.key.exists=team)")
+	updateApplicationCmd.PersistentFlags().StringSlice(commonParams.AddRuleFlag, []string{}, "Rules to add to the application")
+	updateApplicationCmd.PersistentFlags().StringSlice(commonParams.RemoveRuleFlag, []string{}, "Rules to remove from the application, by type=value or rule ID")
+	updateApplicationCmd.PersistentFlags().String(commonParams.TagList, "", "Replace the tags of the application, ex: (tagA,tagB:val,etc)")
+	updateApplicationCmd.PersistentFlags().String(commonParams.AddTagsFlag, "", "Tags to add to the application, ex: (tagA,tagB:val,etc)")
+	updateApplicationCmd.PersistentFlags().String(commonParams.RemoveTagsFlag, "", "Tag keys to remove from the application, ex: (tagA,tagB)")
+
+	deleteApplicationCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application delete --application-id <application_id>
+		`,
+		),
+		RunE: runDeleteApplicationCommand(applicationsWrapper),
+	}
+
+	addProjectCmd := &cobra.Command{
+		Use:   "add-project",
+		Short: "Associate projects with an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application add-project --application-name <Application Name> --project-name web,api
+		`,
+		),
+		RunE: runApplicationProjectsCommand(applicationsWrapper, projectsWrapper, true),
+	}
+	removeProjectCmd := &cobra.Command{
+		Use:   "remove-project",
+		Short: "Remove the association between projects and an application",
+		Example: heredoc.Doc(
+			`
+			$ cx application remove-project --application-id <application_id> --project-id <project_id>
+		`,
+		),
+		RunE: runApplicationProjectsCommand(applicationsWrapper, projectsWrapper, false),
+	}
+	for _, cmd := range []*cobra.Command{addProjectCmd, removeProjectCmd} {
+		cmd.PersistentFlags().StringSlice(commonParams.ProjectIDFlag, []string{}, "IDs of the projects")
+		cmd.PersistentFlags().StringSlice(commonParams.ProjectName, []string{}, "Names of the projects")
+	}
+
+	summaryCmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Summarize the results of the projects of an application",
+		Long: "The summary command aggregates the latest main branch scan of every project of the application " +
+			"into per project and total severity counts.",
+		Example: heredoc.Doc(
+			`
+			$ cx application summary --application-name <Application Name> --format json
+		`,
+		),
+		RunE: runApplicationSummaryCommand(applicationsWrapper, projectsWrapper, resultsWrapper, scansWrapper, policyWrapper),
+	}
+
+	for _, cmd := range []*cobra.Command{showApplicationCmd, updateApplicationCmd, deleteApplicationCmd, addProjectCmd, removeProjectCmd, summaryCmd} {
+		cmd.PersistentFlags().String(commonParams.ApplicationIDFlag, "", "Application ID")
+		cmd.PersistentFlags().String(commonParams.ApplicationName, "", "Application name")
+	}
+	addFormatFlagToMultipleCommands(
+		[]*cobra.Command{createApplicationCmd, listApplicationsCmd, showApplicationCmd, updateApplicationCmd, addProjectCmd, removeProjectCmd, summaryCmd},
+		printer.FormatTable,
+		printer.FormatJSON,
+		printer.FormatList,
+	)
+	applicationCmd.AddCommand(
+		createApplicationCmd,
+		listApplicationsCmd,
+		showApplicationCmd,
+		updateApplicationCmd,
+		deleteApplicationCmd,
+		addProjectCmd,
+		removeProjectCmd,
+		summaryCmd,
+	)
+	return applicationCmd
+}
+
+func runCreateApplicationCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString(commonParams.ApplicationName)
+		description, _ := cmd.Flags().GetString(commonParams.DescriptionFlag)
+		criticality, _ := cmd.Flags().GetInt(commonParams.CriticalityFlag)
+		ruleList, _ := cmd.Flags().GetStringSlice(commonParams.RuleFlag)
+		tagList, _ := cmd.Flags().GetString(commonParams.TagList)
+		if strings.TrimSpace(name) == "" {
+			return errors.Errorf("%s: Please provide an application name", failedCreatingApplication)
+		}
+		if criticality < 1 || criticality > applicationMaxCriticality {
+			return errors.Errorf(applicationInvalidCriticality, failedCreatingApplication, commonParams.CriticalityFlag, applicationMaxCriticality)
+		}
+		rules, err := parseApplicationRules(ruleList)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingApplication)
+		}
+
+		application, errorModel, err := applicationsWrapper.Create(&wrappers.ApplicationConfiguration{
+			Name:        name,
+			Description: description,
+			Criticality: criticality,
+			Rules:       rules,
+			Tags:        parseTagList(tagList),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingApplication)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedCreatingApplication, errorModel.Code, errorModel.Message)
+		}
+		return printByFormat(cmd, toApplicationView(application))
+	}
+}
+
+func runListApplicationsCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		params, err := getFilters(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedListingApplications)
+		}
+		applications, err := applicationsWrapper.Get(params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedListingApplications)
+		}
+		views := []applicationView{}
+		if applications != nil {
+			for i := range applications.Applications {
+				views = append(views, toApplicationView(&applications.Applications[i]))
+			}
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func runShowApplicationCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := resolveApplication(cmd, applicationsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingApplication)
+		}
+		return printByFormat(cmd, toApplicationView(application))
+	}
+}
+
+func runUpdateApplicationCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := resolveApplication(cmd, applicationsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingApplication)
+		}
+		configuration := &wrappers.ApplicationConfiguration{
+			Name:        application.Name,
+			Description: application.Description,
+			Criticality: application.Criticality,
+			Rules:       application.Rules,
+			Tags:        updateProjectTags(cmd, application.Tags),
+		}
+		if cmd.Flags().Changed(commonParams.DescriptionFlag) {
+			configuration.Description, _ = cmd.Flags().GetString(commonParams.DescriptionFlag)
+		}
+		if cmd.Flags().Changed(commonParams.CriticalityFlag) {
+			configuration.Criticality, _ = cmd.Flags().GetInt(commonParams.CriticalityFlag)
+			if configuration.Criticality < 1 || configuration.Criticality > applicationMaxCriticality {
+				return errors.Errorf(applicationInvalidCriticality, failedUpdatingApplication, commonParams.CriticalityFlag, applicationMaxCriticality)
+			}
+		}
+		configuration.Rules, err = updateApplicationRules(cmd, application.Rules)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingApplication)
+		}
+
+		errorModel, err := applicationsWrapper.Update(application.ID, configuration)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingApplication)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedUpdatingApplication, errorModel.Code, errorModel.Message)
+		}
+		application.Description = configuration.Description
+		application.Criticality = configuration.Criticality
+		application.Rules = configuration.Rules
+		application.Tags = configuration.Tags
+		return printByFormat(cmd, toApplicationView(application))
+	}
+}
+
+func runDeleteApplicationCommand(applicationsWrapper wrappers.ApplicationsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := resolveApplication(cmd, applicationsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedDeletingApplication)
+		}
+		errorModel, err := applicationsWrapper.Delete(application.ID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedDeletingApplication)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedDeletingApplication, errorModel.Code, errorModel.Message)
+		}
+		return nil
+	}
+}
+
+// runApplicationProjectsCommand associates or disassociates projects through their application IDs, like project create and update do
+func runApplicationProjectsCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	associate bool,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := resolveApplication(cmd, applicationsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedAssociatingProjects)
+		}
+		projectIDs, _ := cmd.Flags().GetStringSlice(commonParams.ProjectIDFlag)
+		projectNames, _ := cmd.Flags().GetStringSlice(commonParams.ProjectName)
+		if len(projectIDs) == 0 && len(projectNames) == 0 {
+			return errors.Errorf(applicationMissingProjects, failedAssociatingProjects)
+		}
+		for _, projectName := range projectNames {
+			project, errorModel, findErr := projectsWrapper.GetByName(projectName)
+			if findErr != nil {
+				return errors.Wrapf(findErr, "%s", failedAssociatingProjects)
+			}
+			if errorModel != nil {
+				return errors.Errorf(services.ErrorCodeFormat, failedAssociatingProjects, errorModel.Code, errorModel.Message)
+			}
+			projectIDs = append(projectIDs, project.ID)
+		}
+
+		views := []applicationProjectView{}
+		for _, projectID := range projectIDs {
+			view, updateErr := updateProjectApplication(projectsWrapper, projectID, application.ID, associate)
+			if updateErr != nil {
+				return errors.Wrapf(updateErr, "%s", failedAssociatingProjects)
+			}
+			views = append(views, *view)
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func updateProjectApplication(projectsWrapper wrappers.ProjectsWrapper, projectID, applicationID string, associate bool) (*applicationProjectView, error) {
+	project, errorModel, err := projectsWrapper.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, services.FailedGettingProj, errorModel.Code, errorModel.Message)
+	}
+	view := &applicationProjectView{ProjectID: project.ID, ProjectName: project.Name, Status: applicationProjectUnchanged}
+	associated := slices.Contains(project.ApplicationIds, applicationID)
+	if associated == associate {
+		return view, nil
+	}
+
+	applicationIds := []string{}
+	for _, id := range project.ApplicationIds {
+		if id != applicationID {
+			applicationIds = append(applicationIds, id)
+		}
+	}
+	view.Status = applicationProjectDisassociated
+	if associate {
+		applicationIds = append(applicationIds, applicationID)
+		view.Status = applicationProjectAssociated
+	}
+	err = projectsWrapper.Update(project.ID, &wrappers.Project{
+		Name:           project.Name,
+		RepoURL:        project.RepoURL,
+		MainBranch:     project.MainBranch,
+		Tags:           project.Tags,
+		Groups:         project.Groups,
+		PrivatePackage: project.PrivatePackage,
+		ApplicationIds: applicationIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func runApplicationSummaryCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		application, err := resolveApplication(cmd, applicationsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedSummarizingApplication)
+		}
+		var projects []wrappers.ProjectResponseModel
+		for _, projectID := range application.ProjectIds {
+			project, errorModel, getErr := projectsWrapper.GetByID(projectID)
+			if getErr != nil {
+				return errors.Wrapf(getErr, "%s", failedSummarizingApplication)
+			}
+			if errorModel != nil {
+				return errors.Errorf(services.ErrorCodeFormat, failedSummarizingApplication, errorModel.Code, errorModel.Message)
+			}
+			projects = append(projects, *project)
+		}
+
+		projectResults, err := getPortfolioProjectsResults(projects, resultsWrapper, scansWrapper, policyWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedSummarizingApplication)
+		}
+		return printByFormat(cmd, toApplicationSummaryViews(buildPortfolioSummary(projectResults, 0)))
+	}
+}
+
+// resolveApplication reads the application given by --application-id or --application-name
+func resolveApplication(cmd *cobra.Command, applicationsWrapper wrappers.ApplicationsWrapper) (*wrappers.Application, error) {
+	applicationID, _ := cmd.Flags().GetString(commonParams.ApplicationIDFlag)
+	applicationName, _ := cmd.Flags().GetString(commonParams.ApplicationName)
+	if applicationID != "" {
+		application, errorModel, err := applicationsWrapper.GetByID(applicationID)
+		if err != nil {
+			return nil, err
+		}
+		if errorModel != nil {
+			return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingApplication, errorModel.Code, errorModel.Message)
+		}
+		return application, nil
+	}
+	if applicationName == "" {
+		return nil, errors.Errorf(applicationMissingIdentifier, failedGettingApplication)
+	}
+	application, err := getApplication(applicationName, applicationsWrapper)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, errors.Errorf(errorConstants.ApplicationDoesntExistOrNoPermission)
+	}
+	return application, nil
+}
+
+func parseApplicationRules(ruleList []string) ([]wrappers.Rule, error) {
+	rules := []wrappers.Rule{}
+	for _, rule := range ruleList {
+		ruleType, value, found := strings.Cut(rule, applicationRuleSeparator)
+		if !found || strings.TrimSpace(ruleType) == "" || value == "" {
+			return nil, errors.Errorf(applicationInvalidRule, rule)
+		}
+		rules = append(rules, wrappers.Rule{Type: strings.TrimSpace(ruleType), Value: value})
+	}
+	return rules, nil
+}
+
+// updateApplicationRules replaces, adds and removes rules in that order, like the tags of project update
+func updateApplicationRules(cmd *cobra.Command, current []wrappers.Rule) ([]wrappers.Rule, error) {
+	rules := append([]wrappers.Rule{}, current...)
+	if cmd.Flags().Changed(commonParams.RuleFlag) {
+		ruleList, _ := cmd.Flags().GetStringSlice(commonParams.RuleFlag)
+		replaced, err := parseApplicationRules(ruleList)
+		if err != nil {
+			return nil, err
+		}
+		rules = replaced
+	}
+	addRules, _ := cmd.Flags().GetStringSlice(commonParams.AddRuleFlag)
+	added, err := parseApplicationRules(addRules)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range added {
+		if !containsRule(rules, rule) {
+			rules = append(rules, rule)
+		}
+	}
+	removeRules, _ := cmd.Flags().GetStringSlice(commonParams.RemoveRuleFlag)
+	for _, remove := range removeRules {
+		ruleType, value, _ := strings.Cut(remove, applicationRuleSeparator)
+		rules = slices.DeleteFunc(rules, func(rule wrappers.Rule) bool {
+			return (rule.ID != "" && rule.ID == remove) || (rule.Type == strings.TrimSpace(ruleType) && rule.Value == value)
+		})
+	}
+	return rules, nil
+}
+
+func containsRule(rules []wrappers.Rule, rule wrappers.Rule) bool {
+	for _, existing := range rules {
+		if existing.Type == rule.Type && existing.Value == rule.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func toApplicationView(application *wrappers.Application) applicationView {
+	rules := make([]string, 0, len(application.Rules))
+	for _, rule := range application.Rules {
+		rules = append(rules, rule.Type+applicationRuleSeparator+rule.Value)
+	}
+	return applicationView{
+		ID:          application.ID,
+		Name:        application.Name,
+		Description: application.Description,
+		Criticality: application.Criticality,
+		Rules:       rules,
+		Tags:        application.Tags,
+		ProjectIds:  application.ProjectIds,
+		CreatedAt:   application.CreatedAt,
+	}
+}
+
+func toApplicationSummaryViews(summary *wrappers.PortfolioSummary) []applicationSummaryView {
+	views := make([]applicationSummaryView, 0, len(summary.Projects)+1)
+	for _, project := range summary.Projects {
+		views = append(views, applicationSummaryView{
+			ProjectID:    project.ProjectID,
+			ProjectName:  project.ProjectName,
+			Branch:       project.BranchName,
+			ScanID:       project.ScanID,
+			Critical:     project.CriticalIssues,
+			High:         project.HighIssues,
+			Medium:       project.MediumIssues,
+			Low:          project.LowIssues,
+			Info:         project.InfoIssues,
+			Total:        project.TotalIssues,
+			PolicyStatus: project.PolicyStatus,
+			Error:        project.Error,
+		})
+	}
+	return append(views, applicationSummaryView{
+		ProjectName: applicationSummaryTotal,
+		ScanID:      fmt.Sprintf("%d of %d scanned", summary.ScannedProjects, summary.TotalProjects),
+		Critical:    summary.CriticalIssues,
+		High:        summary.HighIssues,
+		Medium:      summary.MediumIssues,
+		Low:         summary.LowIssues,
+		Info:        summary.InfoIssues,
+		Total:       summary.TotalIssues,
+	})
+}
+
+type applicationView struct {
+	ID          string `format:"name:Application ID"`
+	Name        string
+	Description string
+	Criticality int
+	Rules       []string
+	Tags        map[string]string
+	ProjectIds  []string  `format:"name:Project IDs"`
+	CreatedAt   time.Time `format:"name:Created at;time:01-02-06 15:04:05"`
+}
+
+type applicationProjectView struct {
+	ProjectID   string `format:"name:Project ID"`
+	ProjectName string `format:"name:Project Name"`
+	Status      string
+}
+
+type applicationSummaryView struct {
+	ProjectID    string `format:"name:Project ID"`
+	ProjectName  string `format:"name:Project Name"`
+	Branch       string
+	ScanID       string `format:"name:Scan ID"`
+	Critical     int
+	High         int
+	Medium       int
+	Low          int
+	Info         int
+	Total        int
+	PolicyStatus string `format:"name:Policy Status"`
+	Error        string
+}
@@ -0,0 +1,123 @@
+//go:build !integration
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+// associatedProjectsWrapper records the application IDs sent on project update
+type associatedProjectsWrapper struct {
+	mock.ProjectsMockWrapper
+	applicationIds map[string][]string
+}
+
+func (p *associatedProjectsWrapper) GetByID(projectID string) (*wrappers.ProjectResponseModel, *wrappers.ErrorModel, error) {
+	return &wrappers.ProjectResponseModel{ID: projectID, Name: projectID, ApplicationIds: []string{"other", "mockID"}}, nil, nil
+}
+
+func (p *associatedProjectsWrapper) Update(projectID string, model *wrappers.Project) error {
+	p.applicationIds[projectID] = model.ApplicationIds
+	return nil
+}
+
+func TestApplicationHelp(t *testing.T) {
+	execCmdNilAssertion(t, "help", "application")
+}
+
+func TestApplicationCreate(t *testing.T) {
+	execCmdNilAssertion(t, "application", "create", "--application-name", "payments", "--criticality", "1",
+		"--rule", "project.tag.key.exists=team", "--tags", "team:payments")
+}
+
+func TestApplicationCreateInvalid(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "application", "create", "--application-name", "payments", "--criticality", "4")
+	assert.ErrorContains(t, err, "--criticality should be between 1 and 3")
+
+	err = execCmdNotNilAssertion(t, "application", "create", "--application-name", "payments", "--rule", "project.name.in")
+	assert.ErrorContains(t, err, "invalid rule project.name.in")
+
+	err = execCmdNotNilAssertion(t, "application", "create", "--application-name", mock.FakeBadRequest400)
+	assert.ErrorContains(t, err, failedCreatingApplication)
+}
+
+func TestApplicationListAndShow(t *testing.T) {
+	execCmdNilAssertion(t, "application", "list", "--format", "json", "--filter", "name=MOCK")
+	execCmdNilAssertion(t, "application", "show", "--application-name", "MOCK")
+	execCmdNilAssertion(t, "application", "show", "--application-id", "mockID", "--format", "list")
+
+	err := execCmdNotNilAssertion(t, "application", "show")
+	assert.ErrorContains(t, err, "Please provide an application ID or name")
+	err = execCmdNotNilAssertion(t, "application", "show", "--application-id", mock.ApplicationDoesntExist)
+	assert.ErrorContains(t, err, "does not exist")
+}
+
+func TestApplicationUpdateAndDelete(t *testing.T) {
+	execCmdNilAssertion(t, "application", "update", "--application-id", "mockID", "--criticality", "2",
+		"--add-rule", "project.name.in=web;api", "--add-tags", "pci", "--description", "Payments")
+	execCmdNilAssertion(t, "application", "delete", "--application-name", "MOCK")
+}
+
+func TestUpdateApplicationRules(t *testing.T) {
+	cmd := NewApplicationCommand(&mock.ApplicationsMockWrapper{}, &mock.ProjectsMockWrapper{}, &mock.ResultsMockWrapper{},
+		&mock.ScansMockWrapper{}, &mock.PolicyMockWrapper{})
+	updateCmd, _, err := cmd.Find([]string{"update"})
+	assert.NilError(t, err)
+	assert.NilError(t, updateCmd.ParseFlags([]string{
+		"--add-rule", "project.name.in=web;api", "--add-rule", "project.tag.key.exists=team", "--remove-rule", "R1",
+	}))
+
+	rules, err := updateApplicationRules(updateCmd, []wrappers.Rule{
+		{ID: "R1", Type: "project.name.starts-with", Value: "web-"},
+		{ID: "R2", Type: "project.tag.key.exists", Value: "team"},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, rules, []wrappers.Rule{
+		{ID: "R2", Type: "project.tag.key.exists", Value: "team"},
+		{Type: "project.name.in", Value: "web;api"},
+	})
+}
+
+func TestApplicationProjects(t *testing.T) {
+	execCmdNilAssertion(t, "application", "add-project", "--application-name", "MOCK", "--project-name", "web,api")
+
+	projectsWrapper := &associatedProjectsWrapper{applicationIds: make(map[string][]string)}
+	view, err := updateProjectApplication(projectsWrapper, "P1", "mockID", true)
+	assert.NilError(t, err)
+	assert.Equal(t, view.Status, applicationProjectUnchanged)
+
+	view, err = updateProjectApplication(projectsWrapper, "P1", "mockID", false)
+	assert.NilError(t, err)
+	assert.Equal(t, view.Status, applicationProjectDisassociated)
+	assert.DeepEqual(t, projectsWrapper.applicationIds["P1"], []string{"other"})
+
+	view, err = updateProjectApplication(projectsWrapper, "P2", "new", true)
+	assert.NilError(t, err)
+	assert.Equal(t, view.Status, applicationProjectAssociated)
+	assert.DeepEqual(t, projectsWrapper.applicationIds["P2"], []string{"other", "mockID", "new"})
+
+	err = execCmdNotNilAssertion(t, "application", "remove-project", "--application-id", "mockID")
+	assert.ErrorContains(t, err, "Please provide project IDs or names")
+}
+
+func TestApplicationSummary(t *testing.T) {
+	execCmdNilAssertion(t, "application", "summary", "--application-name", "MOCK", "--format", "json")
+}
+
+func TestToApplicationSummaryViews(t *testing.T) {
+	views := toApplicationSummaryViews(&wrappers.PortfolioSummary{
+		TotalProjects: 2, ScannedProjects: 1, HighIssues: 3, TotalIssues: 3,
+		Projects: []*wrappers.PortfolioProjectSummary{
+			{ProjectID: "P1", ProjectName: "web", HighIssues: 3, TotalIssues: 3},
+			{ProjectID: "P2", ProjectName: "api", Error: portfolioNoScanMessage},
+		},
+	})
+	assert.Equal(t, len(views), 3)
+	assert.Equal(t, views[2].ProjectName, applicationSummaryTotal)
+	assert.Equal(t, views[2].ScanID, "1 of 2 scanned")
+	assert.Equal(t, views[2].High, 3)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/MakeNowJust/heredoc"
 	"github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -119,8 +120,8 @@ func NewAuthCommand(authWrapper wrappers.AuthWrapper) *cobra.Command {
 func validLogin() func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		clientID := viper.GetString(params.AccessKeyIDConfigKey)
-		clientSecret := viper.GetString(params.AccessKeySecretConfigKey)
-		apiKey := viper.GetString(params.AstAPIKey)
+		clientSecret := configuration.GetCredential(params.AccessKeySecretConfigKey)
+		apiKey := configuration.GetCredential(params.AstAPIKey)
 		if (clientID != "" && clientSecret != "") || apiKey != "" {
 			authWrapper := wrappers.NewAuthHTTPWrapper()
 			authWrapper.SetPath(viper.GetString(params.ScansPathKey))
@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const failedExportingTriage = "Failed exporting triage decisions"
+
+// triageCSVHeader is shared with triage import, so an exported file can be applied to another project
+var triageCSVHeader = []string{"projectId", "similarityId", "scanType", "state", "severity", "comment"}
+
+func triageExportSubCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+) *cobra.Command {
+	triageExportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the predicates of the triaged results of a project",
+		Long: "The export command reads the predicate history of every triaged result of the latest completed scan of a project. " +
+			"A JSON file keeps the whole history, a CSV file keeps the current decisions in the triage import layout.",
+		Example: heredoc.Doc(
+			`
+			$ cx triage export --project-id <ProjectID> --file triage.json
+			$ cx triage export --project-id <ProjectID> --branch main --file decisions.csv
+		`,
+		),
+		RunE: runTriageExport(resultsPredicatesWrapper, resultsWrapper, scanWrapper),
+	}
+	triageExportCmd.PersistentFlags().String(params.ProjectIDFlag, "", "Project ID.")
+	triageExportCmd.PersistentFlags().String(params.BranchFlag, "", "Branch of the scan to read the results from, the latest scan of the project by default")
+	triageExportCmd.PersistentFlags().String(params.TriageFileFlag, "", "JSON or CSV file to write, the JSON export is printed when not set")
+	triageExportCmd.PersistentFlags().Int(params.ConcurrencyFlag, triageDefaultConcurrency, "Number of predicate histories read concurrently")
+	markFlagAsRequired(triageExportCmd, params.ProjectIDFlag)
+	return triageExportCmd
+}
+
+func runTriageExport(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		projectID, _ := cmd.Flags().GetString(params.ProjectIDFlag)
+		branch, _ := cmd.Flags().GetString(params.BranchFlag)
+		file, _ := cmd.Flags().GetString(params.TriageFileFlag)
+		concurrency, _ := cmd.Flags().GetInt(params.ConcurrencyFlag)
+		if concurrency <= 0 {
+			return errors.Errorf("--%s should be higher than 0", params.ConcurrencyFlag)
+		}
+		export, err := getTriageExport(resultsPredicatesWrapper, resultsWrapper, scanWrapper, projectID, branch, concurrency)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedExportingTriage)
+		}
+		if file == "" {
+			return printer.Print(cmd.OutOrStdout(), export, printer.FormatJSON)
+		}
+		if strings.EqualFold(filepath.Ext(file), "."+printer.FormatCSV) {
+			err = writeTriageCSV(file, export)
+		} else {
+			err = writeJSONReport(file, export)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedExportingTriage)
+		}
+		return nil
+	}
+}
+
+// getTriageableResults reads the sast and kics results of the latest completed scan of a project, once per similarity id
+func getTriageableResults(
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+	projectID, branch string,
+) (*wrappers.ScanResponseModel, []*wrappers.ScanResult, error) {
+	scan, err := getLatestScan(scanWrapper, projectID, branch)
+	if err != nil {
+		return nil, nil, err
+	}
+	if scan == nil {
+		return nil, nil, errors.Errorf("no completed scan found for project %s", projectID)
+	}
+	seen := make(map[string]bool)
+	var results []*wrappers.ScanResult
+	err = forEachResultsPage(resultsWrapper, map[string]string{params.ScanIDQueryParam: scan.ID}, func(page *wrappers.ScanResultsCollection) error {
+		results = appendTriageableResults(results, page.Results, seen)
+		return nil
+	})
+	return scan, results, err
+}
+
+// appendTriageableResults appends the sast and kics results whose similarity id wasn't seen yet
+func appendTriageableResults(results, page []*wrappers.ScanResult, seen map[string]bool) []*wrappers.ScanResult {
+	for _, result := range page {
+		if result.SimilarityID == "" || !isTriageableType(result.Type) || seen[resultMatchKey(result)] {
+			continue
+		}
+		seen[resultMatchKey(result)] = true
+		results = append(results, result)
+	}
+	return results
+}
+
+func isTriageableType(resultType string) bool {
+	return strings.EqualFold(resultType, params.SastType) || strings.EqualFold(resultType, params.KicsType)
+}
+
+// getTriageExport reads the predicate history of the results of the latest scan of a project, keeping the triaged ones
+func getTriageExport(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+	projectID, branch string,
+	concurrency int,
+) (*wrappers.TriageExport, error) {
+	scan, results, err := getTriageableResults(resultsWrapper, scanWrapper, projectID, branch)
+	if err != nil {
+		return nil, err
+	}
+	return getTriagedResults(resultsPredicatesWrapper, scan, results, projectID, concurrency)
+}
+
+// getTriagedResults reads the predicate history of the given results of a scan, keeping the triaged ones
+func getTriagedResults(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	scan *wrappers.ScanResponseModel,
+	results []*wrappers.ScanResult,
+	projectID string,
+	concurrency int,
+) (*wrappers.TriageExport, error) {
+	triaged := make([]*wrappers.TriagedResult, len(results))
+	var firstErr error
+	var mutex sync.Mutex
+	runConcurrently(len(results), concurrency, func(i int) {
+		var vulnerability *wrappers.PackageVulnerability
+		var predicates []wrappers.Predicate
+		var predicatesErr error
+		if isVulnerabilityScanType(results[i].Type) {
+			vulnerability = vulnerabilityFromResult(results[i])
+			predicates, predicatesErr = getVulnerabilityPredicateHistory(resultsPredicatesWrapper, vulnerability, projectID, results[i].Type)
+		} else {
+			predicates, predicatesErr = getPredicateHistory(resultsPredicatesWrapper, results[i].SimilarityID, projectID, results[i].Type)
+		}
+		if predicatesErr != nil {
+			mutex.Lock()
+			if firstErr == nil {
+				firstErr = predicatesErr
+			}
+			mutex.Unlock()
+			return
+		}
+		if len(predicates) > 0 {
+			triaged[i] = &wrappers.TriagedResult{
+				SimilarityID:  results[i].SimilarityID,
+				ScanType:      results[i].Type,
+				Vulnerability: vulnerability,
+				Predicates:    predicates,
+			}
+		}
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	export := &wrappers.TriageExport{ProjectID: projectID, ScanID: scan.ID, Branch: scan.Branch, Results: []wrappers.TriagedResult{}}
+	for _, result := range triaged {
+		if result != nil {
+			export.Results = append(export.Results, *result)
+		}
+	}
+	sort.SliceStable(export.Results, func(i, j int) bool {
+		if export.Results[i].ScanType != export.Results[j].ScanType {
+			return export.Results[i].ScanType < export.Results[j].ScanType
+		}
+		return export.Results[i].SimilarityID < export.Results[j].SimilarityID
+	})
+	return export, nil
+}
+
+// getPredicateHistory returns the predicates of a result in the project, oldest first
+func getPredicateHistory(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	similarityID, projectID, scanType string,
+) ([]wrappers.Predicate, error) {
+	predicatesCollection, webError, err := resultsPredicatesWrapper.GetAllPredicatesForSimilarityID(similarityID, projectID, scanType)
+	return projectPredicateHistory(predicatesCollection, webError, err, projectID)
+}
+
+// getVulnerabilityPredicateHistory returns the predicates of an SCA or container vulnerability in the project, oldest first
+func getVulnerabilityPredicateHistory(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	vulnerability *wrappers.PackageVulnerability,
+	projectID, scanType string,
+) ([]wrappers.Predicate, error) {
+	predicatesCollection, webError, err := resultsPredicatesWrapper.GetAllPredicatesForVulnerability(vulnerability, projectID, scanType)
+	return projectPredicateHistory(predicatesCollection, webError, err, projectID)
+}
+
+func projectPredicateHistory(
+	predicatesCollection *wrappers.PredicatesCollectionResponseModel,
+	webError *wrappers.WebError,
+	err error,
+	projectID string,
+) ([]wrappers.Predicate, error) {
+	if err != nil {
+		return nil, err
+	}
+	if webError != nil {
+		return nil, errors.Errorf("CODE: %d, %s", webError.Code, webError.Message)
+	}
+	if predicatesCollection == nil {
+		return nil, nil
+	}
+	var predicates []wrappers.Predicate
+	for i := range predicatesCollection.PredicateHistoryPerProject {
+		history := &predicatesCollection.PredicateHistoryPerProject[i]
+		if history.ProjectID == "" || history.ProjectID == projectID {
+			predicates = append(predicates, history.Predicates...)
+		}
+	}
+	sort.SliceStable(predicates, func(i, j int) bool {
+		return predicates[i].CreatedAt.Before(predicates[j].CreatedAt)
+	})
+	return predicates, nil
+}
+
+func writeTriageCSV(file string, export *wrappers.TriageExport) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	writer := csv.NewWriter(f)
+	err = writer.Write(triageCSVHeader)
+	if err != nil {
+		return err
+	}
+	for i := range export.Results {
+		result := &export.Results[i]
+		current := latestPredicate(result.Predicates)
+		err = writer.Write([]string{export.ProjectID, result.SimilarityID, result.ScanType, current.State, current.Severity, current.Comment})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,338 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedImportingTriage    = "Failed importing triage decisions"
+	triageDefaultConcurrency = 4
+	triageStatusUpdated      = "UPDATED"
+	triageStatusWouldUpdate  = "WOULD_UPDATE"
+	triageStatusUnchanged    = "UNCHANGED"
+	triageStatusInvalid      = "INVALID"
+	triageStatusFailed       = "FAILED"
+	triageImportFailedRows   = "%s: %d of %d rows failed"
+)
+
+var (
+	triageStates     = []string{"TO_VERIFY", "NOT_EXPLOITABLE", "PROPOSED_NOT_EXPLOITABLE", "CONFIRMED", "URGENT"}
+	triageSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO"}
+	// triageImportColumns maps the accepted column names to the json keys of a decision
+	triageImportColumns = map[string]string{
+		"projectid":     "projectId",
+		"project-id":    "projectId",
+		"similarityid":  "similarityId",
+		"similarity-id": "similarityId",
+		"scantype":      "scanType",
+		"scan-type":     "scanType",
+		"state":         "state",
+		"severity":      "severity",
+		"comment":       "comment",
+	}
+)
+
+// triageDecision is a row of the imported file
+type triageDecision struct {
+	ProjectID    string `json:"projectId"`
+	SimilarityID string `json:"similarityId"`
+	ScanType     string `json:"scanType"`
+	State        string `json:"state"`
+	Severity     string `json:"severity"`
+	Comment      string `json:"comment"`
+}
+
+type triageImportView struct {
+	Row          int    `format:"name:Row"`
+	ProjectID    string `format:"name:Project ID"`
+	SimilarityID string `format:"name:Similarity ID"`
+	ScanType     string `format:"name:Scan Type"`
+	Status       string
+	Changes      string
+	Error        string
+}
+
+func triageImportSubCommand(resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) *cobra.Command {
+	triageImportCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Apply the state, severity and comment changes of a CSV or JSON file",
+		Long: "The import command applies many triage decisions across projects and engines. Every row is compared with the " +
+			"current predicate of the issue, and the outcome of every row is reported.",
+		Example: heredoc.Doc(
+			`
+			$ cx triage import --file decisions.csv --dry-run
+			$ cx triage import --file decisions.json --concurrency 8 --format json
+		`,
+		),
+		RunE: runTriageImport(resultsPredicatesWrapper, featureFlagsWrapper),
+	}
+	triageImportCmd.PersistentFlags().String(params.TriageFileFlag, "",
+		"CSV or JSON file with the projectId, similarityId, scanType, state, severity and comment of every decision")
+	triageImportCmd.PersistentFlags().Bool(params.DryRunFlag, false, "Report the changes against the current predicates without applying them")
+	triageImportCmd.PersistentFlags().Int(params.ConcurrencyFlag, triageDefaultConcurrency, "Number of decisions applied concurrently")
+	markFlagAsRequired(triageImportCmd, params.TriageFileFlag)
+	return triageImportCmd
+}
+
+func runTriageImport(resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		file, _ := cmd.Flags().GetString(params.TriageFileFlag)
+		dryRun, _ := cmd.Flags().GetBool(params.DryRunFlag)
+		concurrency, _ := cmd.Flags().GetInt(params.ConcurrencyFlag)
+		if concurrency <= 0 {
+			return errors.Errorf("--%s should be higher than 0", params.ConcurrencyFlag)
+		}
+		decisions, err := readTriageDecisions(file)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedImportingTriage)
+		}
+		flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.CVSSV3Enabled)
+
+		views := applyTriageDecisions(resultsPredicatesWrapper, decisions, flagResponse.Status, dryRun, concurrency)
+		err = printByFormat(cmd, views)
+		if err != nil {
+			return err
+		}
+		failedRows := 0
+		for i := range views {
+			if views[i].Status == triageStatusInvalid || views[i].Status == triageStatusFailed {
+				failedRows++
+			}
+		}
+		if failedRows > 0 {
+			return errors.Errorf(triageImportFailedRows, failedImportingTriage, failedRows, len(views))
+		}
+		return nil
+	}
+}
+
+func readTriageDecisions(file string) ([]*triageDecision, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if strings.EqualFold(filepath.Ext(file), "."+printer.FormatJSON) {
+		var decisions []*triageDecision
+		err = json.NewDecoder(f).Decode(&decisions)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid json file %s", file)
+		}
+		return decisions, nil
+	}
+	return readTriageDecisionsCSV(f)
+}
+
+func readTriageDecisionsCSV(reader io.Reader) ([]*triageDecision, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid csv header")
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		key, ok := triageImportColumns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, errors.Errorf("unknown csv column %s", name)
+		}
+		columns[i] = key
+	}
+	var decisions []*triageDecision
+	for {
+		record, readErr := csvReader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, errors.Wrapf(readErr, "invalid csv file")
+		}
+		values := make(map[string]string, len(record))
+		for i, value := range record {
+			values[columns[i]] = strings.TrimSpace(value)
+		}
+		decisions = append(decisions, &triageDecision{
+			ProjectID:    values["projectId"],
+			SimilarityID: values["similarityId"],
+			ScanType:     values["scanType"],
+			State:        values["state"],
+			Severity:     values["severity"],
+			Comment:      values["comment"],
+		})
+	}
+	return decisions, nil
+}
+
+// applyTriageDecisions applies the decisions with at most concurrency requests in flight, the views keep the file order
+func applyTriageDecisions(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	decisions []*triageDecision,
+	criticalEnabled, dryRun bool,
+	concurrency int,
+) []triageImportView {
+	views := make([]triageImportView, len(decisions))
+	runConcurrently(len(decisions), concurrency, func(row int) {
+		views[row] = applyTriageDecision(resultsPredicatesWrapper, decisions[row], criticalEnabled, dryRun)
+		views[row].Row = row + 1
+	})
+	return views
+}
+
+// runConcurrently calls run for every index below count, with at most concurrency calls running at once
+func runConcurrently(count, concurrency int, run func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				run(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+func applyTriageDecision(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	decision *triageDecision,
+	criticalEnabled, dryRun bool,
+) triageImportView {
+	view := triageImportView{ProjectID: decision.ProjectID, SimilarityID: decision.SimilarityID, ScanType: decision.ScanType}
+	err := validateTriageDecision(decision, criticalEnabled)
+	if err != nil {
+		view.Status = triageStatusInvalid
+		view.Error = err.Error()
+		return view
+	}
+
+	current, err := getCurrentPredicate(resultsPredicatesWrapper, decision)
+	if err != nil {
+		view.Status = triageStatusFailed
+		view.Error = err.Error()
+		return view
+	}
+	predicate := &wrappers.PredicateRequest{
+		SimilarityID: decision.SimilarityID,
+		ProjectID:    decision.ProjectID,
+		State:        strings.ToUpper(decision.State),
+		Severity:     strings.ToUpper(decision.Severity),
+		Comment:      decision.Comment,
+	}
+	var changes []string
+	if current != nil {
+		if predicate.State == "" {
+			predicate.State = current.State
+		}
+		if predicate.Severity == "" {
+			predicate.Severity = current.Severity
+		}
+	}
+	if predicate.State == "" || predicate.Severity == "" {
+		view.Status = triageStatusInvalid
+		view.Error = "state and severity are required when the issue has no predicate"
+		return view
+	}
+	currentState, currentSeverity := "", ""
+	if current != nil {
+		currentState, currentSeverity = current.State, current.Severity
+	}
+	if !strings.EqualFold(currentState, predicate.State) {
+		changes = append(changes, fmt.Sprintf("state: %s -> %s", currentState, predicate.State))
+	}
+	if !strings.EqualFold(currentSeverity, predicate.Severity) {
+		changes = append(changes, fmt.Sprintf("severity: %s -> %s", currentSeverity, predicate.Severity))
+	}
+	if predicate.Comment != "" {
+		changes = append(changes, "comment: "+predicate.Comment)
+	}
+	view.Changes = strings.Join(changes, "; ")
+	if len(changes) == 0 {
+		view.Status = triageStatusUnchanged
+		return view
+	}
+	if dryRun {
+		view.Status = triageStatusWouldUpdate
+		return view
+	}
+
+	webError, err := resultsPredicatesWrapper.PredicateSeverityAndState(predicate, decision.ScanType)
+	if err == nil && webError != nil {
+		err = errors.Errorf("CODE: %d, %s", webError.Code, webError.Message)
+	}
+	if err != nil {
+		view.Status = triageStatusFailed
+		view.Error = err.Error()
+		return view
+	}
+	view.Status = triageStatusUpdated
+	return view
+}
+
+func validateTriageDecision(decision *triageDecision, criticalEnabled bool) error {
+	var missing []string
+	if decision.ProjectID == "" {
+		missing = append(missing, "projectId")
+	}
+	if decision.SimilarityID == "" {
+		missing = append(missing, "similarityId")
+	}
+	if decision.ScanType == "" {
+		missing = append(missing, "scanType")
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+	if decision.State == "" && decision.Severity == "" && decision.Comment == "" {
+		return errors.New("nothing to change, set a state, a severity or a comment")
+	}
+	if decision.State != "" && !slices.Contains(triageStates, strings.ToUpper(decision.State)) {
+		return errors.Errorf("invalid state %s, use one of %s", decision.State, strings.Join(triageStates, ", "))
+	}
+	if decision.Severity != "" && !slices.Contains(triageSeverities, strings.ToUpper(decision.Severity)) {
+		return errors.Errorf("invalid severity %s, use one of %s", decision.Severity, strings.Join(triageSeverities, ", "))
+	}
+	if !criticalEnabled && strings.EqualFold(decision.Severity, criticalLabel) {
+		return errors.New("critical severity is not available for your tenant")
+	}
+	return nil
+}
+
+// getCurrentPredicate returns the latest predicate of the issue, nil when it was never triaged
+func getCurrentPredicate(resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper, decision *triageDecision) (*wrappers.Predicate, error) {
+	predicates, err := getPredicateHistory(resultsPredicatesWrapper, decision.SimilarityID, decision.ProjectID, decision.ScanType)
+	if err != nil {
+		return nil, err
+	}
+	return latestPredicate(predicates), nil
+}
+
+func latestPredicate(predicates []wrappers.Predicate) *wrappers.Predicate {
+	var latest *wrappers.Predicate
+	for i := range predicates {
+		if latest == nil || predicates[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &predicates[i]
+		}
+	}
+	return latest
+}
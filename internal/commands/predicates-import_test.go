@@ -0,0 +1,107 @@
+//go:build !integration
+
+package commands
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+// triagedPredicatesWrapper keeps the last predicate of every similarity id
+type triagedPredicatesWrapper struct {
+	mock.ResultsPredicatesMockWrapper
+	mutex   sync.Mutex
+	current map[string]wrappers.BasePredicate
+	updates int
+}
+
+func (r *triagedPredicatesWrapper) PredicateSeverityAndState(predicate *wrappers.PredicateRequest, _ string) (*wrappers.WebError, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.updates++
+	return nil, nil
+}
+
+func (r *triagedPredicatesWrapper) GetAllPredicatesForSimilarityID(similarityID, projectID, _ string) (
+	*wrappers.PredicatesCollectionResponseModel, *wrappers.WebError, error,
+) {
+	current, ok := r.current[similarityID]
+	if !ok {
+		return &wrappers.PredicatesCollectionResponseModel{}, nil, nil
+	}
+	return &wrappers.PredicatesCollectionResponseModel{
+		PredicateHistoryPerProject: []wrappers.PredicateHistory{{
+			ProjectID:    projectID,
+			SimilarityID: similarityID,
+			Predicates: []wrappers.Predicate{
+				{BasePredicate: wrappers.BasePredicate{State: "TO_VERIFY", Severity: "LOW"}, CreatedAt: time.Unix(0, 0)},
+				{BasePredicate: current, CreatedAt: time.Unix(1, 0)},
+			},
+		}},
+	}, nil, nil
+}
+
+func TestReadTriageDecisionsCSV(t *testing.T) {
+	decisions, err := readTriageDecisionsCSV(strings.NewReader(
+		"Project-ID, similarityId,SCAN-TYPE,state,severity,comment\nP1, 123,sast,confirmed,,\"checked, real\"\n"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(decisions), 1)
+	assert.DeepEqual(t, *decisions[0], triageDecision{
+		ProjectID: "P1", SimilarityID: "123", ScanType: "sast", State: "confirmed", Comment: "checked, real",
+	})
+
+	_, err = readTriageDecisionsCSV(strings.NewReader("projectId,owner\nP1,me\n"))
+	assert.ErrorContains(t, err, "unknown csv column owner")
+}
+
+func TestValidateTriageDecision(t *testing.T) {
+	valid := triageDecision{ProjectID: "P1", SimilarityID: "1", ScanType: "sast", State: "urgent", Severity: "high"}
+	assert.NilError(t, validateTriageDecision(&valid, false))
+
+	invalid := map[string]triageDecision{
+		"missing projectId, scanType": {SimilarityID: "1", State: "URGENT"},
+		"nothing to change":           {ProjectID: "P1", SimilarityID: "1", ScanType: "sast"},
+		"invalid state FIXED":         {ProjectID: "P1", SimilarityID: "1", ScanType: "sast", State: "FIXED"},
+		"invalid severity BLOCKER":    {ProjectID: "P1", SimilarityID: "1", ScanType: "sast", Severity: "BLOCKER"},
+		"critical severity":           {ProjectID: "P1", SimilarityID: "1", ScanType: "sast", Severity: "critical"},
+	}
+	for expected, decision := range invalid {
+		decision := decision
+		assert.ErrorContains(t, validateTriageDecision(&decision, false), expected)
+	}
+}
+
+func TestApplyTriageDecisions(t *testing.T) {
+	predicatesWrapper := &triagedPredicatesWrapper{current: map[string]wrappers.BasePredicate{
+		"1": {State: "TO_VERIFY", Severity: "HIGH"},
+		"2": {State: "CONFIRMED", Severity: "HIGH"},
+	}}
+	decisions := []*triageDecision{
+		{ProjectID: "P1", SimilarityID: "1", ScanType: "sast", State: "confirmed"},
+		{ProjectID: "P1", SimilarityID: "2", ScanType: "sast", State: "CONFIRMED", Severity: "high"},
+		{ProjectID: "P1", SimilarityID: "3", ScanType: "kics", State: "URGENT"},
+		{ProjectID: "P1", SimilarityID: "4", ScanType: "kics", State: "URGENT", Severity: "MEDIUM"},
+		{ProjectID: "", SimilarityID: "5", ScanType: "kics", State: "URGENT"},
+	}
+
+	views := applyTriageDecisions(predicatesWrapper, decisions, false, true, 2)
+	assert.Equal(t, predicatesWrapper.updates, 0)
+	assert.Equal(t, views[0].Row, 1)
+	assert.Equal(t, views[0].Status, triageStatusWouldUpdate)
+	assert.Equal(t, views[0].Changes, "state: TO_VERIFY -> CONFIRMED")
+	assert.Equal(t, views[1].Status, triageStatusUnchanged)
+	assert.Equal(t, views[2].Status, triageStatusInvalid)
+	assert.Equal(t, views[3].Changes, "state:  -> URGENT; severity:  -> MEDIUM")
+	assert.Equal(t, views[4].Status, triageStatusInvalid)
+
+	views = applyTriageDecisions(predicatesWrapper, decisions, false, false, 3)
+	assert.Equal(t, predicatesWrapper.updates, 2)
+	assert.Equal(t, views[0].Status, triageStatusUpdated)
+	assert.Equal(t, views[3].Status, triageStatusUpdated)
+}
@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedPropagatingTriage = "Failed propagating triage decisions"
+	triageConflictSkip      = "skip"
+	triageConflictOverwrite = "overwrite"
+	triageConflictNewest    = "newest"
+	triageStatusNotFound    = "NOT_FOUND"
+	triageStatusConflict    = "CONFLICT"
+	triagePropagatedComment = "Propagated from project %s"
+	triagePropagateFailed   = "%s: %d of %d decisions failed"
+)
+
+var triageConflictModes = []string{triageConflictSkip, triageConflictOverwrite, triageConflictNewest}
+
+type triagePropagateView struct {
+	SimilarityID   string `format:"name:Similarity ID"`
+	ScanType       string `format:"name:Scan Type"`
+	SourceState    string `format:"name:Source State"`
+	SourceSeverity string `format:"name:Source Severity"`
+	TargetState    string `format:"name:Target State"`
+	TargetSeverity string `format:"name:Target Severity"`
+	Status         string
+	Error          string
+}
+
+func triagePropagateSubCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+) *cobra.Command {
+	triagePropagateCmd := &cobra.Command{
+		Use:   "propagate",
+		Short: "Apply the triage decisions of a project to the matching results of another project",
+		Long: "The propagate command applies the current predicate of every triaged result of the source project to the result " +
+			"with the same similarity ID in the latest scan of the target project, for example after a fork or a renamed repository.",
+		Example: heredoc.Doc(
+			`
+			$ cx triage propagate --from-project <ProjectID> --to-project <ProjectID> --dry-run
+			$ cx triage propagate --from-project <ProjectID> --to-project <ProjectID> --on-conflict newest --format json
+		`,
+		),
+		RunE: runTriagePropagate(resultsPredicatesWrapper, resultsWrapper, scanWrapper),
+	}
+	triagePropagateCmd.PersistentFlags().String(params.FromProjectFlag, "", "ID of the project to read the decisions from")
+	triagePropagateCmd.PersistentFlags().String(params.ToProjectFlag, "", "ID of the project to apply the decisions to")
+	triagePropagateCmd.PersistentFlags().String(params.FromBranchFlag, "", "Branch of the source project scan, the latest scan of the project by default")
+	triagePropagateCmd.PersistentFlags().String(params.ToBranchFlag, "", "Branch of the target project scan, the latest scan of the project by default")
+	triagePropagateCmd.PersistentFlags().String(params.OnConflictFlag, triageConflictSkip,
+		"What to do when the target result was triaged differently: skip, overwrite, or newest to keep the latest decision")
+	triagePropagateCmd.PersistentFlags().Bool(params.DryRunFlag, false, "Report the changes without applying them")
+	triagePropagateCmd.PersistentFlags().Int(params.ConcurrencyFlag, triageDefaultConcurrency, "Number of decisions applied concurrently")
+	markFlagAsRequired(triagePropagateCmd, params.FromProjectFlag)
+	markFlagAsRequired(triagePropagateCmd, params.ToProjectFlag)
+	return triagePropagateCmd
+}
+
+func runTriagePropagate(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		fromProject, _ := cmd.Flags().GetString(params.FromProjectFlag)
+		toProject, _ := cmd.Flags().GetString(params.ToProjectFlag)
+		fromBranch, _ := cmd.Flags().GetString(params.FromBranchFlag)
+		toBranch, _ := cmd.Flags().GetString(params.ToBranchFlag)
+		onConflict, _ := cmd.Flags().GetString(params.OnConflictFlag)
+		dryRun, _ := cmd.Flags().GetBool(params.DryRunFlag)
+		concurrency, _ := cmd.Flags().GetInt(params.ConcurrencyFlag)
+		if !slices.Contains(triageConflictModes, onConflict) {
+			return errors.Errorf("%s: invalid --%s %s, use one of skip, overwrite, newest", failedPropagatingTriage, params.OnConflictFlag, onConflict)
+		}
+		if concurrency <= 0 {
+			return errors.Errorf("--%s should be higher than 0", params.ConcurrencyFlag)
+		}
+		if fromProject == toProject {
+			return errors.Errorf("%s: the source and target projects are the same", failedPropagatingTriage)
+		}
+
+		source, err := getTriageExport(resultsPredicatesWrapper, resultsWrapper, scanWrapper, fromProject, fromBranch, concurrency)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPropagatingTriage)
+		}
+		_, targetResults, err := getTriageableResults(resultsWrapper, scanWrapper, toProject, toBranch)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPropagatingTriage)
+		}
+		targetKeys := make(map[string]bool, len(targetResults))
+		for _, result := range targetResults {
+			targetKeys[resultMatchKey(result)] = true
+		}
+
+		views := make([]triagePropagateView, len(source.Results))
+		runConcurrently(len(source.Results), concurrency, func(i int) {
+			views[i] = propagateTriagedResult(resultsPredicatesWrapper, &source.Results[i], fromProject, toProject, targetKeys, onConflict, dryRun)
+		})
+		err = printByFormat(cmd, views)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for i := range views {
+			if views[i].Status == triageStatusFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return errors.Errorf(triagePropagateFailed, failedPropagatingTriage, failed, len(views))
+		}
+		return nil
+	}
+}
+
+// propagateTriagedResult applies the current source predicate to the target project, following the conflict mode
+func propagateTriagedResult(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	result *wrappers.TriagedResult,
+	fromProject, toProject string,
+	targetKeys map[string]bool,
+	onConflict string,
+	dryRun bool,
+) triagePropagateView {
+	source := latestPredicate(result.Predicates)
+	view := triagePropagateView{
+		SimilarityID:   result.SimilarityID,
+		ScanType:       result.ScanType,
+		SourceState:    source.State,
+		SourceSeverity: source.Severity,
+	}
+	if !targetKeys[resultMatchKey(&wrappers.ScanResult{Type: result.ScanType, SimilarityID: result.SimilarityID})] {
+		view.Status = triageStatusNotFound
+		return view
+	}
+
+	targetHistory, err := getPredicateHistory(resultsPredicatesWrapper, result.SimilarityID, toProject, result.ScanType)
+	if err != nil {
+		view.Status = triageStatusFailed
+		view.Error = err.Error()
+		return view
+	}
+	target := latestPredicate(targetHistory)
+	if target != nil {
+		view.TargetState, view.TargetSeverity = target.State, target.Severity
+		if target.State == source.State && target.Severity == source.Severity {
+			view.Status = triageStatusUnchanged
+			return view
+		}
+		if onConflict == triageConflictSkip || (onConflict == triageConflictNewest && !source.CreatedAt.After(target.CreatedAt)) {
+			view.Status = triageStatusConflict
+			return view
+		}
+	}
+	if dryRun {
+		view.Status = triageStatusWouldUpdate
+		return view
+	}
+
+	comment := source.Comment
+	if comment == "" {
+		comment = fmt.Sprintf(triagePropagatedComment, fromProject)
+	}
+	webError, err := resultsPredicatesWrapper.PredicateSeverityAndState(&wrappers.PredicateRequest{
+		SimilarityID: result.SimilarityID,
+		ProjectID:    toProject,
+		State:        source.State,
+		Severity:     source.Severity,
+		Comment:      comment,
+	}, result.ScanType)
+	if err == nil && webError != nil {
+		err = errors.Errorf("CODE: %d, %s", webError.Code, webError.Message)
+	}
+	if err != nil {
+		view.Status = triageStatusFailed
+		view.Error = err.Error()
+		return view
+	}
+	view.Status = triageStatusUpdated
+	return view
+}
@@ -0,0 +1,146 @@
+//go:build !integration
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+// projectScansWrapper returns a scan named after the project
+type projectScansWrapper struct {
+	mock.ScansMockWrapper
+}
+
+func (s *projectScansWrapper) Get(queryParams map[string]string) (*wrappers.ScansCollectionResponseModel, *wrappers.ErrorModel, error) {
+	projectID := queryParams[params.ProjectIDQueryParam]
+	return &wrappers.ScansCollectionResponseModel{
+		Scans: []wrappers.ScanResponseModel{{ID: projectID + "-scan", ProjectID: projectID, Branch: "main"}},
+	}, nil, nil
+}
+
+// projectResultsWrapper keys the results by scan id
+type projectResultsWrapper struct {
+	mock.ResultsMockWrapper
+	results map[string][]*wrappers.ScanResult
+}
+
+func (r *projectResultsWrapper) GetAllResultsByScanID(queryParams map[string]string) (*wrappers.ScanResultsCollection, *wrappers.WebError, error) {
+	results := r.results[queryParams[params.ScanIDQueryParam]]
+	return &wrappers.ScanResultsCollection{Results: results, TotalCount: uint(len(results))}, nil, nil
+}
+
+// projectPredicatesWrapper keys the predicates by project and similarity id
+type projectPredicatesWrapper struct {
+	mock.ResultsPredicatesMockWrapper
+	mutex      sync.Mutex
+	predicates map[string][]wrappers.Predicate
+	updates    []*wrappers.PredicateRequest
+}
+
+func (r *projectPredicatesWrapper) PredicateSeverityAndState(predicate *wrappers.PredicateRequest, _ string) (*wrappers.WebError, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.updates = append(r.updates, predicate)
+	return nil, nil
+}
+
+func (r *projectPredicatesWrapper) GetAllPredicatesForSimilarityID(similarityID, projectID, _ string) (
+	*wrappers.PredicatesCollectionResponseModel, *wrappers.WebError, error,
+) {
+	return &wrappers.PredicatesCollectionResponseModel{
+		PredicateHistoryPerProject: []wrappers.PredicateHistory{
+			{ProjectID: projectID, SimilarityID: similarityID, Predicates: r.predicates[projectID+"/"+similarityID]},
+		},
+	}, nil, nil
+}
+
+func newTriagePredicate(state, severity string, createdAt int64) wrappers.Predicate {
+	return wrappers.Predicate{BasePredicate: wrappers.BasePredicate{State: state, Severity: severity}, CreatedAt: time.Unix(createdAt, 0)}
+}
+
+func newTriageWrappers() (*projectPredicatesWrapper, *projectResultsWrapper) {
+	predicatesWrapper := &projectPredicatesWrapper{predicates: map[string][]wrappers.Predicate{
+		"A/1": {newTriagePredicate("TO_VERIFY", "HIGH", 1), newTriagePredicate("CONFIRMED", "HIGH", 10)},
+		"A/2": {newTriagePredicate("NOT_EXPLOITABLE", "LOW", 10)},
+		"A/3": {newTriagePredicate("URGENT", "HIGH", 5)},
+		"A/4": {newTriagePredicate("CONFIRMED", "MEDIUM", 5)},
+		"A/5": {newTriagePredicate("CONFIRMED", "MEDIUM", 5)},
+		"B/2": {newTriagePredicate("NOT_EXPLOITABLE", "LOW", 1)},
+		"B/3": {newTriagePredicate("CONFIRMED", "HIGH", 1)},
+		"B/4": {newTriagePredicate("NOT_EXPLOITABLE", "MEDIUM", 9)},
+	}}
+	resultsWrapper := &projectResultsWrapper{results: map[string][]*wrappers.ScanResult{
+		"A-scan": {
+			{Type: params.SastType, SimilarityID: "1"},
+			{Type: params.SastType, SimilarityID: "1"},
+			{Type: params.KicsType, SimilarityID: "2"},
+			{Type: params.SastType, SimilarityID: "3"},
+			{Type: params.SastType, SimilarityID: "4"},
+			{Type: params.SastType, SimilarityID: "5"},
+			{Type: params.SastType, SimilarityID: "6"},
+			{Type: params.ScaType, SimilarityID: "7"},
+		},
+		"B-scan": {
+			{Type: params.SastType, SimilarityID: "1"},
+			{Type: params.KicsType, SimilarityID: "2"},
+			{Type: params.SastType, SimilarityID: "3"},
+			{Type: params.SastType, SimilarityID: "4"},
+		},
+	}}
+	return predicatesWrapper, resultsWrapper
+}
+
+func TestGetTriageExport(t *testing.T) {
+	predicatesWrapper, resultsWrapper := newTriageWrappers()
+	export, err := getTriageExport(predicatesWrapper, resultsWrapper, &projectScansWrapper{}, "A", "", 2)
+	assert.NilError(t, err)
+	assert.Equal(t, export.ScanID, "A-scan")
+	assert.Equal(t, export.Branch, "main")
+	assert.Equal(t, len(export.Results), 5)
+	assert.Equal(t, export.Results[0].ScanType, params.KicsType)
+	assert.Equal(t, export.Results[1].SimilarityID, "1")
+	assert.Equal(t, len(export.Results[1].Predicates), 2)
+
+	file := filepath.Join(t.TempDir(), "decisions.csv")
+	assert.NilError(t, writeTriageCSV(file, export))
+	f, err := os.Open(file)
+	assert.NilError(t, err)
+	defer f.Close()
+	decisions, err := readTriageDecisionsCSV(f)
+	assert.NilError(t, err)
+	assert.Equal(t, len(decisions), 5)
+	assert.DeepEqual(t, *decisions[1], triageDecision{ProjectID: "A", SimilarityID: "1", ScanType: params.SastType, State: "CONFIRMED", Severity: "HIGH"})
+}
+
+func TestPropagateTriagedResult(t *testing.T) {
+	predicatesWrapper, resultsWrapper := newTriageWrappers()
+	source, err := getTriageExport(predicatesWrapper, resultsWrapper, &projectScansWrapper{}, "A", "", 1)
+	assert.NilError(t, err)
+	targetKeys := map[string]bool{"sast/1": true, "kics/2": true, "sast/3": true, "sast/4": true}
+
+	statuses := func(onConflict string, dryRun bool) []string {
+		var statuses []string
+		for i := range source.Results {
+			view := propagateTriagedResult(predicatesWrapper, &source.Results[i], "A", "B", targetKeys, onConflict, dryRun)
+			statuses = append(statuses, view.SimilarityID+":"+view.Status)
+		}
+		return statuses
+	}
+	assert.DeepEqual(t, statuses(triageConflictSkip, true), []string{"2:UNCHANGED", "1:WOULD_UPDATE", "3:CONFLICT", "4:CONFLICT", "5:NOT_FOUND"})
+	assert.DeepEqual(t, statuses(triageConflictNewest, true), []string{"2:UNCHANGED", "1:WOULD_UPDATE", "3:WOULD_UPDATE", "4:CONFLICT", "5:NOT_FOUND"})
+	assert.Equal(t, len(predicatesWrapper.updates), 0)
+
+	assert.DeepEqual(t, statuses(triageConflictOverwrite, false), []string{"2:UNCHANGED", "1:UPDATED", "3:UPDATED", "4:UPDATED", "5:NOT_FOUND"})
+	assert.Equal(t, len(predicatesWrapper.updates), 3)
+	assert.Equal(t, predicatesWrapper.updates[0].ProjectID, "B")
+	assert.Equal(t, predicatesWrapper.updates[0].Comment, "Propagated from project A")
+}
@@ -0,0 +1,313 @@
+package commands
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedSuppressingResults     = "Failed applying suppression comments"
+	notExploitableState          = "NOT_EXPLOITABLE"
+	cxIgnoreDefaultComment       = "Suppressed by a cx-ignore comment"
+	kicsIgnoreLineDefaultComment = "Suppressed by a kics-scan ignore-line comment"
+	suppressionMaxLineSize       = 1024 * 1024
+)
+
+var (
+	// cxIgnorePattern matches "// cx-ignore[query-a, query-b]: justification" after any usual comment token
+	cxIgnorePattern       = regexp.MustCompile(`(?://|#|/\*|--|<!--|;)\s*cx-ignore(?:\[([^\]]*)\])?(?:\s*:\s*(.*))?`)
+	kicsIgnoreLinePattern = regexp.MustCompile(`#\s*kics-scan\s+ignore-line\b`)
+	commentEndPattern     = regexp.MustCompile(`\s*(?:\*/|-->)\s*$`)
+)
+
+// suppression is an annotation found in the sources, it covers its own line and, when alone on its line, the next one
+type suppression struct {
+	queries       []string
+	justification string
+	kicsOnly      bool
+	trailing      bool
+}
+
+// suppressionIndex reads the annotations of the scanned files on demand
+type suppressionIndex struct {
+	sourceDir string
+	files     map[string]map[int][]*suppression
+}
+
+func triageSuppressSubCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+) *cobra.Command {
+	triageSuppressCmd := &cobra.Command{
+		Use:   "suppress",
+		Short: "Mark the results annotated in the sources as not exploitable",
+		Long: heredoc.Doc(
+			`
+			The suppress command reads the annotations of the scanned sources and marks the matching results as not exploitable, with the justification as comment.
+			"// cx-ignore[query-name]: justification" suppresses the SAST or KICS results of the query on its line, or on the next line when the comment is alone on its line. Every query is suppressed when the name is omitted.
+			"# kics-scan ignore-line" suppresses the KICS results of the next line.
+		`,
+		),
+		Example: heredoc.Doc(
+			`
+			$ cx triage suppress --scan-id <ScanID> -s <path> --dry-run
+		`,
+		),
+		RunE: runTriageSuppress(resultsPredicatesWrapper, featureFlagsWrapper, resultsWrapper, scanWrapper),
+	}
+	triageSuppressCmd.PersistentFlags().String(params.ScanIDFlag, "", "ID of the scan to read the results from")
+	triageSuppressCmd.PersistentFlags().StringP(params.SourcesFlag, params.SourcesFlagSh, "", "Local directory with the scanned sources")
+	triageSuppressCmd.PersistentFlags().Bool(params.DryRunFlag, false, "Report the results to suppress without changing them")
+	triageSuppressCmd.PersistentFlags().Int(params.ConcurrencyFlag, triageDefaultConcurrency, "Number of results updated concurrently")
+	markFlagAsRequired(triageSuppressCmd, params.ScanIDFlag)
+	markFlagAsRequired(triageSuppressCmd, params.SourcesFlag)
+	return triageSuppressCmd
+}
+
+func runTriageSuppress(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
+		sourceDir, _ := cmd.Flags().GetString(params.SourcesFlag)
+		dryRun, _ := cmd.Flags().GetBool(params.DryRunFlag)
+		concurrency, _ := cmd.Flags().GetInt(params.ConcurrencyFlag)
+		if concurrency <= 0 {
+			return errors.Errorf("--%s should be higher than 0", params.ConcurrencyFlag)
+		}
+		if info, err := os.Stat(sourceDir); err != nil || !info.IsDir() {
+			return errors.Errorf("%s: %s is not a directory", failedSuppressingResults, sourceDir)
+		}
+		scan, errorModel, err := scanWrapper.GetByID(scanID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingScan)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedGettingScan, errorModel.Code, errorModel.Message)
+		}
+
+		views, err := applySuppressions(resultsPredicatesWrapper, featureFlagsWrapper, resultsWrapper, scan, sourceDir, dryRun, concurrency)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedSuppressingResults)
+		}
+		err = printByFormat(cmd, views)
+		if err != nil {
+			return err
+		}
+		failedRows := 0
+		for i := range views {
+			if views[i].Status == triageStatusInvalid || views[i].Status == triageStatusFailed {
+				failedRows++
+			}
+		}
+		if failedRows > 0 {
+			return errors.Errorf(triageImportFailedRows, failedSuppressingResults, failedRows, len(views))
+		}
+		return nil
+	}
+}
+
+// applySuppressionsAfterScan suppresses the annotated results when the scan sources are a local directory
+func applySuppressionsAfterScan(
+	cmd *cobra.Command,
+	scan *wrappers.ScanResponseModel,
+	resultsWrapper wrappers.ResultsWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) error {
+	applySuppressionsFlag, _ := cmd.Flags().GetBool(params.ApplySuppressionsFlag)
+	if !applySuppressionsFlag {
+		return nil
+	}
+	sourceDir, _ := cmd.Flags().GetString(params.SourcesFlag)
+	if info, err := os.Stat(sourceDir); err != nil || !info.IsDir() {
+		log.Printf("Skipping suppression comments, %s is not a local directory", sourceDir)
+		return nil
+	}
+	views, err := applySuppressions(resultsPredicatesWrapper, featureFlagsWrapper, resultsWrapper, scan, sourceDir, false, triageDefaultConcurrency)
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedSuppressingResults)
+	}
+	suppressed := 0
+	for i := range views {
+		switch views[i].Status {
+		case triageStatusUpdated:
+			suppressed++
+		case triageStatusInvalid, triageStatusFailed:
+			log.Printf("Failed suppressing result %s: %s", views[i].SimilarityID, views[i].Error)
+		}
+	}
+	log.Printf("Suppression comments marked %d results as not exploitable", suppressed)
+	return nil
+}
+
+func applySuppressions(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scan *wrappers.ScanResponseModel,
+	sourceDir string,
+	dryRun bool,
+	concurrency int,
+) ([]triageImportView, error) {
+	decisions, err := getSuppressionDecisions(resultsWrapper, scan, sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.CVSSV3Enabled)
+	return applyTriageDecisions(resultsPredicatesWrapper, decisions, flagResponse.Status, dryRun, concurrency), nil
+}
+
+// getSuppressionDecisions matches the exploitable sast and kics results of the scan with the annotations of the sources
+func getSuppressionDecisions(resultsWrapper wrappers.ResultsWrapper, scan *wrappers.ScanResponseModel, sourceDir string) ([]*triageDecision, error) {
+	index := &suppressionIndex{sourceDir: sourceDir, files: make(map[string]map[int][]*suppression)}
+	seen := make(map[string]bool)
+	var decisions []*triageDecision
+	err := forEachResultsPage(resultsWrapper, map[string]string{params.ScanIDQueryParam: scan.ID}, func(page *wrappers.ScanResultsCollection) error {
+		for _, result := range page.Results {
+			if result.SimilarityID == "" || !isTriageableType(result.Type) || strings.EqualFold(result.State, notExploitableState) {
+				continue
+			}
+			if seen[resultMatchKey(result)] {
+				continue
+			}
+			file, line := suppressionLocation(result)
+			found := index.find(file, line, result)
+			if found == nil {
+				continue
+			}
+			seen[resultMatchKey(result)] = true
+			logger.PrintfIfVerbose("Result %s in %s:%d is suppressed: %s", result.SimilarityID, file, line, found.justification)
+			decisions = append(decisions, &triageDecision{
+				ProjectID:    scan.ProjectID,
+				SimilarityID: result.SimilarityID,
+				ScanType:     result.Type,
+				State:        notExploitableState,
+				Severity:     strings.ToUpper(result.Severity),
+				Comment:      found.justification,
+			})
+		}
+		return nil
+	})
+	return decisions, err
+}
+
+// suppressionLocation returns the file and line of the first sast node or of the kics result
+func suppressionLocation(result *wrappers.ScanResult) (file string, line int) {
+	if strings.EqualFold(result.Type, params.KicsType) {
+		return result.ScanResultData.Filename, int(result.ScanResultData.Line)
+	}
+	if len(result.ScanResultData.Nodes) == 0 {
+		return "", 0
+	}
+	return result.ScanResultData.Nodes[0].FileName, int(result.ScanResultData.Nodes[0].Line)
+}
+
+func (s *suppressionIndex) find(file string, line int, result *wrappers.ScanResult) *suppression {
+	if file == "" || line <= 0 {
+		return nil
+	}
+	lines, ok := s.files[file]
+	if !ok {
+		lines = readSuppressions(s.sourceDir, file)
+		s.files[file] = lines
+	}
+	isKics := strings.EqualFold(result.Type, params.KicsType)
+	for _, annotationLine := range []int{line, line - 1} {
+		for _, found := range lines[annotationLine] {
+			if (found.kicsOnly && !isKics) || (found.trailing && annotationLine != line) {
+				continue
+			}
+			if found.matches(result.ScanResultData.QueryName) {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+func (s *suppression) matches(queryName string) bool {
+	if len(s.queries) == 0 {
+		return true
+	}
+	for _, query := range s.queries {
+		if normalizeQueryName(query) == normalizeQueryName(queryName) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeQueryName lets "sql-injection" match the "SQL_Injection" query
+func normalizeQueryName(name string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(name))
+}
+
+// readSuppressions returns the annotations of a scanned file by line number, files outside the source directory are ignored
+func readSuppressions(sourceDir, file string) map[int][]*suppression {
+	suppressions := make(map[int][]*suppression)
+	path := filepath.Join(sourceDir, filepath.FromSlash(strings.TrimPrefix(file, "/")))
+	relative, err := filepath.Rel(sourceDir, path)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return suppressions
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		logger.PrintfIfVerbose("Skipping suppression comments of %s: %v", file, err)
+		return suppressions
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), suppressionMaxLineSize)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		found := parseSuppression(scanner.Text())
+		if found != nil {
+			suppressions[lineNumber] = append(suppressions[lineNumber], found)
+		}
+	}
+	return suppressions
+}
+
+func parseSuppression(line string) *suppression {
+	if match := cxIgnorePattern.FindStringSubmatchIndex(line); match != nil {
+		found := &suppression{trailing: strings.TrimSpace(line[:match[0]]) != ""}
+		if match[4] >= 0 {
+			found.justification = strings.TrimSpace(commentEndPattern.ReplaceAllString(line[match[4]:match[5]], ""))
+		}
+		queries := ""
+		if match[2] >= 0 {
+			queries = line[match[2]:match[3]]
+		}
+		for _, query := range strings.Split(queries, ",") {
+			if strings.TrimSpace(query) != "" {
+				found.queries = append(found.queries, strings.TrimSpace(query))
+			}
+		}
+		if found.justification == "" {
+			found.justification = cxIgnoreDefaultComment
+		}
+		return found
+	}
+	if kicsIgnoreLinePattern.MatchString(line) {
+		return &suppression{justification: kicsIgnoreLineDefaultComment, kicsOnly: true}
+	}
+	return nil
+}
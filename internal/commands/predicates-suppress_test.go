@@ -0,0 +1,78 @@
+//go:build !integration
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func TestParseSuppression(t *testing.T) {
+	found := parseSuppression(`query := db.Query(sql) // cx-ignore[SQL_Injection, Stored_XSS]: input is a constant`)
+	assert.DeepEqual(t, found.queries, []string{"SQL_Injection", "Stored_XSS"})
+	assert.Equal(t, found.justification, "input is a constant")
+	assert.Assert(t, found.trailing)
+
+	found = parseSuppression(`  /* cx-ignore */`)
+	assert.Equal(t, len(found.queries), 0)
+	assert.Equal(t, found.justification, cxIgnoreDefaultComment)
+	assert.Assert(t, !found.trailing)
+
+	found = parseSuppression(`  # kics-scan ignore-line`)
+	assert.Assert(t, found.kicsOnly)
+
+	assert.Assert(t, parseSuppression(`name := "cx-ignore"`) == nil)
+	assert.Assert(t, parseSuppression(`// kics-scan ignore-line`) == nil)
+}
+
+func TestGetSuppressionDecisions(t *testing.T) {
+	sourceDir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(sourceDir, "src"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(sourceDir, "src", "app.js"), []byte(
+		"// cx-ignore[sql-injection]: sanitized by the ORM\n"+
+			"db.query(input)\n"+
+			"eval(input) // cx-ignore[Code_Injection]\n"+
+			"render(input)\n"), 0600))
+	assert.NilError(t, os.WriteFile(filepath.Join(sourceDir, "main.tf"), []byte(
+		"resource \"aws_s3_bucket\" \"b\" {\n"+
+			"  # kics-scan ignore-line\n"+
+			"  acl = \"public-read\"\n"+
+			"}\n"), 0600))
+
+	sastResult := func(similarityID, query string, line uint) *wrappers.ScanResult {
+		return &wrappers.ScanResult{Type: params.SastType, SimilarityID: similarityID, Severity: "high", ScanResultData: wrappers.ScanResultData{
+			QueryName: query,
+			Nodes:     []*wrappers.ScanResultNode{{FileName: "/src/app.js", Line: line}},
+		}}
+	}
+	resultsWrapper := &projectResultsWrapper{results: map[string][]*wrappers.ScanResult{"scan": {
+		sastResult("1", "SQL_Injection", 2),
+		sastResult("2", "Code_Injection", 3),
+		sastResult("3", "Code_Injection", 4),
+		sastResult("4", "Stored_XSS", 2),
+		{Type: params.KicsType, SimilarityID: "5", Severity: "medium", ScanResultData: wrappers.ScanResultData{QueryName: "S3 Bucket ACL", Filename: "main.tf", Line: 3}},
+		{Type: params.SastType, SimilarityID: "6", State: notExploitableState, ScanResultData: wrappers.ScanResultData{
+			QueryName: "SQL_Injection",
+			Nodes:     []*wrappers.ScanResultNode{{FileName: "/src/app.js", Line: 2}},
+		}},
+		{Type: params.SastType, SimilarityID: "7", ScanResultData: wrappers.ScanResultData{
+			Nodes: []*wrappers.ScanResultNode{{FileName: "/../outside.js", Line: 1}},
+		}},
+	}}}
+
+	decisions, err := getSuppressionDecisions(resultsWrapper, &wrappers.ScanResponseModel{ID: "scan", ProjectID: "P1"}, sourceDir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(decisions), 3)
+	assert.DeepEqual(t, *decisions[0], triageDecision{
+		ProjectID: "P1", SimilarityID: "1", ScanType: params.SastType, State: notExploitableState, Severity: "HIGH", Comment: "sanitized by the ORM",
+	})
+	assert.Equal(t, decisions[1].SimilarityID, "2")
+	assert.Equal(t, decisions[1].Comment, cxIgnoreDefaultComment)
+	assert.Equal(t, decisions[2].SimilarityID, "5")
+	assert.Equal(t, decisions[2].Comment, kicsIgnoreLineDefaultComment)
+}
@@ -0,0 +1,642 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	failedOpeningWorkbench = "Failed opening the triage workbench"
+	workbenchDefaultWidth  = 100
+	workbenchDefaultHeight = 30
+	workbenchDetailHeight  = 12
+	workbenchSnippetLines  = 2
+	workbenchHelp          = "↑/↓ move  e/v/t filter engine/severity/state  s/S stage state/severity  c comment  u unstage  p push  q quit"
+	workbenchAll           = "all"
+
+	keyUp        = "up"
+	keyDown      = "down"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdown"
+	keyEnter     = "enter"
+	keyEscape    = "esc"
+	keyBackspace = "backspace"
+
+	ansiClear         = "\x1b[H\x1b[2J"
+	ansiReverse       = "\x1b[7m"
+	ansiReset         = "\x1b[0m"
+	ansiEnterScreen   = "\x1b[?1049h\x1b[?25l"
+	ansiLeaveScreen   = "\x1b[?25h\x1b[?1049l"
+	workbenchLineEnd  = "\r\n"
+	escapeByte        = 0x1b
+	deleteByte        = 0x7f
+	backspaceByte     = 0x08
+	ctrlCByte         = 0x03
+	carriageReturn    = '\r'
+	lineFeed          = '\n'
+	escapeSequenceLen = 2
+)
+
+// triageChange is a staged state, severity or comment change of a result
+type triageChange struct {
+	State    string
+	Severity string
+	Comment  string
+}
+
+// triageWorkbench is the state of the triage tui, kept apart from the terminal so it can be driven by keys in tests
+type triageWorkbench struct {
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper
+	scan                     *wrappers.ScanResponseModel
+	results                  []*wrappers.ScanResult
+	visible                  []int
+	cursor                   int
+	offset                   int
+	engine                   string
+	severity                 string
+	state                    string
+	staged                   map[int]*triageChange
+	sourceDir                string
+	sources                  map[string][]string
+	criticalEnabled          bool
+	concurrency              int
+	message                  string
+	comment                  *string
+	quitArmed                bool
+	width                    int
+	height                   int
+}
+
+func triageTuiSubCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+) *cobra.Command {
+	triageTuiCmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Browse and triage the results of a scan in a full-screen terminal interface",
+		Long: "The tui command lists the results of a scan with engine, severity and state filters and a detail pane. " +
+			"State, severity and comment changes are staged with keyboard shortcuts and pushed together.",
+		Example: heredoc.Doc(
+			`
+			$ cx triage tui --scan-id <ScanID> -s <path>
+		`,
+		),
+		RunE: runTriageTui(resultsPredicatesWrapper, featureFlagsWrapper, resultsWrapper, scanWrapper),
+	}
+	triageTuiCmd.PersistentFlags().String(params.ScanIDFlag, "", "ID of the scan to browse")
+	triageTuiCmd.PersistentFlags().StringP(params.SourcesFlag, params.SourcesFlagSh, "", "Local directory with the scanned sources, to show the code of the results")
+	triageTuiCmd.PersistentFlags().Int(params.ConcurrencyFlag, triageDefaultConcurrency, "Number of staged changes pushed concurrently")
+	markFlagAsRequired(triageTuiCmd, params.ScanIDFlag)
+	return triageTuiCmd
+}
+
+func runTriageTui(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
+		sourceDir, _ := cmd.Flags().GetString(params.SourcesFlag)
+		concurrency, _ := cmd.Flags().GetInt(params.ConcurrencyFlag)
+		if concurrency <= 0 {
+			return errors.Errorf("--%s should be higher than 0", params.ConcurrencyFlag)
+		}
+		fd := int(os.Stdin.Fd())
+		if !term.IsTerminal(fd) {
+			return errors.Errorf("%s: the triage workbench needs an interactive terminal", failedOpeningWorkbench)
+		}
+		scan, errorModel, err := scanWrapper.GetByID(scanID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingScan)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedGettingScan, errorModel.Code, errorModel.Message)
+		}
+		var results []*wrappers.ScanResult
+		err = forEachResultsPage(resultsWrapper, map[string]string{params.ScanIDQueryParam: scanID}, func(page *wrappers.ScanResultsCollection) error {
+			results = append(results, page.Results...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.CVSSV3Enabled)
+		workbench := newTriageWorkbench(resultsPredicatesWrapper, scan, results, sourceDir, flagResponse.Status, concurrency)
+
+		oldState, err := term.MakeRaw(fd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedOpeningWorkbench)
+		}
+		defer func() {
+			_ = term.Restore(fd, oldState)
+		}()
+		out := cmd.OutOrStdout()
+		fmt.Fprint(out, ansiEnterScreen)
+		defer fmt.Fprint(out, ansiLeaveScreen)
+
+		reader := bufio.NewReader(os.Stdin)
+		for {
+			workbench.width, workbench.height, err = term.GetSize(int(os.Stdout.Fd()))
+			if err != nil {
+				workbench.width, workbench.height = workbenchDefaultWidth, workbenchDefaultHeight
+			}
+			workbench.render(out)
+			key, readErr := readWorkbenchKey(reader)
+			if readErr != nil {
+				return readErr
+			}
+			if workbench.handleKey(key) {
+				return nil
+			}
+		}
+	}
+}
+
+func newTriageWorkbench(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	scan *wrappers.ScanResponseModel,
+	results []*wrappers.ScanResult,
+	sourceDir string,
+	criticalEnabled bool,
+	concurrency int,
+) *triageWorkbench {
+	workbench := &triageWorkbench{
+		resultsPredicatesWrapper: resultsPredicatesWrapper,
+		scan:                     scan,
+		results:                  results,
+		staged:                   make(map[int]*triageChange),
+		sourceDir:                sourceDir,
+		sources:                  make(map[string][]string),
+		criticalEnabled:          criticalEnabled,
+		concurrency:              concurrency,
+		width:                    workbenchDefaultWidth,
+		height:                   workbenchDefaultHeight,
+	}
+	workbench.applyFilters()
+	return workbench
+}
+
+// readWorkbenchKey reads one key press, translating the escape sequences of the arrow and page keys
+func readWorkbenchKey(reader *bufio.Reader) (string, error) {
+	r, _, err := reader.ReadRune()
+	if err != nil {
+		return "", err
+	}
+	switch r {
+	case escapeByte:
+		if reader.Buffered() < escapeSequenceLen {
+			return keyEscape, nil
+		}
+		sequence := make([]byte, escapeSequenceLen)
+		if _, err = io.ReadFull(reader, sequence); err != nil {
+			return "", err
+		}
+		switch string(sequence) {
+		case "[A":
+			return keyUp, nil
+		case "[B":
+			return keyDown, nil
+		case "[5", "[6":
+			_, _ = reader.ReadByte()
+			if sequence[1] == '5' {
+				return keyPageUp, nil
+			}
+			return keyPageDown, nil
+		}
+		return keyEscape, nil
+	case carriageReturn, lineFeed:
+		return keyEnter, nil
+	case deleteByte, backspaceByte:
+		return keyBackspace, nil
+	case ctrlCByte:
+		return "q", nil
+	}
+	return string(r), nil
+}
+
+// handleKey applies a key press and tells if the workbench should close
+func (w *triageWorkbench) handleKey(key string) bool {
+	if w.comment != nil {
+		w.handleCommentKey(key)
+		return false
+	}
+	if key != "q" {
+		w.quitArmed = false
+	}
+	w.message = ""
+	switch key {
+	case keyUp, "k":
+		w.moveCursor(-1)
+	case keyDown, "j":
+		w.moveCursor(1)
+	case keyPageUp:
+		w.moveCursor(-w.listHeight())
+	case keyPageDown:
+		w.moveCursor(w.listHeight())
+	case "e":
+		w.engine = nextWorkbenchFilter(w.engine, w.engines())
+		w.applyFilters()
+	case "v":
+		w.severity = nextWorkbenchFilter(w.severity, triageSeverities)
+		w.applyFilters()
+	case "t":
+		w.state = nextWorkbenchFilter(w.state, triageStates)
+		w.applyFilters()
+	case "s":
+		w.stageState()
+	case "S":
+		w.stageSeverity()
+	case "c":
+		if w.selected() >= 0 {
+			comment := ""
+			if change, ok := w.staged[w.selected()]; ok {
+				comment = change.Comment
+			}
+			w.comment = &comment
+		}
+	case "u":
+		delete(w.staged, w.selected())
+	case "p":
+		w.push()
+	case "q":
+		if len(w.staged) == 0 || w.quitArmed {
+			return true
+		}
+		w.quitArmed = true
+		w.message = fmt.Sprintf("%d staged changes were not pushed, press q again to discard them", len(w.staged))
+	}
+	return false
+}
+
+func (w *triageWorkbench) handleCommentKey(key string) {
+	switch key {
+	case keyEnter:
+		if index := w.selected(); index >= 0 {
+			w.change(index).Comment = *w.comment
+		}
+		w.comment = nil
+	case keyEscape:
+		w.comment = nil
+	case keyBackspace:
+		if *w.comment != "" {
+			_, size := utf8.DecodeLastRuneInString(*w.comment)
+			*w.comment = (*w.comment)[:len(*w.comment)-size]
+		}
+	default:
+		if utf8.RuneCountInString(key) == 1 {
+			*w.comment += key
+		}
+	}
+}
+
+func (w *triageWorkbench) selected() int {
+	if w.cursor < 0 || w.cursor >= len(w.visible) {
+		return -1
+	}
+	return w.visible[w.cursor]
+}
+
+func (w *triageWorkbench) moveCursor(delta int) {
+	w.cursor += delta
+	if w.cursor >= len(w.visible) {
+		w.cursor = len(w.visible) - 1
+	}
+	if w.cursor < 0 {
+		w.cursor = 0
+	}
+}
+
+func (w *triageWorkbench) engines() []string {
+	seen := make(map[string]bool)
+	for _, result := range w.results {
+		seen[result.Type] = true
+	}
+	return sortedKeys(seen)
+}
+
+// nextWorkbenchFilter cycles a filter through all and every value
+func nextWorkbenchFilter(current string, values []string) string {
+	if current == "" {
+		if len(values) == 0 {
+			return ""
+		}
+		return values[0]
+	}
+	for i, value := range values {
+		if strings.EqualFold(value, current) && i+1 < len(values) {
+			return values[i+1]
+		}
+	}
+	return ""
+}
+
+func (w *triageWorkbench) applyFilters() {
+	w.visible = w.visible[:0]
+	for i, result := range w.results {
+		if w.engine != "" && !strings.EqualFold(result.Type, w.engine) {
+			continue
+		}
+		if w.severity != "" && !strings.EqualFold(w.currentSeverity(i), w.severity) {
+			continue
+		}
+		if w.state != "" && !strings.EqualFold(w.currentState(i), w.state) {
+			continue
+		}
+		w.visible = append(w.visible, i)
+	}
+	w.moveCursor(0)
+}
+
+func (w *triageWorkbench) currentState(index int) string {
+	if change, ok := w.staged[index]; ok && change.State != "" {
+		return change.State
+	}
+	return strings.ToUpper(w.results[index].State)
+}
+
+func (w *triageWorkbench) currentSeverity(index int) string {
+	if change, ok := w.staged[index]; ok && change.Severity != "" {
+		return change.Severity
+	}
+	return strings.ToUpper(w.results[index].Severity)
+}
+
+func (w *triageWorkbench) change(index int) *triageChange {
+	change, ok := w.staged[index]
+	if !ok {
+		change = &triageChange{}
+		w.staged[index] = change
+	}
+	return change
+}
+
+func (w *triageWorkbench) stageState() {
+	index := w.selected()
+	if index < 0 {
+		return
+	}
+	state := nextWorkbenchFilter(w.currentState(index), triageStates)
+	if state == "" {
+		state = triageStates[0]
+	}
+	w.change(index).State = state
+}
+
+func (w *triageWorkbench) stageSeverity() {
+	index := w.selected()
+	if index < 0 {
+		return
+	}
+	if isVulnerabilityScanType(w.results[index].Type) {
+		w.message = fmt.Sprintf("The severity of %s vulnerabilities can't be changed", w.results[index].Type)
+		return
+	}
+	severities := triageSeverities
+	if !w.criticalEnabled {
+		severities = severities[1:]
+	}
+	severity := nextWorkbenchFilter(w.currentSeverity(index), severities)
+	if severity == "" {
+		severity = severities[0]
+	}
+	w.change(index).Severity = severity
+}
+
+// push sends the staged changes, keeping the failed ones staged
+func (w *triageWorkbench) push() {
+	var decisionIndexes, vulnerabilityIndexes []int
+	var decisions []*triageDecision
+	indexes := make([]int, 0, len(w.staged))
+	for index := range w.staged {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		result := w.results[index]
+		if isVulnerabilityScanType(result.Type) {
+			vulnerabilityIndexes = append(vulnerabilityIndexes, index)
+			continue
+		}
+		change := w.staged[index]
+		decisions = append(decisions, &triageDecision{
+			ProjectID:    w.scan.ProjectID,
+			SimilarityID: result.SimilarityID,
+			ScanType:     result.Type,
+			State:        change.State,
+			Severity:     change.Severity,
+			Comment:      change.Comment,
+		})
+		decisionIndexes = append(decisionIndexes, index)
+	}
+
+	pushed, failed := 0, 0
+	firstError := ""
+	views := applyTriageDecisions(w.resultsPredicatesWrapper, decisions, w.criticalEnabled, false, w.concurrency)
+	for i := range views {
+		if views[i].Status == triageStatusInvalid || views[i].Status == triageStatusFailed {
+			failed++
+			if firstError == "" {
+				firstError = views[i].Error
+			}
+			continue
+		}
+		w.commit(decisionIndexes[i])
+		pushed++
+	}
+	for _, index := range vulnerabilityIndexes {
+		if err := w.pushVulnerability(index); err != nil {
+			failed++
+			if firstError == "" {
+				firstError = err.Error()
+			}
+			continue
+		}
+		w.commit(index)
+		pushed++
+	}
+
+	w.message = fmt.Sprintf("Pushed %d changes", pushed)
+	if failed > 0 {
+		w.message += fmt.Sprintf(", %d failed: %s", failed, firstError)
+	}
+	w.applyFilters()
+}
+
+func (w *triageWorkbench) pushVulnerability(index int) error {
+	result := w.results[index]
+	change := w.staged[index]
+	vulnerability := vulnerabilityFromResult(result)
+	if vulnerability.PackageName == "" || vulnerability.VulnerabilityID == "" {
+		return errors.Errorf("Missing the package of %s", slaResultName(result))
+	}
+	state := change.State
+	if state == "" {
+		state = strings.ToUpper(result.State)
+	}
+	webError, err := w.resultsPredicatesWrapper.PredicateVulnerabilityState(&wrappers.VulnerabilityPredicateRequest{
+		PackageVulnerability: *vulnerability,
+		ProjectIDs:           []string{w.scan.ProjectID},
+		Actions: []wrappers.VulnerabilityPredicateAction{
+			{ActionType: wrappers.ChangeStateAction, Value: state, Comment: change.Comment},
+		},
+	}, result.Type)
+	if err == nil && webError != nil {
+		err = errors.Errorf("CODE: %d, %s", webError.Code, webError.Message)
+	}
+	return err
+}
+
+// vulnerabilityFromResult reads the package of an SCA or container result, SCA package identifiers look like npm-lodash-4.17.15
+func vulnerabilityFromResult(result *wrappers.ScanResult) *wrappers.PackageVulnerability {
+	data := result.ScanResultData
+	vulnerability := &wrappers.PackageVulnerability{
+		PackageName:     data.PackageName,
+		PackageVersion:  data.PackageVersion,
+		VulnerabilityID: result.VulnerabilityDetails.CveName,
+		ImageName:       data.ImageName,
+		ImageTag:        data.ImageTag,
+	}
+	if vulnerability.VulnerabilityID == "" {
+		vulnerability.VulnerabilityID = result.ID
+	}
+	parts := strings.Split(data.PackageIdentifier, "-")
+	if vulnerability.PackageName == "" && len(parts) >= 3 {
+		vulnerability.PackageManager = parts[0]
+		vulnerability.PackageName = strings.Join(parts[1:len(parts)-1], "-")
+		vulnerability.PackageVersion = parts[len(parts)-1]
+	}
+	return vulnerability
+}
+
+func (w *triageWorkbench) commit(index int) {
+	change := w.staged[index]
+	if change.State != "" {
+		w.results[index].State = change.State
+	}
+	if change.Severity != "" {
+		w.results[index].Severity = change.Severity
+	}
+	delete(w.staged, index)
+}
+
+func (w *triageWorkbench) listHeight() int {
+	height := w.height - workbenchDetailHeight - 3
+	if height < 1 {
+		return 1
+	}
+	return height
+}
+
+func (w *triageWorkbench) render(out io.Writer) {
+	var lines []string
+	filter := func(value string) string {
+		if value == "" {
+			return workbenchAll
+		}
+		return strings.ToLower(value)
+	}
+	lines = append(lines, ansiReverse+w.fit(fmt.Sprintf(" Scan %s | engine: %s  severity: %s  state: %s | %d of %d results | %d staged",
+		w.scan.ID, filter(w.engine), filter(w.severity), filter(w.state), len(w.visible), len(w.results), len(w.staged)))+ansiReset)
+
+	listHeight := w.listHeight()
+	if w.cursor < w.offset {
+		w.offset = w.cursor
+	}
+	if w.cursor >= w.offset+listHeight {
+		w.offset = w.cursor - listHeight + 1
+	}
+	for row := w.offset; row < w.offset+listHeight; row++ {
+		if row >= len(w.visible) {
+			lines = append(lines, "")
+			continue
+		}
+		index := w.visible[row]
+		marker := " "
+		if _, ok := w.staged[index]; ok {
+			marker = "*"
+		}
+		line := w.fit(fmt.Sprintf("%s %-8s %-24s %-10s %s  %s", marker, w.currentSeverity(index), w.currentState(index),
+			w.results[index].Type, slaResultName(w.results[index]), slaResultLocation(w.results[index])))
+		if row == w.cursor {
+			line = ansiReverse + line + ansiReset
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, w.fit(strings.Repeat("─", w.width)))
+	detail := w.detailLines()
+	for i := 0; i < workbenchDetailHeight; i++ {
+		line := ""
+		if i < len(detail) {
+			line = w.fit(detail[i])
+		}
+		lines = append(lines, line)
+	}
+
+	footer := workbenchHelp
+	if w.comment != nil {
+		footer = "Comment (enter to stage, esc to cancel): " + *w.comment
+	} else if w.message != "" {
+		footer = w.message
+	}
+	lines = append(lines, ansiReverse+w.fit(footer)+ansiReset)
+	fmt.Fprint(out, ansiClear+strings.Join(lines, workbenchLineEnd))
+}
+
+func (w *triageWorkbench) detailLines() []string {
+	index := w.selected()
+	if index < 0 {
+		return []string{"No results match the filters"}
+	}
+	result := w.results[index]
+	lines := []string{fmt.Sprintf("%s  %s  similarity id %s", slaResultName(result), slaResultLocation(result), result.SimilarityID)}
+	if change, ok := w.staged[index]; ok {
+		lines = append(lines, fmt.Sprintf("Staged: state %s  severity %s  comment %q", w.currentState(index), w.currentSeverity(index), change.Comment))
+	}
+	description := result.Description
+	if description == "" {
+		description = result.ScanResultData.Description
+	}
+	if description != "" {
+		lines = append(lines, strings.Join(strings.Fields(description), " "))
+	}
+	for i, node := range result.ScanResultData.Nodes {
+		if node == nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %d. %s:%d %s", i+1, node.FileName, node.Line, node.Name))
+	}
+	if w.sourceDir != "" {
+		file, line := suppressionLocation(result)
+		if snippet := readSourceSnippet(w.sources, w.sourceDir, file, uint(line), workbenchSnippetLines); snippet != nil {
+			lines = append(lines, snippet.NumberedLines()...)
+		}
+	}
+	return lines
+}
+
+// fit cuts or pads a line to the terminal width
+func (w *triageWorkbench) fit(line string) string {
+	line = strings.ReplaceAll(line, "\t", "    ")
+	if utf8.RuneCountInString(line) > w.width {
+		return string([]rune(line)[:w.width])
+	}
+	return line + strings.Repeat(" ", w.width-utf8.RuneCountInString(line))
+}
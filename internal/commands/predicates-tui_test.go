@@ -0,0 +1,144 @@
+//go:build !integration
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func newTestWorkbench(predicatesWrapper wrappers.ResultsPredicatesWrapper) *triageWorkbench {
+	results := []*wrappers.ScanResult{
+		{Type: params.SastType, SimilarityID: "1", Severity: "HIGH", State: "TO_VERIFY", Description: "Untrusted input reaches a query",
+			ScanResultData: wrappers.ScanResultData{QueryName: "SQL_Injection", Nodes: []*wrappers.ScanResultNode{
+				{FileName: "/src/app.js", Line: 2, Name: "input"},
+				{FileName: "/src/app.js", Line: 3, Name: "query"},
+			}}},
+		{Type: params.KicsType, SimilarityID: "2", Severity: "MEDIUM", State: "CONFIRMED",
+			ScanResultData: wrappers.ScanResultData{QueryName: "S3 Bucket ACL", Filename: "main.tf", Line: 3}},
+		{Type: params.ScaType, ID: "CVE-2021-23337", SimilarityID: "3", Severity: "HIGH", State: "TO_VERIFY",
+			ScanResultData: wrappers.ScanResultData{PackageIdentifier: "npm-lodash-4.17.15"}},
+	}
+	return newTriageWorkbench(predicatesWrapper, &wrappers.ScanResponseModel{ID: "scan", ProjectID: "P1"}, results, "", false, 2)
+}
+
+func TestTriageWorkbenchFilters(t *testing.T) {
+	workbench := newTestWorkbench(&triagedPredicatesWrapper{})
+	assert.Equal(t, len(workbench.visible), 3)
+
+	workbench.handleKey("e")
+	assert.Equal(t, workbench.engine, params.KicsType)
+	assert.DeepEqual(t, workbench.visible, []int{1})
+
+	workbench.handleKey("e")
+	workbench.handleKey("e")
+	workbench.handleKey("e")
+	assert.Equal(t, workbench.engine, "")
+
+	workbench.handleKey("v")
+	workbench.handleKey("v")
+	assert.Equal(t, workbench.severity, "HIGH")
+	assert.DeepEqual(t, workbench.visible, []int{0, 2})
+
+	workbench.handleKey("t")
+	assert.DeepEqual(t, workbench.visible, []int{0, 2})
+	workbench.handleKey("j")
+	workbench.handleKey("j")
+	assert.Equal(t, workbench.selected(), 2)
+	workbench.handleKey("k")
+	assert.Equal(t, workbench.selected(), 0)
+}
+
+func TestTriageWorkbenchStaging(t *testing.T) {
+	workbench := newTestWorkbench(&triagedPredicatesWrapper{})
+
+	workbench.handleKey("s")
+	workbench.handleKey("S")
+	assert.Equal(t, workbench.currentState(0), "NOT_EXPLOITABLE")
+	assert.Equal(t, workbench.currentSeverity(0), "MEDIUM")
+
+	workbench.handleKey("c")
+	for _, key := range []string{"o", "k", "x", keyBackspace, "!", keyEnter} {
+		workbench.handleKey(key)
+	}
+	assert.Equal(t, workbench.staged[0].Comment, "ok!")
+	assert.Assert(t, workbench.comment == nil)
+
+	workbench.handleKey(keyDown)
+	workbench.handleKey(keyDown)
+	workbench.handleKey("S")
+	assert.Assert(t, strings.Contains(workbench.message, "can't be changed"))
+
+	assert.Assert(t, !workbench.handleKey("q"))
+	assert.Assert(t, workbench.quitArmed)
+	workbench.handleKey(keyUp)
+	workbench.handleKey(keyUp)
+	workbench.handleKey("u")
+	assert.Equal(t, len(workbench.staged), 0)
+	assert.Assert(t, workbench.handleKey("q"))
+}
+
+func TestTriageWorkbenchPush(t *testing.T) {
+	predicatesWrapper := &triagedPredicatesWrapper{current: map[string]wrappers.BasePredicate{"1": {State: "TO_VERIFY", Severity: "HIGH"}}}
+	workbench := newTestWorkbench(predicatesWrapper)
+	workbench.handleKey("t")
+	assert.DeepEqual(t, workbench.visible, []int{0, 2})
+
+	workbench.handleKey("s")
+	workbench.handleKey(keyDown)
+	workbench.handleKey("s")
+	workbench.handleKey("p")
+	assert.Equal(t, workbench.message, "Pushed 2 changes")
+	assert.Equal(t, predicatesWrapper.updates, 1)
+	assert.Equal(t, len(workbench.staged), 0)
+	assert.Equal(t, workbench.results[0].State, "NOT_EXPLOITABLE")
+	assert.Equal(t, workbench.results[2].State, "NOT_EXPLOITABLE")
+	assert.Equal(t, len(workbench.visible), 0)
+}
+
+func TestVulnerabilityFromResult(t *testing.T) {
+	vulnerability := vulnerabilityFromResult(&wrappers.ScanResult{ID: "CVE-1", ScanResultData: wrappers.ScanResultData{PackageIdentifier: "npm-lodash.merge-js-1.0.0"}})
+	assert.DeepEqual(t, *vulnerability, wrappers.PackageVulnerability{
+		PackageManager: "npm", PackageName: "lodash.merge-js", PackageVersion: "1.0.0", VulnerabilityID: "CVE-1",
+	})
+}
+
+func TestTriageWorkbenchRender(t *testing.T) {
+	sourceDir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(sourceDir, "src"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(sourceDir, "src", "app.js"), []byte("const input = req.body\ndb.query(input)\n"), 0600))
+	workbench := newTestWorkbench(&triagedPredicatesWrapper{})
+	workbench.sourceDir = sourceDir
+	workbench.width = 120
+	workbench.handleKey("s")
+
+	var out bytes.Buffer
+	workbench.render(&out)
+	screen := out.String()
+	assert.Assert(t, strings.Contains(screen, "3 of 3 results | 1 staged"))
+	assert.Assert(t, strings.Contains(screen, "* HIGH     NOT_EXPLOITABLE"))
+	assert.Assert(t, strings.Contains(screen, "Untrusted input reaches a query"))
+	assert.Assert(t, strings.Contains(screen, "2. /src/app.js:3 query"))
+	assert.Assert(t, strings.Contains(screen, "db.query(input)"))
+	for _, line := range strings.Split(screen, workbenchLineEnd) {
+		line = strings.NewReplacer(ansiClear, "", ansiReverse, "", ansiReset, "").Replace(line)
+		assert.Assert(t, len([]rune(line)) <= workbench.width)
+	}
+}
+
+func TestReadWorkbenchKey(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("\x1b[A\x1b[B\x1b[6~\rj\x7f"))
+	for _, expected := range []string{keyUp, keyDown, keyPageDown, keyEnter, "j", keyBackspace} {
+		key, err := readWorkbenchKey(reader)
+		assert.NilError(t, err)
+		assert.Equal(t, key, expected)
+	}
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+const (
+	vulnerabilitiesFlagDescription = "SCA or container vulnerability, for SCA: packagename=<Name>,packageversion=<Version>,packagemanager=<Manager>,vulnerabilityid=<CVE>. " +
+		"Container vulnerabilities also need imagename=<Image>,imagetag=<Tag>"
+	vulnerabilityPackageName    = "packagename"
+	vulnerabilityPackageVersion = "packageversion"
+	vulnerabilityPackageManager = "packagemanager"
+	vulnerabilityID             = "vulnerabilityid"
+	vulnerabilityImageName      = "imagename"
+	vulnerabilityImageTag       = "imagetag"
+)
+
+// isVulnerabilityScanType tells if the engine triages package vulnerabilities instead of similarity ids
+func isVulnerabilityScanType(scanType string) bool {
+	switch strings.ToLower(strings.TrimSpace(scanType)) {
+	case params.ScaType, params.ContainersType, params.ContainersTypeFlag:
+		return true
+	default:
+		return false
+	}
+}
+
+func isContainersScanType(scanType string) bool {
+	return strings.EqualFold(strings.TrimSpace(scanType), params.ContainersType) || strings.EqualFold(strings.TrimSpace(scanType), params.ContainersTypeFlag)
+}
+
+// parseVulnerability reads the key=value pairs of the --vulnerabilities flag
+func parseVulnerability(value, scanType string) (*wrappers.PackageVulnerability, error) {
+	values := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		keyValue := strings.SplitN(pair, "=", params.KeyValuePairSize)
+		if len(keyValue) != params.KeyValuePairSize {
+			return nil, errors.Errorf("Invalid --%s value %s, use key=value pairs", params.VulnerabilitiesFlag, pair)
+		}
+		values[strings.ToLower(strings.TrimSpace(keyValue[0]))] = strings.TrimSpace(keyValue[1])
+	}
+	required := []string{vulnerabilityPackageName, vulnerabilityPackageVersion, vulnerabilityID}
+	if isContainersScanType(scanType) {
+		required = append(required, vulnerabilityImageName, vulnerabilityImageTag)
+	} else {
+		required = append(required, vulnerabilityPackageManager)
+	}
+	var missing []string
+	for _, key := range required {
+		if values[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, errors.Errorf("Missing %s in --%s", strings.Join(missing, ", "), params.VulnerabilitiesFlag)
+	}
+	return &wrappers.PackageVulnerability{
+		PackageManager:  values[vulnerabilityPackageManager],
+		PackageName:     values[vulnerabilityPackageName],
+		PackageVersion:  values[vulnerabilityPackageVersion],
+		VulnerabilityID: values[vulnerabilityID],
+		ImageName:       values[vulnerabilityImageName],
+		ImageTag:        values[vulnerabilityImageTag],
+	}, nil
+}
+
+func updateVulnerabilityPredicate(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	vulnerabilities, projectID, severity, state, comment, scanType string,
+) error {
+	if severity != "" {
+		return errors.Errorf("The severity of %s vulnerabilities can't be changed", scanType)
+	}
+	if !slices.Contains(triageStates, strings.ToUpper(state)) {
+		return errors.Errorf("Invalid state %s, use one of %s", state, strings.Join(triageStates, ", "))
+	}
+	vulnerability, err := parseVulnerability(vulnerabilities, scanType)
+	if err != nil {
+		return err
+	}
+	predicate := &wrappers.VulnerabilityPredicateRequest{
+		PackageVulnerability: *vulnerability,
+		ProjectIDs:           []string{projectID},
+		Actions: []wrappers.VulnerabilityPredicateAction{
+			{ActionType: wrappers.ChangeStateAction, Value: strings.ToUpper(state), Comment: comment},
+		},
+	}
+	webError, err := resultsPredicatesWrapper.PredicateVulnerabilityState(predicate, scanType)
+	if err != nil {
+		return errors.Wrapf(err, "%s", "Failed updating the predicate")
+	}
+	if webError != nil {
+		return errors.Errorf("%s: CODE: %d, %s", "Failed updating the predicate", webError.Code, webError.Message)
+	}
+	return nil
+}
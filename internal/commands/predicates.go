@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -12,7 +13,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func NewResultsPredicatesCommand(resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) *cobra.Command {
+func NewResultsPredicatesCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+) *cobra.Command {
 	triageCmd := &cobra.Command{
 		Use:   "triage",
 		Short: "Manage results",
@@ -20,13 +26,18 @@ func NewResultsPredicatesCommand(resultsPredicatesWrapper wrappers.ResultsPredic
 	}
 	triageShowCmd := triageShowSubCommand(resultsPredicatesWrapper)
 	triageUpdateCmd := triageUpdateSubCommand(resultsPredicatesWrapper, featureFlagsWrapper)
+	triageImportCmd := triageImportSubCommand(resultsPredicatesWrapper, featureFlagsWrapper)
+	triageExportCmd := triageExportSubCommand(resultsPredicatesWrapper, resultsWrapper, scanWrapper)
+	triagePropagateCmd := triagePropagateSubCommand(resultsPredicatesWrapper, resultsWrapper, scanWrapper)
+	triageSuppressCmd := triageSuppressSubCommand(resultsPredicatesWrapper, featureFlagsWrapper, resultsWrapper, scanWrapper)
+	triageTuiCmd := triageTuiSubCommand(resultsPredicatesWrapper, featureFlagsWrapper, resultsWrapper, scanWrapper)
 
 	addFormatFlagToMultipleCommands(
-		[]*cobra.Command{triageShowCmd},
+		[]*cobra.Command{triageShowCmd, triageImportCmd, triagePropagateCmd, triageSuppressCmd},
 		printer.FormatList, printer.FormatTable, printer.FormatJSON,
 	)
 
-	triageCmd.AddCommand(triageShowCmd, triageUpdateCmd)
+	triageCmd.AddCommand(triageShowCmd, triageUpdateCmd, triageImportCmd, triageExportCmd, triagePropagateCmd, triageSuppressCmd, triageTuiCmd)
 	return triageCmd
 }
 
@@ -38,6 +49,7 @@ func triageShowSubCommand(resultsPredicatesWrapper wrappers.ResultsPredicatesWra
 		Example: heredoc.Doc(
 			`
 			$ cx triage show --similarity-id <SimilarityID> --project-id <ProjectID> --scan-type <SAST||IAC-SECURITY>
+			$ cx triage show --vulnerabilities packagename=<Name>,packageversion=<Version>,packagemanager=<Manager>,vulnerabilityid=<CVE> --project-id <ProjectID> --scan-type sca
 		`,
 		),
 
@@ -45,10 +57,10 @@ func triageShowSubCommand(resultsPredicatesWrapper wrappers.ResultsPredicatesWra
 	}
 
 	triageShowCmd.PersistentFlags().String(params.SimilarityIDFlag, "", "Similarity ID")
+	triageShowCmd.PersistentFlags().String(params.VulnerabilitiesFlag, "", vulnerabilitiesFlagDescription)
 	triageShowCmd.PersistentFlags().String(params.ProjectIDFlag, "", "Project ID.")
 	triageShowCmd.PersistentFlags().String(params.ScanTypeFlag, "", "Scan Type")
 
-	markFlagAsRequired(triageShowCmd, params.SimilarityIDFlag)
 	markFlagAsRequired(triageShowCmd, params.ProjectIDFlag)
 	markFlagAsRequired(triageShowCmd, params.ScanTypeFlag)
 
@@ -69,20 +81,25 @@ func triageUpdateSubCommand(resultsPredicatesWrapper wrappers.ResultsPredicatesW
 				--severity <CRITICAL|HIGH|MEDIUM|LOW|INFO> 
 				--comment <Comment(Optional)> 
 				--scan-type <SAST|IAC-SECURITY>
+				$ cx triage update
+				--vulnerabilities packagename=<Name>,packageversion=<Version>,packagemanager=<Manager>,vulnerabilityid=<CVE>
+				--project-id <ProjectID>
+				--state <TO_VERIFY|NOT_EXPLOITABLE|PROPOSED_NOT_EXPLOITABLE|CONFIRMED|URGENT>
+				--comment <Comment(Optional)>
+				--scan-type <SCA|CONTAINERS>
 		`,
 		),
 		RunE: runTriageUpdate(resultsPredicatesWrapper, featureFlagsWrapper),
 	}
 
 	triageUpdateCmd.PersistentFlags().String(params.SimilarityIDFlag, "", "Similarity ID")
+	triageUpdateCmd.PersistentFlags().String(params.VulnerabilitiesFlag, "", vulnerabilitiesFlagDescription)
 	triageUpdateCmd.PersistentFlags().String(params.SeverityFlag, "", "Severity")
 	triageUpdateCmd.PersistentFlags().String(params.ProjectIDFlag, "", "Project ID.")
 	triageUpdateCmd.PersistentFlags().String(params.StateFlag, "", "State")
 	triageUpdateCmd.PersistentFlags().String(params.CommentFlag, "", "Optional comment.")
 	triageUpdateCmd.PersistentFlags().String(params.ScanTypeFlag, "", "Scan Type")
 
-	markFlagAsRequired(triageUpdateCmd, params.SimilarityIDFlag)
-	markFlagAsRequired(triageUpdateCmd, params.SeverityFlag)
 	markFlagAsRequired(triageUpdateCmd, params.ProjectIDFlag)
 	markFlagAsRequired(triageUpdateCmd, params.StateFlag)
 	markFlagAsRequired(triageUpdateCmd, params.ScanTypeFlag)
@@ -97,6 +114,7 @@ func runTriageShow(resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper) f
 		var err error
 
 		similarityID, _ := cmd.Flags().GetString(params.SimilarityIDFlag)
+		vulnerabilities, _ := cmd.Flags().GetString(params.VulnerabilitiesFlag)
 		scanType, _ := cmd.Flags().GetString(params.ScanTypeFlag)
 		projectID, _ := cmd.Flags().GetString(params.ProjectIDFlag)
 
@@ -105,11 +123,22 @@ func runTriageShow(resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper) f
 			return errors.Errorf("%s", "Multiple project-ids are not allowed.")
 		}
 
-		predicatesCollection, errorModel, err = resultsPredicatesWrapper.GetAllPredicatesForSimilarityID(
-			similarityID,
-			projectID,
-			scanType,
-		)
+		if isVulnerabilityScanType(scanType) {
+			vulnerability, parseErr := parseVulnerability(vulnerabilities, scanType)
+			if parseErr != nil {
+				return parseErr
+			}
+			predicatesCollection, errorModel, err = resultsPredicatesWrapper.GetAllPredicatesForVulnerability(vulnerability, projectID, scanType)
+		} else {
+			if similarityID == "" {
+				return errors.Errorf("required flag(s) \"%s\" not set", params.SimilarityIDFlag)
+			}
+			predicatesCollection, errorModel, err = resultsPredicatesWrapper.GetAllPredicatesForSimilarityID(
+				similarityID,
+				projectID,
+				scanType,
+			)
+		}
 
 		if err != nil {
 			return errors.Wrapf(err, "%s", "Failed showing the predicate")
@@ -142,6 +171,20 @@ func runTriageUpdate(resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
 		state, _ := cmd.Flags().GetString(params.StateFlag)
 		comment, _ := cmd.Flags().GetString(params.CommentFlag)
 		scanType, _ := cmd.Flags().GetString(params.ScanTypeFlag)
+		if isVulnerabilityScanType(scanType) {
+			vulnerabilities, _ := cmd.Flags().GetString(params.VulnerabilitiesFlag)
+			return updateVulnerabilityPredicate(resultsPredicatesWrapper, vulnerabilities, projectID, severity, state, comment, scanType)
+		}
+		var missingFlags []string
+		if severity == "" {
+			missingFlags = append(missingFlags, fmt.Sprintf("%q", params.SeverityFlag))
+		}
+		if similarityID == "" {
+			missingFlags = append(missingFlags, fmt.Sprintf("%q", params.SimilarityIDFlag))
+		}
+		if len(missingFlags) > 0 {
+			return errors.Errorf("required flag(s) %s not set", strings.Join(missingFlags, ", "))
+		}
 		// check if the current tenant has critical severity available
 		flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.CVSSV3Enabled)
 		criticalEnabled := flagResponse.Status
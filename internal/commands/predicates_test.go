@@ -4,6 +4,8 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"gotest.tools/assert"
@@ -38,7 +40,9 @@ func TestRunUpdateTriageCommand(t *testing.T) {
 
 func TestRunShowTriageCommandWithNoInput(t *testing.T) {
 	err := execCmdNotNilAssertion(t, "triage", "show")
-	assert.Assert(t, err.Error() == "required flag(s) \"project-id\", \"scan-type\", \"similarity-id\" not set")
+	assert.Assert(t, err.Error() == "required flag(s) \"project-id\", \"scan-type\" not set")
+	err = execCmdNotNilAssertion(t, "triage", "show", "--project-id", "MOCK", "--scan-type", "sast")
+	assert.Assert(t, err.Error() == "required flag(s) \"similarity-id\" not set")
 }
 
 func TestRunUpdateTriageCommandWithNoInput(t *testing.T) {
@@ -46,5 +50,83 @@ func TestRunUpdateTriageCommandWithNoInput(t *testing.T) {
 	fmt.Println(err)
 	assert.Assert(
 		t,
-		err.Error() == "required flag(s) \"project-id\", \"scan-type\", \"severity\", \"similarity-id\", \"state\" not set")
+		err.Error() == "required flag(s) \"project-id\", \"scan-type\", \"state\" not set")
+	err = execCmdNotNilAssertion(t, "triage", "update", "--project-id", "MOCK", "--scan-type", "sast", "--state", "confirmed")
+	assert.Assert(t, err.Error() == "required flag(s) \"severity\", \"similarity-id\" not set")
+}
+
+func TestRunShowTriageCommandForScaVulnerability(t *testing.T) {
+	execCmdNilAssertion(t, "triage", "show", "--project-id", "MOCK", "--scan-type", "sca",
+		"--vulnerabilities", "packagename=lodash,packageversion=4.17.15,packagemanager=npm,vulnerabilityId=CVE-2020-8203")
+}
+
+func TestRunUpdateTriageCommandForScaVulnerability(t *testing.T) {
+	execCmdNilAssertion(t, "triage", "update", "--project-id", "MOCK", "--scan-type", "sca", "--state", "not_exploitable",
+		"--comment", "Not reachable", "--vulnerabilities", "packagename=lodash,packageversion=4.17.15,packagemanager=npm,vulnerabilityId=CVE-2020-8203")
+}
+
+func TestRunUpdateTriageCommandForContainerVulnerability(t *testing.T) {
+	execCmdNilAssertion(t, "triage", "update", "--project-id", "MOCK", "--scan-type", "containers", "--state", "confirmed",
+		"--vulnerabilities", "imagename=nginx,imagetag=1.25,packagename=openssl,packageversion=3.0.2,vulnerabilityid=CVE-2023-0286")
+}
+
+func TestRunUpdateTriageCommandForContainerVulnerabilityWithMissingImage(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "update", "--project-id", "MOCK", "--scan-type", "containers", "--state", "confirmed",
+		"--vulnerabilities", "packagename=openssl,packageversion=3.0.2,vulnerabilityid=CVE-2023-0286")
+	assert.Equal(t, err.Error(), "Missing imagename, imagetag in --vulnerabilities")
+}
+
+func TestRunUpdateTriageCommandForScaVulnerabilityWithSeverity(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "update", "--project-id", "MOCK", "--scan-type", "sca", "--state", "confirmed", "--severity", "low",
+		"--vulnerabilities", "packagename=lodash,packageversion=4.17.15,packagemanager=npm,vulnerabilityId=CVE-2020-8203")
+	assert.Equal(t, err.Error(), "The severity of sca vulnerabilities can't be changed")
+}
+
+func TestRunImportTriageCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "decisions.csv")
+	err := os.WriteFile(file, []byte("projectId,similarityId,scanType,state,severity,comment\nMOCK,MOCK,sast,confirmed,low,checked\n"), 0600)
+	assert.NilError(t, err)
+	execCmdNilAssertion(t, "triage", "import", "--file", file, "--dry-run")
+	execCmdNilAssertion(t, "triage", "import", "--file", file, "--concurrency", "2", "--format", "json")
+}
+
+func TestRunImportTriageCommandWithInvalidRows(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "decisions.json")
+	err := os.WriteFile(file, []byte(`[{"projectId":"MOCK","similarityId":"MOCK","scanType":"sast","state":"FIXED"}]`), 0600)
+	assert.NilError(t, err)
+	err = execCmdNotNilAssertion(t, "triage", "import", "--file", file)
+	assert.Equal(t, err.Error(), "Failed importing triage decisions: 1 of 1 rows failed")
+}
+
+func TestRunExportTriageCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "triage.json")
+	execCmdNilAssertion(t, "triage", "export", "--project-id", "MOCK", "--file", file)
+	_, err := os.Stat(file)
+	assert.NilError(t, err)
+}
+
+func TestRunPropagateTriageCommand(t *testing.T) {
+	execCmdNilAssertion(t, "triage", "propagate", "--from-project", "MOCK", "--to-project", "MOCK2", "--on-conflict", "newest", "--dry-run")
+}
+
+func TestRunPropagateTriageCommandWithInvalidConflictMode(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "propagate", "--from-project", "MOCK", "--to-project", "MOCK2", "--on-conflict", "merge")
+	assert.Equal(t, err.Error(), "Failed propagating triage decisions: invalid --on-conflict merge, use one of skip, overwrite, newest")
+
+	err = execCmdNotNilAssertion(t, "triage", "propagate", "--from-project", "MOCK", "--to-project", "MOCK2", "--on-conflict", "skip-all")
+	assert.ErrorContains(t, err, "invalid --on-conflict skip-all")
+}
+
+func TestRunSuppressTriageCommand(t *testing.T) {
+	execCmdNilAssertion(t, "triage", "suppress", "--scan-id", "MOCK", "-s", t.TempDir(), "--dry-run")
+}
+
+func TestRunSuppressTriageCommandWithMissingSources(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "suppress", "--scan-id", "MOCK", "-s", "missing-dir")
+	assert.Equal(t, err.Error(), "Failed applying suppression comments: missing-dir is not a directory")
+}
+
+func TestTriageTuiWithoutTerminal(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "tui", "--scan-id", "MOCK")
+	assert.ErrorContains(t, err, "needs an interactive terminal")
 }
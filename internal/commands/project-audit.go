@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/semaphore"
+)
+
+const (
+	failedAuditingProjects  = "Failed auditing projects"
+	projectAuditDefaultName = "cx_project_audit"
+	projectAuditStaleDays   = 30
+	projectAuditEngines     = "sast,sca,kics"
+	projectAuditScansPage   = 100
+	projectAuditNoScan      = -1
+)
+
+// projectAuditEngineNames maps the scan types accepted by scan create to the engine names of the scans
+var projectAuditEngineNames = map[string]string{
+	commonParams.IacType:         commonParams.KicsType,
+	commonParams.APISecurityType: commonParams.APISecType,
+	commonParams.ScsType:         commonParams.MicroEnginesType,
+}
+
+func projectAuditSubCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Report the scanning coverage of the projects",
+		Long: "The audit command reports the projects without scans in the last days, the projects whose main branch was never scanned, " +
+			"the projects without groups or application and the engines that never ran on each project.",
+		Example: heredoc.Doc(
+			`
+			$ cx project audit --stale-days 60 --report-format json,csv
+			$ cx project audit --project-tags team:payments --scan-types sast,sca,iac-security,api-security
+		`,
+		),
+		RunE: runProjectAudit(projectsWrapper, scansWrapper, applicationsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper),
+	}
+	auditCmd.PersistentFlags().Int(commonParams.StaleDaysFlag, projectAuditStaleDays, "Number of days without scans after which a project is stale")
+	auditCmd.PersistentFlags().String(commonParams.ScanTypes, projectAuditEngines, "Engines expected to run on every project, ex: (sast,iac-security,sca,api-security)")
+	auditCmd.PersistentFlags().String(commonParams.ProjectTagList, "", "Only include projects with these tags, ex: (tagA,tagB:val,etc)")
+	auditCmd.PersistentFlags().String(commonParams.ApplicationName, "", "Only include projects associated with this application")
+	auditCmd.PersistentFlags().String(commonParams.ProjectGroupList, "", "Only include projects assigned to one of these groups, ex: (PowerUsers,etc)")
+	auditCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterProjectsListFlagUsage)
+	addResultFormatFlag(auditCmd, printer.FormatJSON, printer.FormatCSV)
+	auditCmd.PersistentFlags().String(commonParams.TargetFlag, projectAuditDefaultName, "Output file")
+	auditCmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
+	return auditCmd
+}
+
+func runProjectAudit(
+	projectsWrapper wrappers.ProjectsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		staleDays, _ := cmd.Flags().GetInt(commonParams.StaleDaysFlag)
+		scanTypes, _ := cmd.Flags().GetString(commonParams.ScanTypes)
+		targetFile, _ := cmd.Flags().GetString(commonParams.TargetFlag)
+		targetPath, _ := cmd.Flags().GetString(commonParams.TargetPathFlag)
+		reportFormats, _ := cmd.Flags().GetString(commonParams.TargetFormatFlag)
+		if staleDays < 1 {
+			return errors.Errorf("--%s should be higher than 0", commonParams.StaleDaysFlag)
+		}
+
+		projects, err := getPortfolioProjects(cmd, projectsWrapper, applicationsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedAuditingProjects)
+		}
+		logger.PrintfIfVerbose("Auditing %d projects", len(projects))
+
+		audit, err := auditProjects(projects, projectsWrapper, scansWrapper, accessManagementWrapper, isAccessManagementEnabled(featureFlagsWrapper),
+			parseAuditEngines(scanTypes), staleDays, time.Now())
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedAuditingProjects)
+		}
+
+		err = createDirectory(targetPath)
+		if err != nil {
+			return err
+		}
+		for _, reportFormat := range strings.Split(reportFormats, ",") {
+			err = createProjectAuditReport(strings.TrimSpace(reportFormat), targetFile, targetPath, audit)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func parseAuditEngines(scanTypes string) []string {
+	var engines []string
+	for _, scanType := range strings.Split(scanTypes, ",") {
+		engine := strings.ToLower(strings.TrimSpace(scanType))
+		if name, found := projectAuditEngineNames[engine]; found {
+			engine = name
+		}
+		if engine != "" && !slices.Contains(engines, engine) {
+			engines = append(engines, engine)
+		}
+	}
+	return engines
+}
+
+func auditProjects(
+	projects []wrappers.ProjectResponseModel,
+	projectsWrapper wrappers.ProjectsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	accessManagementEnabled bool,
+	engines []string,
+	staleDays int,
+	now time.Time,
+) (*wrappers.ProjectAudit, error) {
+	sem := semaphore.NewWeighted(portfolioMaxConcurrency)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	entries := make([]*wrappers.ProjectAuditEntry, len(projects))
+	for i := range projects {
+		err := sem.Acquire(ctx, 1)
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func(index int, project wrappers.ProjectResponseModel) {
+			defer wg.Done()
+			defer sem.Release(1)
+			entries[index] = auditProject(&project, projectsWrapper, scansWrapper, accessManagementWrapper, accessManagementEnabled, engines, staleDays, now)
+		}(i, projects[i])
+	}
+	wg.Wait()
+
+	audit := &wrappers.ProjectAudit{
+		CreatedAt:       now.Format(summaryCreatedAtLayout),
+		StaleDays:       staleDays,
+		TotalProjects:   len(entries),
+		EnginesNeverRun: make(map[string]int),
+		Projects:        entries,
+	}
+	for _, engine := range engines {
+		audit.EnginesNeverRun[engine] = 0
+	}
+	for _, entry := range entries {
+		if entry.Stale {
+			audit.StaleProjects++
+		}
+		if !entry.MainBranchScanned {
+			audit.MainBranchNotScanned++
+		}
+		if !entry.HasGroups {
+			audit.WithoutGroups++
+		}
+		if !entry.HasApplication {
+			audit.WithoutApplication++
+		}
+		for _, engine := range entry.EnginesNeverRun {
+			audit.EnginesNeverRun[engine]++
+		}
+	}
+	return audit, nil
+}
+
+// auditProject checks the recent completed scans, the scanned branches and the groups of one project
+func auditProject(
+	project *wrappers.ProjectResponseModel,
+	projectsWrapper wrappers.ProjectsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	accessManagementEnabled bool,
+	engines []string,
+	staleDays int,
+	now time.Time,
+) *wrappers.ProjectAuditEntry {
+	entry := &wrappers.ProjectAuditEntry{
+		ProjectID:         project.ID,
+		ProjectName:       project.Name,
+		MainBranch:        project.MainBranch,
+		DaysSinceLastScan: projectAuditNoScan,
+		Stale:             true,
+		HasApplication:    len(project.ApplicationIds) > 0,
+		EnginesRun:        []string{},
+		EnginesNeverRun:   []string{},
+	}
+
+	groups, err := getProjectGroups(project, accessManagementWrapper, accessManagementEnabled)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+	entry.HasGroups = len(groups.ids) > 0
+
+	scans, err := getAllProjectScans(scansWrapper, project.ID)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+
+	var lastScan *wrappers.ScanResponseModel
+	enginesRun := make(map[string]bool)
+	for i := range scans {
+		scan := &scans[i]
+		if lastScan == nil || scan.CreatedAt.After(lastScan.CreatedAt) {
+			lastScan = scan
+		}
+		for _, engine := range scan.Engines {
+			enginesRun[strings.ToLower(engine)] = true
+		}
+	}
+	if lastScan != nil {
+		entry.LastScanID = lastScan.ID
+		entry.LastScanCreatedAt = lastScan.CreatedAt.Format(summaryCreatedAtLayout)
+		entry.DaysSinceLastScan = int(now.Sub(lastScan.CreatedAt).Hours() / hoursPerDay)
+	}
+	entry.Stale = entry.DaysSinceLastScan == projectAuditNoScan || entry.DaysSinceLastScan >= staleDays
+	entry.EnginesRun = sortedKeys(enginesRun)
+	for _, engine := range engines {
+		if !enginesRun[engine] {
+			entry.EnginesNeverRun = append(entry.EnginesNeverRun, engine)
+		}
+	}
+
+	if project.MainBranch == "" {
+		return entry
+	}
+	branches, errorModel, err := projectsWrapper.GetBranchesByID(project.ID, map[string]string{commonParams.BranchNameQueryParam: project.MainBranch})
+	if err != nil {
+		entry.Error = errors.Wrapf(err, "%s", failedGettingBranches).Error()
+		return entry
+	}
+	if errorModel != nil {
+		entry.Error = errors.Errorf(services.ErrorCodeFormat, failedGettingBranches, errorModel.Code, errorModel.Message).Error()
+		return entry
+	}
+	entry.MainBranchScanned = slices.Contains(branches, project.MainBranch)
+	return entry
+}
+
+// getAllProjectScans reads every completed scan of a project, one page at a time, so the engines of the older scans are counted too
+func getAllProjectScans(scansWrapper wrappers.ScansWrapper, projectID string) ([]wrappers.ScanResponseModel, error) {
+	var scans []wrappers.ScanResponseModel
+	for offset := 0; ; offset += projectAuditScansPage {
+		page, errorModel, err := scansWrapper.Get(map[string]string{
+			commonParams.ProjectIDQueryParam: projectID,
+			commonParams.StatusesQueryParam:  latestScanStatusesFilter,
+			commonParams.SortQueryParam:      latestScanSort,
+			commonParams.LimitQueryParam:     strconv.Itoa(projectAuditScansPage),
+			commonParams.OffsetQueryParam:    strconv.Itoa(offset),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", failedGettingAll)
+		}
+		if errorModel != nil {
+			return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+		}
+		scans = append(scans, page.Scans...)
+		if len(page.Scans) < projectAuditScansPage || len(scans) >= int(page.FilteredTotalCount) {
+			return scans, nil
+		}
+	}
+}
+
+func createProjectAuditReport(format, targetFile, targetPath string, audit *wrappers.ProjectAudit) error {
+	if printer.IsFormat(format, printer.FormatJSON) {
+		return writeJSONReport(createTargetName(targetFile, targetPath, printer.FormatJSON), audit)
+	}
+	if printer.IsFormat(format, printer.FormatCSV) {
+		return writeProjectAuditCSV(createTargetName(targetFile, targetPath, printer.FormatCSV), audit)
+	}
+	return errors.Errorf("bad report format %s", format)
+}
+
+func writeProjectAuditCSV(targetFile string, audit *wrappers.ProjectAudit) error {
+	log.Println("Creating Project Audit Report: ", targetFile)
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	writer := csv.NewWriter(f)
+	_ = writer.Write([]string{
+		"Project ID", "Project Name", "Main Branch", "Last Scan ID", "Last Scan Created At", "Days Since Last Scan", "Stale",
+		"Main Branch Scanned", "Has Groups", "Has Application", "Engines Run", "Engines Never Run", "Error",
+	})
+	for _, project := range audit.Projects {
+		daysSinceLastScan := ""
+		if project.DaysSinceLastScan != projectAuditNoScan {
+			daysSinceLastScan = strconv.Itoa(project.DaysSinceLastScan)
+		}
+		_ = writer.Write([]string{
+			project.ProjectID, project.ProjectName, project.MainBranch, project.LastScanID, project.LastScanCreatedAt, daysSinceLastScan,
+			strconv.FormatBool(project.Stale), strconv.FormatBool(project.MainBranchScanned), strconv.FormatBool(project.HasGroups),
+			strconv.FormatBool(project.HasApplication), strings.Join(project.EnginesRun, ";"), strings.Join(project.EnginesNeverRun, ";"), project.Error,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,116 @@
+//go:build !integration
+
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+// auditedScansWrapper serves fixed scans per project
+type auditedScansWrapper struct {
+	mock.ScansMockWrapper
+	scans map[string][]wrappers.ScanResponseModel
+}
+
+func (s *auditedScansWrapper) Get(params map[string]string) (*wrappers.ScansCollectionResponseModel, *wrappers.ErrorModel, error) {
+	scans := s.scans[params["project-id"]]
+	offset, _ := strconv.Atoi(params["offset"])
+	limit, _ := strconv.Atoi(params["limit"])
+	page := scans[min(offset, len(scans)):min(offset+limit, len(scans))]
+	return &wrappers.ScansCollectionResponseModel{TotalCount: uint(len(scans)), FilteredTotalCount: uint(len(scans)), Scans: page}, nil, nil
+}
+
+func TestAuditProjects(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	scansWrapper := &auditedScansWrapper{scans: map[string][]wrappers.ScanResponseModel{
+		"P1": {
+			{ID: "S2", CreatedAt: now.AddDate(0, 0, -40), Engines: []string{"sast", "kics"}},
+			{ID: "S1", CreatedAt: now.AddDate(0, 0, -5), Engines: []string{"sast"}},
+		},
+		"P2": {{ID: "S3", CreatedAt: now.AddDate(0, 0, -45), Engines: []string{"sca"}}},
+	}}
+	projects := []wrappers.ProjectResponseModel{
+		{ID: "P1", Name: "web", MainBranch: "master", Groups: []string{"G1"}, ApplicationIds: []string{"A1"}},
+		{ID: "P2", Name: "api", MainBranch: "main"},
+		{ID: "P3", Name: "empty"},
+	}
+
+	audit, err := auditProjects(projects, &mock.ProjectsMockWrapper{}, scansWrapper, &mock.AccessManagementMockWrapper{}, false,
+		parseAuditEngines("sast,sca,iac-security"), 30, now)
+	assert.NilError(t, err)
+	assert.Equal(t, audit.TotalProjects, 3)
+	assert.Equal(t, audit.StaleProjects, 2)
+	assert.Equal(t, audit.MainBranchNotScanned, 2)
+	assert.Equal(t, audit.WithoutGroups, 2)
+	assert.Equal(t, audit.WithoutApplication, 2)
+	assert.DeepEqual(t, audit.EnginesNeverRun, map[string]int{"sast": 2, "sca": 2, "kics": 2})
+
+	web := audit.Projects[0]
+	assert.Equal(t, web.LastScanID, "S1")
+	assert.Equal(t, web.DaysSinceLastScan, 5)
+	assert.Assert(t, !web.Stale)
+	assert.Assert(t, web.MainBranchScanned)
+	assert.DeepEqual(t, web.EnginesRun, []string{"kics", "sast"})
+	assert.DeepEqual(t, web.EnginesNeverRun, []string{"sca"})
+
+	empty := audit.Projects[2]
+	assert.Equal(t, empty.DaysSinceLastScan, projectAuditNoScan)
+	assert.Assert(t, empty.Stale)
+}
+
+func TestAuditProjectReadsAllScans(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	var scans []wrappers.ScanResponseModel
+	for i := 0; i < projectAuditScansPage+10; i++ {
+		scans = append(scans, wrappers.ScanResponseModel{ID: strconv.Itoa(i), CreatedAt: now.AddDate(0, 0, -i), Engines: []string{"sast"}})
+	}
+	scans[len(scans)-1].Engines = []string{"sca"}
+	scansWrapper := &auditedScansWrapper{scans: map[string][]wrappers.ScanResponseModel{"P1": scans}}
+
+	entry := auditProject(&wrappers.ProjectResponseModel{ID: "P1"}, &mock.ProjectsMockWrapper{}, scansWrapper, &mock.AccessManagementMockWrapper{}, false,
+		parseAuditEngines("sast,sca"), 30, now)
+	assert.Equal(t, entry.LastScanID, "0")
+	assert.DeepEqual(t, entry.EnginesRun, []string{"sast", "sca"})
+	assert.DeepEqual(t, entry.EnginesNeverRun, []string{})
+}
+
+func TestAuditProjectsGroupsFromAccessManagement(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	projects := []wrappers.ProjectResponseModel{
+		{ID: "P1", Name: "legacy-groups", Groups: []string{"G1"}},
+		{ID: "P2", Name: "assigned"},
+	}
+	accessWrapper := &projectGroupsAccessWrapper{groups: map[string][]*wrappers.Group{"P2": {{ID: "G1", Name: "Dev"}}}}
+
+	audit, err := auditProjects(projects, &mock.ProjectsMockWrapper{}, &auditedScansWrapper{}, accessWrapper, true, parseAuditEngines("sast"), 30, now)
+	assert.NilError(t, err)
+	assert.Equal(t, audit.WithoutGroups, 1)
+	assert.Assert(t, !audit.Projects[0].HasGroups)
+	assert.Assert(t, audit.Projects[1].HasGroups)
+}
+
+func TestProjectAuditReports(t *testing.T) {
+	outputPath := t.TempDir()
+	execCmdNilAssertion(t, "project", "audit", "--report-format", "json,csv", "--output-path", outputPath, "--scan-types", "sast,sca")
+
+	content, err := os.ReadFile(filepath.Join(outputPath, projectAuditDefaultName+".json"))
+	assert.NilError(t, err)
+	var audit wrappers.ProjectAudit
+	assert.NilError(t, json.Unmarshal(content, &audit))
+	assert.Equal(t, audit.TotalProjects, 1)
+	assert.Equal(t, audit.StaleDays, projectAuditStaleDays)
+	_, err = os.Stat(filepath.Join(outputPath, projectAuditDefaultName+".csv"))
+	assert.NilError(t, err)
+
+	err = execCmdNotNilAssertion(t, "project", "audit", "--stale-days", "0")
+	assert.ErrorContains(t, err, "--stale-days should be higher than 0")
+}
@@ -0,0 +1,474 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/MakeNowJust/heredoc"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedBackingUpProject      = "Failed backing up the project"
+	failedRestoringProject      = "Failed restoring the project"
+	backupProjectFile           = "project.json"
+	backupConfigurationFile     = "project-configuration.json"
+	backupGroupsFile            = "project-groups.json"
+	backupTriageFile            = "triage.json"
+	restoreItemProject          = "Project"
+	restoreItemGroups           = "Groups"
+	restoreItemConfiguration    = "Configuration"
+	restoreItemTriage           = "Triage"
+	restoreStatusRestored       = "RESTORED"
+	restoreStatusSkipped        = "SKIPPED"
+	restoreStatusFailed         = "FAILED"
+	restoreFailedItems          = "%s: %d of %d items failed"
+	projectDeletionCancelled    = "Project deletion cancelled"
+	projectDeletionNoBackupNote = "none, the project can't be restored"
+)
+
+// projectDeletionSummary is what is lost when a project is deleted, shown before the deletion is confirmed
+type projectDeletionSummary struct {
+	project        *wrappers.ProjectResponseModel
+	groups         []accessGrant
+	scans          uint
+	triagedResults int
+	backupFile     string
+}
+
+type projectRestoreView struct {
+	Item    string
+	Status  string
+	Details string
+}
+
+func projectRestoreSubCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Recreate a deleted project from a backup archive",
+		Long: "The restore command creates the project saved by 'project delete --backup' with its tags, groups and scan configuration, " +
+			"then applies the triage history of its results again. Scans and results are not recreated, scan the project again to get them back.",
+		Example: heredoc.Doc(
+			`
+			$ cx project restore --file web-backup.zip
+			$ cx project restore --file web-backup.zip --project-name web-restored --format json
+		`,
+		),
+		RunE: runRestoreProject(projectsWrapper, resultsPredicatesWrapper, accessManagementWrapper, featureFlagsWrapper),
+	}
+	restoreCmd.PersistentFlags().String(commonParams.TriageFileFlag, "", "Backup archive created by 'project delete --backup'")
+	restoreCmd.PersistentFlags().String(commonParams.ProjectName, "", "Name of the restored project, the name of the deleted project by default")
+	restoreCmd.PersistentFlags().Int(commonParams.ConcurrencyFlag, triageDefaultConcurrency, "Number of results triaged concurrently")
+	markFlagAsRequired(restoreCmd, commonParams.TriageFileFlag)
+	return restoreCmd
+}
+
+func runRestoreProject(
+	projectsWrapper wrappers.ProjectsWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		file, _ := cmd.Flags().GetString(commonParams.TriageFileFlag)
+		projectName, _ := cmd.Flags().GetString(commonParams.ProjectName)
+		concurrency, _ := cmd.Flags().GetInt(commonParams.ConcurrencyFlag)
+		if concurrency <= 0 {
+			return errors.Errorf("--%s should be higher than 0", commonParams.ConcurrencyFlag)
+		}
+		backup, err := loadResultsBundle(file)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedRestoringProject)
+		}
+		flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.CVSSV3Enabled)
+		views, err := restoreProject(backup, projectName, projectsWrapper, resultsPredicatesWrapper, accessManagementWrapper,
+			isAccessManagementEnabled(featureFlagsWrapper), flagResponse.Status, concurrency)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedRestoringProject)
+		}
+		err = printByFormat(cmd, views)
+		if err != nil {
+			return err
+		}
+		failedItems := 0
+		for i := range views {
+			if views[i].Status == restoreStatusFailed {
+				failedItems++
+			}
+		}
+		if failedItems > 0 {
+			return errors.Errorf(restoreFailedItems, failedRestoringProject, failedItems, len(views))
+		}
+		return nil
+	}
+}
+
+// prepareProjectDeletion reads what the deletion of a project loses, saving it to backupFile when set
+func prepareProjectDeletion(
+	projectID, backupFile string,
+	concurrency int,
+	projectsWrapper wrappers.ProjectsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) (*projectDeletionSummary, error) {
+	project, errorModel, err := projectsWrapper.GetByID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	accessManagementEnabled := isAccessManagementEnabled(featureFlagsWrapper)
+	groups, err := getAccessGrants(project, accessManagementWrapper, accessManagementEnabled)
+	if err != nil {
+		return nil, err
+	}
+	scans, errorModel, err := scansWrapper.Get(map[string]string{
+		commonParams.ProjectIDQueryParam: projectID,
+		commonParams.LimitQueryParam:     "1",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	summary := &projectDeletionSummary{project: project, groups: groups, scans: scans.TotalCount, backupFile: backupFile}
+	if backupFile == "" {
+		return summary, nil
+	}
+
+	backup, triage, err := createProjectBackup(project, groups, concurrency, projectsWrapper, scansWrapper, resultsWrapper, resultsPredicatesWrapper)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedBackingUpProject)
+	}
+	backup.addFeatureFlags(featureFlagsWrapper)
+	err = backup.save(backupFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedBackingUpProject)
+	}
+	if triage != nil {
+		summary.triagedResults = len(triage.Results)
+	}
+	return summary, nil
+}
+
+// createProjectBackup saves the project, its scan configuration, groups, the latest scan with its results and the triage history
+// of these results. The archive is also a results bundle of the latest scan.
+func createProjectBackup(
+	project *wrappers.ProjectResponseModel,
+	groups []accessGrant,
+	concurrency int,
+	projectsWrapper wrappers.ProjectsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+) (*resultsBundle, *wrappers.TriageExport, error) {
+	backup := newResultsBundle()
+	backup.add(backupProjectFile, project)
+
+	configuration, err := fetchProjectConfiguration(projectsWrapper, project.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	projectConfiguration := make(map[string]string)
+	for i := range configuration {
+		if configuration[i].OriginLevel == projOriginLevel && isExportableProjectConfig(&configuration[i]) {
+			projectConfiguration[configuration[i].Key] = configuration[i].Value
+		}
+	}
+	backup.add(backupConfigurationFile, projectConfiguration)
+
+	groupEntries := []wrappers.AccessGroupEntry{}
+	for _, grant := range groups {
+		groupEntries = append(groupEntries, wrappers.AccessGroupEntry{ID: grant.GroupID, Name: grant.GroupName, Roles: grant.Roles})
+	}
+	backup.add(backupGroupsFile, groupEntries)
+
+	scan, err := getLatestScan(scansWrapper, project.ID, "")
+	if err != nil || scan == nil {
+		return backup, nil, err
+	}
+	backup.add(bundleScanFile, scan)
+	baseURI, err := resultsWrapper.GetResultsURL(project.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	backup.add(bundleBaseURIFile, baseURI)
+	results := &wrappers.ScanResultsCollection{ScanID: scan.ID, Results: []*wrappers.ScanResult{}}
+	var triageable []*wrappers.ScanResult
+	seen := make(map[string]bool)
+	err = forEachResultsPage(resultsWrapper, map[string]string{commonParams.ScanIDQueryParam: scan.ID}, func(page *wrappers.ScanResultsCollection) error {
+		results.Results = append(results.Results, page.Results...)
+		triageable = appendTriageableResults(triageable, page.Results, seen)
+		triageable = appendVulnerabilityResults(triageable, page.Results, seen)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	results.TotalCount = uint(len(results.Results))
+	backup.add(bundleResultsFile, results)
+
+	triage, err := getTriagedResults(resultsPredicatesWrapper, scan, triageable, project.ID, concurrency)
+	if err != nil {
+		return nil, nil, err
+	}
+	backup.add(backupTriageFile, triage)
+	return backup, triage, nil
+}
+
+// appendVulnerabilityResults appends the SCA and container results with a known package whose key wasn't seen yet
+func appendVulnerabilityResults(results, page []*wrappers.ScanResult, seen map[string]bool) []*wrappers.ScanResult {
+	for _, result := range page {
+		if !isVulnerabilityScanType(result.Type) || seen[resultMatchKey(result)] {
+			continue
+		}
+		vulnerability := vulnerabilityFromResult(result)
+		if vulnerability.PackageName == "" || vulnerability.VulnerabilityID == "" {
+			continue
+		}
+		seen[resultMatchKey(result)] = true
+		results = append(results, result)
+	}
+	return results
+}
+
+// confirmProjectDeletion shows what the deletion loses and reads the answer of the user
+func confirmProjectDeletion(in io.Reader, out io.Writer, summary *projectDeletionSummary) bool {
+	project := summary.project
+	fmt.Fprintf(out, "Project:      %s (%s)\n", project.Name, project.ID)
+	fmt.Fprintf(out, "Tags:         %s\n", formatProjectTags(project.Tags))
+	var groups []string
+	for _, grant := range summary.groups {
+		name := grant.GroupName
+		if name == "" {
+			name = grant.GroupID
+		}
+		groups = append(groups, name)
+	}
+	fmt.Fprintf(out, "Groups:       %s\n", formatSorted(groups))
+	fmt.Fprintf(out, "Applications: %d\n", len(project.ApplicationIds))
+	fmt.Fprintf(out, "Scans:        %d\n", summary.scans)
+	if summary.backupFile == "" {
+		fmt.Fprintf(out, "Backup:       %s\n", projectDeletionNoBackupNote)
+	} else {
+		fmt.Fprintf(out, "Triaged:      %d results\n", summary.triagedResults)
+		fmt.Fprintf(out, "Backup:       %s\n", summary.backupFile)
+	}
+	fmt.Fprintf(out, "Delete project %s and all its scans and results? [y/N]: ", project.Name)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// restoreProject creates the project of a backup, then restores its groups, configuration and triage, reporting each of them
+func restoreProject(
+	backup *resultsBundle,
+	projectName string,
+	projectsWrapper wrappers.ProjectsWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	accessManagementEnabled, criticalEnabled bool,
+	concurrency int,
+) ([]projectRestoreView, error) {
+	saved := &wrappers.ProjectResponseModel{}
+	found, err := backup.get(backupProjectFile, saved)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.Errorf("%s not found in the backup", backupProjectFile)
+	}
+	var groups []wrappers.AccessGroupEntry
+	_, err = backup.get(backupGroupsFile, &groups)
+	if err != nil {
+		return nil, err
+	}
+	configuration := make(map[string]string)
+	_, err = backup.get(backupConfigurationFile, &configuration)
+	if err != nil {
+		return nil, err
+	}
+	triage := &wrappers.TriageExport{}
+	_, err = backup.get(backupTriageFile, triage)
+	if err != nil {
+		return nil, err
+	}
+
+	if projectName == "" {
+		projectName = saved.Name
+	}
+	projModel := wrappers.Project{
+		Name:           projectName,
+		RepoURL:        saved.RepoURL,
+		MainBranch:     saved.MainBranch,
+		Tags:           saved.Tags,
+		PrivatePackage: saved.PrivatePackage,
+		ApplicationIds: saved.ApplicationIds,
+	}
+	if !accessManagementEnabled {
+		for _, group := range groups {
+			projModel.Groups = append(projModel.Groups, group.ID)
+		}
+	}
+	project, errorModel, err := projectsWrapper.Create(&projModel)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	views := []projectRestoreView{{Item: restoreItemProject, Status: restoreStatusRestored, Details: project.ID}}
+	views = append(views,
+		restoreProjectGroups(project, groups, accessManagementWrapper, projectsWrapper, accessManagementEnabled),
+		restoreProjectConfiguration(project.ID, configuration, projectsWrapper),
+		restoreProjectTriage(project.ID, triage, resultsPredicatesWrapper, criticalEnabled, concurrency),
+	)
+	return views, nil
+}
+
+func restoreProjectGroups(
+	project *wrappers.ProjectResponseModel,
+	groups []wrappers.AccessGroupEntry,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	accessManagementEnabled bool,
+) projectRestoreView {
+	view := projectRestoreView{Item: restoreItemGroups}
+	if len(groups) == 0 {
+		view.Status = restoreStatusSkipped
+		return view
+	}
+	if accessManagementEnabled {
+		var grants []accessGrant
+		for _, group := range groups {
+			grants = append(grants, accessGrant{GroupID: group.ID, GroupName: group.Name, Roles: group.Roles})
+		}
+		_, err := applyAccessGrants(project, grants, nil, false, false, accessManagementWrapper, projectsWrapper, true)
+		if err != nil {
+			view.Status = restoreStatusFailed
+			view.Details = err.Error()
+			return view
+		}
+	}
+	view.Status = restoreStatusRestored
+	view.Details = fmt.Sprintf("%d groups", len(groups))
+	return view
+}
+
+func restoreProjectConfiguration(projectID string, configuration map[string]string, projectsWrapper wrappers.ProjectsWrapper) projectRestoreView {
+	view := projectRestoreView{Item: restoreItemConfiguration}
+	if len(configuration) == 0 {
+		view.Status = restoreStatusSkipped
+		return view
+	}
+	_, err := applyProjectConfiguration(projectsWrapper, projectID, configuration, false, false)
+	if err != nil {
+		view.Status = restoreStatusFailed
+		view.Details = err.Error()
+		return view
+	}
+	view.Status = restoreStatusRestored
+	view.Details = fmt.Sprintf("%d settings", len(configuration))
+	return view
+}
+
+// restoreProjectTriage replays the predicate history of every result, oldest first, so the latest decision ends up current
+func restoreProjectTriage(
+	projectID string,
+	triage *wrappers.TriageExport,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	criticalEnabled bool,
+	concurrency int,
+) projectRestoreView {
+	view := projectRestoreView{Item: restoreItemTriage}
+	if len(triage.Results) == 0 {
+		view.Status = restoreStatusSkipped
+		return view
+	}
+	failures := make(map[string]string)
+	var mutex sync.Mutex
+	runConcurrently(len(triage.Results), concurrency, func(i int) {
+		result := &triage.Results[i]
+		predicates := append([]wrappers.Predicate{}, result.Predicates...)
+		sort.SliceStable(predicates, func(a, b int) bool {
+			return predicates[a].CreatedAt.Before(predicates[b].CreatedAt)
+		})
+		for j := range predicates {
+			failure := replayPredicate(projectID, result, &predicates[j], resultsPredicatesWrapper, criticalEnabled)
+			if failure != "" {
+				mutex.Lock()
+				failures[result.SimilarityID] = failure
+				mutex.Unlock()
+				return
+			}
+		}
+	})
+	if len(failures) > 0 {
+		view.Status = restoreStatusFailed
+		var details []string
+		for _, similarityID := range sortedKeys(failures) {
+			details = append(details, similarityID+": "+failures[similarityID])
+		}
+		view.Details = fmt.Sprintf("%d of %d results failed; %s", len(failures), len(triage.Results), strings.Join(details, "; "))
+		return view
+	}
+	view.Status = restoreStatusRestored
+	view.Details = fmt.Sprintf("%d results", len(triage.Results))
+	return view
+}
+
+// replayPredicate applies one predicate of a backed up result to the project, returning why it failed
+func replayPredicate(
+	projectID string,
+	result *wrappers.TriagedResult,
+	predicate *wrappers.Predicate,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	criticalEnabled bool,
+) string {
+	if result.Vulnerability != nil {
+		webError, err := resultsPredicatesWrapper.PredicateVulnerabilityState(&wrappers.VulnerabilityPredicateRequest{
+			PackageVulnerability: *result.Vulnerability,
+			ProjectIDs:           []string{projectID},
+			Actions: []wrappers.VulnerabilityPredicateAction{
+				{ActionType: wrappers.ChangeStateAction, Value: predicate.State, Comment: predicate.Comment},
+			},
+		}, result.ScanType)
+		if err != nil {
+			return err.Error()
+		}
+		if webError != nil {
+			return fmt.Sprintf("CODE: %d, %s", webError.Code, webError.Message)
+		}
+		return ""
+	}
+	decision := &triageDecision{
+		ProjectID:    projectID,
+		SimilarityID: result.SimilarityID,
+		ScanType:     result.ScanType,
+		State:        predicate.State,
+		Severity:     predicate.Severity,
+		Comment:      predicate.Comment,
+	}
+	applied := applyTriageDecision(resultsPredicatesWrapper, decision, criticalEnabled, false)
+	if applied.Status == triageStatusFailed || applied.Status == triageStatusInvalid {
+		return applied.Error
+	}
+	return ""
+}
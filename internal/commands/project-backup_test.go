@@ -0,0 +1,143 @@
+//go:build !integration
+
+package commands
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+// recordingPredicatesWrapper has no predicate history and records the predicates sent
+type recordingPredicatesWrapper struct {
+	mock.ResultsPredicatesMockWrapper
+	mutex           sync.Mutex
+	predicates      []wrappers.PredicateRequest
+	vulnerabilities []wrappers.VulnerabilityPredicateRequest
+}
+
+func (r *recordingPredicatesWrapper) GetAllPredicatesForSimilarityID(string, string, string) (
+	*wrappers.PredicatesCollectionResponseModel, *wrappers.WebError, error,
+) {
+	return nil, nil, nil
+}
+
+func (r *recordingPredicatesWrapper) PredicateSeverityAndState(predicate *wrappers.PredicateRequest, _ string) (*wrappers.WebError, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.predicates = append(r.predicates, *predicate)
+	return nil, nil
+}
+
+func (r *recordingPredicatesWrapper) PredicateVulnerabilityState(predicate *wrappers.VulnerabilityPredicateRequest, _ string) (*wrappers.WebError, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.vulnerabilities = append(r.vulnerabilities, *predicate)
+	return nil, nil
+}
+
+func TestProjectDeleteWithBackup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "backup.zip")
+	execCmdNilAssertion(t, "project", "delete", "--project-id", "MOCK", "--backup", file)
+
+	backup, err := loadResultsBundle(file)
+	assert.NilError(t, err)
+	project := &wrappers.ProjectResponseModel{}
+	found, err := backup.get(backupProjectFile, project)
+	assert.NilError(t, err)
+	assert.Assert(t, found)
+	assert.Equal(t, project.ID, "MOCK")
+	configuration := make(map[string]string)
+	_, err = backup.get(backupConfigurationFile, &configuration)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, configuration, map[string]string{"scan.config.sast.incremental": "true", "scan.config.sca.filter": "!**/test/**"})
+
+	execCmdNilAssertion(t, "project", "restore", "--file", file, "--project-name", "restored", "--format", "json")
+
+	// The backup is also a results bundle of the latest scan
+	execCmdNilAssertion(t, "results", "show", "--from-bundle", file, "--report-format", "summaryJSON", "--output-path", t.TempDir())
+}
+
+func TestProjectRestoreInvalid(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "restore", "--file", filepath.Join(t.TempDir(), "missing.zip"))
+	assert.ErrorContains(t, err, failedRestoringProject)
+
+	file := filepath.Join(t.TempDir(), "empty.zip")
+	assert.NilError(t, newResultsBundle().save(file))
+	err = execCmdNotNilAssertion(t, "project", "restore", "--file", file)
+	assert.ErrorContains(t, err, backupProjectFile+" not found in the backup")
+}
+
+func TestConfirmProjectDeletion(t *testing.T) {
+	summary := &projectDeletionSummary{
+		project: &wrappers.ProjectResponseModel{ID: "P1", Name: "web", Tags: map[string]string{"team": "payments"}},
+		groups:  []accessGrant{{GroupID: "G1", GroupName: "Dev"}, {GroupID: "G2"}},
+		scans:   3,
+	}
+	var out bytes.Buffer
+	assert.Assert(t, confirmProjectDeletion(strings.NewReader("yes\n"), &out, summary))
+	assert.Assert(t, strings.Contains(out.String(), "Groups:       Dev,G2"))
+	assert.Assert(t, strings.Contains(out.String(), projectDeletionNoBackupNote))
+
+	assert.Assert(t, !confirmProjectDeletion(strings.NewReader("\n"), &out, summary))
+	assert.Assert(t, !confirmProjectDeletion(strings.NewReader(""), &out, summary))
+}
+
+func TestRestoreProjectTriage(t *testing.T) {
+	now := time.Now()
+	predicatesWrapper := &recordingPredicatesWrapper{}
+	view := restoreProjectTriage("P2", &wrappers.TriageExport{ProjectID: "P1", Results: []wrappers.TriagedResult{{
+		SimilarityID: "S1",
+		ScanType:     "sast",
+		Predicates: []wrappers.Predicate{
+			{BasePredicate: wrappers.BasePredicate{State: "NOT_EXPLOITABLE", Severity: "LOW", Comment: "test code"}, CreatedAt: now},
+			{BasePredicate: wrappers.BasePredicate{State: "CONFIRMED", Severity: "HIGH"}, CreatedAt: now.Add(-time.Hour)},
+		},
+	}}}, predicatesWrapper, true, 1)
+	assert.Equal(t, view.Status, restoreStatusRestored)
+	assert.Equal(t, len(predicatesWrapper.predicates), 2)
+	assert.Equal(t, predicatesWrapper.predicates[0].State, "CONFIRMED")
+	assert.Equal(t, predicatesWrapper.predicates[1].State, "NOT_EXPLOITABLE")
+	assert.Equal(t, predicatesWrapper.predicates[1].ProjectID, "P2")
+
+	view = restoreProjectTriage("P2", &wrappers.TriageExport{Results: []wrappers.TriagedResult{{
+		SimilarityID: "S1",
+		ScanType:     "sast",
+		Predicates:   []wrappers.Predicate{{BasePredicate: wrappers.BasePredicate{State: "UNKNOWN", Severity: "LOW"}}},
+	}}}, predicatesWrapper, true, 1)
+	assert.Equal(t, view.Status, restoreStatusFailed)
+}
+
+func TestCreateProjectBackupVulnerabilityTriage(t *testing.T) {
+	resultsWrapper := &projectResultsWrapper{results: map[string][]*wrappers.ScanResult{"P1-scan": {
+		{Type: "sca", ID: "CVE-2021-1", SimilarityID: "1", ScanResultData: wrappers.ScanResultData{PackageIdentifier: "Npm-lodash-4.17.15"}},
+		{Type: "containers", ID: "CVE-2021-2", SimilarityID: "2",
+			ScanResultData: wrappers.ScanResultData{PackageName: "openssl", PackageVersion: "1.1", ImageName: "nginx", ImageTag: "1.25"}},
+		{Type: "sca", ID: "CVE-2021-3", SimilarityID: "3"},
+	}}}
+	_, triage, err := createProjectBackup(&wrappers.ProjectResponseModel{ID: "P1"}, nil, 1,
+		&mock.ProjectsMockWrapper{}, &projectScansWrapper{}, resultsWrapper, &mock.ResultsPredicatesMockWrapper{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(triage.Results), 2)
+	assert.DeepEqual(t, triage.Results[0].Vulnerability, &wrappers.PackageVulnerability{
+		PackageName: "openssl", PackageVersion: "1.1", VulnerabilityID: "CVE-2021-2", ImageName: "nginx", ImageTag: "1.25"})
+	assert.DeepEqual(t, triage.Results[1].Vulnerability, &wrappers.PackageVulnerability{
+		PackageManager: "Npm", PackageName: "lodash", PackageVersion: "4.17.15", VulnerabilityID: "CVE-2021-1"})
+
+	predicatesWrapper := &recordingPredicatesWrapper{}
+	view := restoreProjectTriage("P2", triage, predicatesWrapper, true, 1)
+	assert.Equal(t, view.Status, restoreStatusRestored)
+	assert.Equal(t, len(predicatesWrapper.predicates), 0)
+	assert.Equal(t, len(predicatesWrapper.vulnerabilities), 2)
+	for _, predicate := range predicatesWrapper.vulnerabilities {
+		assert.DeepEqual(t, predicate.ProjectIDs, []string{"P2"})
+		assert.Equal(t, predicate.Actions[0].Value, "NOT_EXPLOITABLE")
+	}
+}
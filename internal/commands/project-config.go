@@ -0,0 +1,582 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	failedGettingProjectConfig  = "Failed getting the project configuration"
+	failedUpdatingProjectConfig = "Failed updating the project configuration"
+	scanConfigKeyPrefix         = "scan.config."
+	secretValueType             = "Secret"
+	secretValueMask             = "********"
+	configStatusSet             = "SET"
+	configStatusUnset           = "UNSET"
+	configStatusWouldSet        = "WOULD_SET"
+	configStatusWouldUnset      = "WOULD_UNSET"
+	configStatusUnchanged       = "UNCHANGED"
+	configStatusLocked          = "LOCKED"
+	configFileExtensionJSON     = ".json"
+	yamlConfigType              = "yaml"
+)
+
+// projectConfigAliases are the short names accepted for the common scan settings
+var projectConfigAliases = map[string]string{
+	"preset":               "scan.config.sast.presetName",
+	"sast-filter":          "scan.config.sast.filter",
+	"incremental":          "scan.config.sast.incremental",
+	"language-mode":        "scan.config.sast.languageMode",
+	"engine-verbose":       "scan.config.sast.engineVerbose",
+	"kics-platforms":       "scan.config.kics.platforms",
+	"kics-filter":          "scan.config.kics.filter",
+	"sca-filter":           "scan.config.sca.filter",
+	"sca-exploitable-path": "scan.config.sca.ExploitablePath",
+}
+
+type projectConfigView struct {
+	Key           string
+	Name          string
+	Value         string
+	OriginLevel   string `format:"name:Origin level"`
+	AllowOverride bool   `format:"name:Allow override"`
+}
+
+type projectConfigChangeView struct {
+	ProjectID string `format:"name:Project ID"`
+	Key       string
+	Current   string
+	New       string
+	Status    string
+}
+
+func projectConfigSubCommand(projectsWrapper wrappers.ProjectsWrapper) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the scan configuration of projects",
+		Long: "The config command reads and changes the project level scan settings, like the SAST preset, filters, incremental and language mode, " +
+			"KICS platforms and SCA filters. Settings inherited from the tenant are shown with their origin level.",
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the scan configuration of a project",
+		Example: heredoc.Doc(
+			`
+			$ cx project config show --project-id <project_id>
+			$ cx project config show --project-id <project_id> --key preset,incremental --format json
+		`,
+		),
+		RunE: runShowProjectConfig(projectsWrapper),
+	}
+	addProjectIDFlag(showCmd, "Project ID to show the configuration of.")
+	showCmd.PersistentFlags().StringSlice(commonParams.ConfigKeyFlag, []string{}, projectConfigKeyUsage("Keys to show"))
+
+	setCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set a scan setting at the project level",
+		Example: heredoc.Doc(
+			`
+			$ cx project config set --project-id <project_id> --key preset --value "ASA Premium"
+			$ cx project config set --project-id <project_id> --key scan.config.sca.filter --value "!**/test/**"
+		`,
+		),
+		RunE: runSetProjectConfig(projectsWrapper),
+	}
+	addProjectIDFlag(setCmd, "Project ID to update.")
+	setCmd.PersistentFlags().String(commonParams.ConfigKeyFlag, "", projectConfigKeyUsage("Key to set"))
+	setCmd.PersistentFlags().String(commonParams.ConfigValueFlag, "", "Value of the setting")
+	markFlagAsRequired(setCmd, commonParams.ConfigKeyFlag)
+	markFlagAsRequired(setCmd, commonParams.ConfigValueFlag)
+
+	unsetCmd := &cobra.Command{
+		Use:   "unset",
+		Short: "Remove project level scan settings, so the tenant values apply again",
+		Example: heredoc.Doc(
+			`
+			$ cx project config unset --project-id <project_id> --key preset,sast-filter
+		`,
+		),
+		RunE: runUnsetProjectConfig(projectsWrapper),
+	}
+	addProjectIDFlag(unsetCmd, "Project ID to update.")
+	unsetCmd.PersistentFlags().StringSlice(commonParams.ConfigKeyFlag, []string{}, projectConfigKeyUsage("Keys to unset"))
+	markFlagAsRequired(unsetCmd, commonParams.ConfigKeyFlag)
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the project level scan settings of projects to a YAML or JSON file",
+		Example: heredoc.Doc(
+			`
+			$ cx project config export --project-id <project_id> --file cx-config.yaml
+			$ cx project config export --project-id <project_id>,<project_id> --file cx-config.json
+		`,
+		),
+		RunE: runExportProjectConfig(projectsWrapper),
+	}
+	exportCmd.PersistentFlags().StringSlice(commonParams.ProjectIDFlag, []string{}, "Project IDs to export")
+	exportCmd.PersistentFlags().String(commonParams.TriageFileFlag, "", "YAML or JSON file to write, the YAML is printed when not set")
+	markFlagAsRequired(exportCmd, commonParams.ProjectIDFlag)
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Apply the scan settings of a YAML or JSON file to projects",
+		Long: "The import command applies the configuration of every project in the file, merged over the file defaults. " +
+			"Projects are matched by projectId or projectName. With --prune the project level settings missing from the file are unset, " +
+			"which keeps the projects in sync with the file.",
+		Example: heredoc.Doc(
+			`
+			$ cx project config import --file cx-config.yaml --dry-run
+			$ cx project config import --file cx-config.yaml --prune
+			$ cx project config import --file cx-config.yaml --project-id <project_id>
+		`,
+		),
+		RunE: runImportProjectConfig(projectsWrapper),
+	}
+	importCmd.PersistentFlags().String(commonParams.TriageFileFlag, "", "YAML or JSON file to apply")
+	importCmd.PersistentFlags().String(commonParams.ProjectIDFlag, "", "Project ID to apply a file with a single project to")
+	importCmd.PersistentFlags().Bool(commonParams.PruneFlag, false, "Unset the project level settings that are not in the file")
+	importCmd.PersistentFlags().Bool(commonParams.DryRunFlag, false, "Report the changes without applying them")
+	markFlagAsRequired(importCmd, commonParams.TriageFileFlag)
+
+	addFormatFlagToMultipleCommands(
+		[]*cobra.Command{showCmd, setCmd, unsetCmd, importCmd},
+		printer.FormatTable, printer.FormatJSON, printer.FormatList,
+	)
+	configCmd.AddCommand(showCmd, setCmd, unsetCmd, exportCmd, importCmd)
+	return configCmd
+}
+
+func projectConfigKeyUsage(prefix string) string {
+	aliases := sortedKeys(projectConfigAliases)
+	return fmt.Sprintf("%s, a full configuration key or one of: %s", prefix, strings.Join(aliases, ", "))
+}
+
+func runShowProjectConfig(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		keys, _ := cmd.Flags().GetStringSlice(commonParams.ConfigKeyFlag)
+		if projectID == "" {
+			return errors.Errorf("%s: Please provide a project ID", failedGettingProjectConfig)
+		}
+		configuration, err := fetchProjectConfiguration(projectsWrapper, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingProjectConfig)
+		}
+		wanted := make(map[string]bool)
+		for _, key := range keys {
+			wanted[resolveProjectConfigKey(key)] = true
+		}
+		views := []projectConfigView{}
+		for i := range configuration {
+			config := &configuration[i]
+			if len(wanted) > 0 && !wanted[config.Key] {
+				continue
+			}
+			views = append(views, projectConfigView{
+				Key:           config.Key,
+				Name:          config.Name,
+				Value:         projectConfigDisplayValue(config),
+				OriginLevel:   config.OriginLevel,
+				AllowOverride: config.AllowOverride,
+			})
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func runSetProjectConfig(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		key, _ := cmd.Flags().GetString(commonParams.ConfigKeyFlag)
+		value, _ := cmd.Flags().GetString(commonParams.ConfigValueFlag)
+		if projectID == "" {
+			return errors.Errorf("%s: Please provide a project ID", failedUpdatingProjectConfig)
+		}
+		views, err := applyProjectConfiguration(projectsWrapper, projectID, map[string]string{key: value}, false, false)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProjectConfig)
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func runUnsetProjectConfig(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		keys, _ := cmd.Flags().GetStringSlice(commonParams.ConfigKeyFlag)
+		if projectID == "" {
+			return errors.Errorf("%s: Please provide a project ID", failedUpdatingProjectConfig)
+		}
+		configuration, err := fetchProjectConfiguration(projectsWrapper, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProjectConfig)
+		}
+		views := []projectConfigChangeView{}
+		var unset []string
+		for _, key := range keys {
+			config := findProjectConfiguration(configuration, resolveProjectConfigKey(key))
+			if config == nil {
+				return errors.Errorf("%s: unknown configuration key %s", failedUpdatingProjectConfig, resolveProjectConfigKey(key))
+			}
+			key = config.Key
+			view := projectConfigChangeView{ProjectID: projectID, Key: key, Current: projectConfigDisplayValue(config), Status: configStatusUnchanged}
+			if config.OriginLevel == projOriginLevel {
+				view.Status = configStatusUnset
+				unset = append(unset, key)
+			}
+			views = append(views, view)
+		}
+		if len(unset) > 0 {
+			errorModel, deleteErr := projectsWrapper.DeleteConfiguration(projectID, unset)
+			if deleteErr != nil {
+				return errors.Wrapf(deleteErr, "%s", failedUpdatingProjectConfig)
+			}
+			if errorModel != nil {
+				return errors.Errorf(services.ErrorCodeFormat, failedUpdatingProjectConfig, errorModel.Code, errorModel.Message)
+			}
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func runExportProjectConfig(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectIDs, _ := cmd.Flags().GetStringSlice(commonParams.ProjectIDFlag)
+		file, _ := cmd.Flags().GetString(commonParams.TriageFileFlag)
+		export := wrappers.ProjectConfigurationFile{Projects: []wrappers.ProjectConfigurationEntry{}}
+		for _, projectID := range projectIDs {
+			project, errorModel, err := projectsWrapper.GetByID(projectID)
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedGettingProjectConfig)
+			}
+			if errorModel != nil {
+				return errors.Errorf(services.ErrorCodeFormat, failedGettingProjectConfig, errorModel.Code, errorModel.Message)
+			}
+			configuration, err := fetchProjectConfiguration(projectsWrapper, projectID)
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedGettingProjectConfig)
+			}
+			entry := wrappers.ProjectConfigurationEntry{ProjectID: projectID, ProjectName: project.Name, Configuration: map[string]string{}}
+			for i := range configuration {
+				config := &configuration[i]
+				if config.OriginLevel == projOriginLevel && isExportableProjectConfig(config) {
+					entry.Configuration[projectConfigAlias(config.Key)] = config.Value
+				}
+			}
+			export.Projects = append(export.Projects, entry)
+		}
+		if file == "" {
+			content, err := marshalYAML("projects", export.Projects)
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedGettingProjectConfig)
+			}
+			_, err = cmd.OutOrStdout().Write(content)
+			return err
+		}
+		err := writeProjectConfigurationFile(file, &export)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingProjectConfig)
+		}
+		return nil
+	}
+}
+
+func runImportProjectConfig(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString(commonParams.TriageFileFlag)
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		prune, _ := cmd.Flags().GetBool(commonParams.PruneFlag)
+		dryRun, _ := cmd.Flags().GetBool(commonParams.DryRunFlag)
+		configFile, err := readProjectConfigurationFile(file)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProjectConfig)
+		}
+		if projectID != "" {
+			if len(configFile.Projects) > 1 {
+				return errors.Errorf("%s: --%s needs a file with a single project", failedUpdatingProjectConfig, commonParams.ProjectIDFlag)
+			}
+			if len(configFile.Projects) == 0 {
+				configFile.Projects = append(configFile.Projects, wrappers.ProjectConfigurationEntry{})
+			}
+			configFile.Projects[0].ProjectID, configFile.Projects[0].ProjectName = projectID, ""
+		}
+
+		views := []projectConfigChangeView{}
+		for i := range configFile.Projects {
+			entry := &configFile.Projects[i]
+			targetID, targetErr := resolveProjectConfigTarget(projectsWrapper, entry)
+			if targetErr != nil {
+				return errors.Wrapf(targetErr, "%s", failedUpdatingProjectConfig)
+			}
+			desired := make(map[string]string, len(configFile.Defaults)+len(entry.Configuration))
+			for key, value := range configFile.Defaults {
+				desired[key] = value
+			}
+			for key, value := range entry.Configuration {
+				desired[key] = value
+			}
+			projectViews, applyErr := applyProjectConfiguration(projectsWrapper, targetID, desired, prune, dryRun)
+			if applyErr != nil {
+				return errors.Wrapf(applyErr, "%s", failedUpdatingProjectConfig)
+			}
+			views = append(views, projectViews...)
+		}
+		return printByFormat(cmd, views)
+	}
+}
+
+func fetchProjectConfiguration(projectsWrapper wrappers.ProjectsWrapper, projectID string) ([]wrappers.ProjectConfiguration, error) {
+	configuration, errorModel, err := projectsWrapper.GetConfiguration(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	return configuration, nil
+}
+
+// applyProjectConfiguration sets the desired values at the project level, the locked tenant settings are reported and left as they are
+func applyProjectConfiguration(
+	projectsWrapper wrappers.ProjectsWrapper,
+	projectID string,
+	desired map[string]string,
+	prune, dryRun bool,
+) ([]projectConfigChangeView, error) {
+	configuration, err := fetchProjectConfiguration(projectsWrapper, projectID)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[string]string, len(desired))
+	for _, key := range sortedKeys(desired) {
+		config := findProjectConfiguration(configuration, resolveProjectConfigKey(key))
+		if config == nil {
+			return nil, errors.Errorf("unknown configuration key %s", resolveProjectConfigKey(key))
+		}
+		resolved[config.Key] = desired[key]
+	}
+
+	var views []projectConfigChangeView
+	var updates []wrappers.ProjectConfiguration
+	var unset []string
+	for _, key := range sortedKeys(resolved) {
+		config := findProjectConfiguration(configuration, key)
+		update := *config
+		update.OriginLevel = projOriginLevel
+		update.Value = resolved[key]
+		view := projectConfigChangeView{ProjectID: projectID, Key: key, Current: projectConfigDisplayValue(config), New: projectConfigDisplayValue(&update)}
+		switch {
+		case config.OriginLevel == projOriginLevel && config.Value == resolved[key]:
+			view.Status = configStatusUnchanged
+		case config.OriginLevel != projOriginLevel && !config.AllowOverride:
+			view.Status = configStatusLocked
+		default:
+			view.Status = configStatusSet
+			updates = append(updates, update)
+		}
+		views = append(views, view)
+	}
+	if prune {
+		for i := range configuration {
+			config := &configuration[i]
+			if _, ok := resolved[config.Key]; ok || config.OriginLevel != projOriginLevel || !isExportableProjectConfig(config) {
+				continue
+			}
+			views = append(views, projectConfigChangeView{
+				ProjectID: projectID,
+				Key:       config.Key,
+				Current:   projectConfigDisplayValue(config),
+				Status:    configStatusUnset,
+			})
+			unset = append(unset, config.Key)
+		}
+	}
+
+	if dryRun {
+		for i := range views {
+			switch views[i].Status {
+			case configStatusSet:
+				views[i].Status = configStatusWouldSet
+			case configStatusUnset:
+				views[i].Status = configStatusWouldUnset
+			}
+		}
+		return views, nil
+	}
+	if len(updates) > 0 {
+		errorModel, updateErr := projectsWrapper.UpdateConfiguration(projectID, updates)
+		if updateErr != nil {
+			return nil, updateErr
+		}
+		if errorModel != nil {
+			return nil, errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+		}
+	}
+	if len(unset) > 0 {
+		errorModel, deleteErr := projectsWrapper.DeleteConfiguration(projectID, unset)
+		if deleteErr != nil {
+			return nil, deleteErr
+		}
+		if errorModel != nil {
+			return nil, errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+		}
+	}
+	return views, nil
+}
+
+func resolveProjectConfigTarget(projectsWrapper wrappers.ProjectsWrapper, entry *wrappers.ProjectConfigurationEntry) (string, error) {
+	if entry.ProjectID != "" {
+		return entry.ProjectID, nil
+	}
+	if entry.ProjectName == "" {
+		return "", errors.New("every project in the file needs a projectId or a projectName")
+	}
+	project, errorModel, err := projectsWrapper.GetByName(entry.ProjectName)
+	if err != nil {
+		return "", err
+	}
+	if errorModel != nil {
+		return "", errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	return project.ID, nil
+}
+
+func resolveProjectConfigKey(key string) string {
+	key = strings.TrimSpace(key)
+	if fullKey, ok := projectConfigAliases[strings.ToLower(key)]; ok {
+		return fullKey
+	}
+	return key
+}
+
+func projectConfigAlias(key string) string {
+	for alias, fullKey := range projectConfigAliases {
+		if fullKey == key {
+			return alias
+		}
+	}
+	return key
+}
+
+func findProjectConfiguration(configuration []wrappers.ProjectConfiguration, key string) *wrappers.ProjectConfiguration {
+	for i := range configuration {
+		if strings.EqualFold(configuration[i].Key, key) {
+			return &configuration[i]
+		}
+	}
+	return nil
+}
+
+// isExportableProjectConfig keeps the scan settings, leaving out secrets and the repository settings that belong to one project
+func isExportableProjectConfig(config *wrappers.ProjectConfiguration) bool {
+	return strings.HasPrefix(config.Key, scanConfigKeyPrefix) && config.ValueType != secretValueType
+}
+
+func projectConfigDisplayValue(config *wrappers.ProjectConfiguration) string {
+	if config.ValueType == secretValueType && config.Value != "" {
+		return secretValueMask
+	}
+	return config.Value
+}
+
+func readProjectConfigurationFile(file string) (*wrappers.ProjectConfigurationFile, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	configFile := &wrappers.ProjectConfigurationFile{}
+	if strings.EqualFold(filepath.Ext(file), configFileExtensionJSON) {
+		err = json.Unmarshal(content, configFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", file)
+		}
+		return configFile, nil
+	}
+	v, err := readYAMLFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", file)
+	}
+	configFile.Defaults = v.GetStringMapString("defaults")
+	for _, entry := range yamlList(v, "projects") {
+		configFile.Projects = append(configFile.Projects, wrappers.ProjectConfigurationEntry{
+			ProjectID:     entry.GetString("projectId"),
+			ProjectName:   entry.GetString("projectName"),
+			Configuration: entry.GetStringMapString("configuration"),
+		})
+	}
+	return configFile, nil
+}
+
+func writeProjectConfigurationFile(file string, configFile *wrappers.ProjectConfigurationFile) error {
+	if strings.EqualFold(filepath.Ext(file), configFileExtensionJSON) {
+		return writeJSONReport(file, configFile)
+	}
+	content, err := marshalYAML("projects", configFile.Projects)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, content, 0600)
+}
+
+// readYAMLFile reads a YAML file with viper, which lowercases the keys so they match case insensitively
+func readYAMLFile(file string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	v.SetConfigType(yamlConfigType)
+	return v, v.ReadInConfig()
+}
+
+// yamlList reads every map of a YAML list with its own viper, so the keys of the nested maps are not split on dots
+func yamlList(v *viper.Viper, key string) []*viper.Viper {
+	items, _ := v.Get(key).([]interface{})
+	list := make([]*viper.Viper, 0, len(items))
+	for _, item := range items {
+		entry := viper.New()
+		if values, ok := item.(map[string]interface{}); ok {
+			_ = entry.MergeConfigMap(values)
+		}
+		list = append(list, entry)
+	}
+	return list
+}
+
+// marshalYAML writes the value under key as YAML. Viper only writes files, so it goes through a temporary file
+func marshalYAML(key string, value interface{}) ([]byte, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var values interface{}
+	err = json.Unmarshal(content, &values)
+	if err != nil {
+		return nil, err
+	}
+	tempFile, err := os.CreateTemp("", "cx-*."+yamlConfigType)
+	if err != nil {
+		return nil, err
+	}
+	_ = tempFile.Close()
+	defer func() {
+		_ = os.Remove(tempFile.Name())
+	}()
+	v := viper.New()
+	v.Set(key, values)
+	err = v.WriteConfigAs(tempFile.Name())
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(tempFile.Name())
+}
@@ -0,0 +1,87 @@
+//go:build !integration
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+// configuredProjectsWrapper records the configuration changes sent to the mock projects
+type configuredProjectsWrapper struct {
+	mock.ProjectsMockWrapper
+	updates []wrappers.ProjectConfiguration
+	unset   []string
+}
+
+func (p *configuredProjectsWrapper) UpdateConfiguration(_ string, configuration []wrappers.ProjectConfiguration) (*wrappers.ErrorModel, error) {
+	p.updates = append(p.updates, configuration...)
+	return nil, nil
+}
+
+func (p *configuredProjectsWrapper) DeleteConfiguration(_ string, keys []string) (*wrappers.ErrorModel, error) {
+	p.unset = append(p.unset, keys...)
+	return nil, nil
+}
+
+func TestApplyProjectConfiguration(t *testing.T) {
+	projectsWrapper := &configuredProjectsWrapper{}
+	views, err := applyProjectConfiguration(projectsWrapper, "P1", map[string]string{
+		"preset":               "ASA Premium",
+		"incremental":          "true",
+		"sca-exploitable-path": "true",
+		"language-mode":        "multi",
+	}, true, false)
+	assert.NilError(t, err)
+
+	statuses := make(map[string]string)
+	for _, view := range views {
+		statuses[view.Key] = view.Status
+	}
+	assert.DeepEqual(t, statuses, map[string]string{
+		"scan.config.sast.presetName":     configStatusSet,
+		"scan.config.sast.incremental":    configStatusUnchanged,
+		"scan.config.sca.ExploitablePath": configStatusLocked,
+		"scan.config.sast.languageMode":   configStatusSet,
+		"scan.config.sca.filter":          configStatusUnset,
+	})
+	assert.Equal(t, len(projectsWrapper.updates), 2)
+	for _, update := range projectsWrapper.updates {
+		assert.Equal(t, update.OriginLevel, projOriginLevel)
+	}
+	assert.DeepEqual(t, projectsWrapper.unset, []string{"scan.config.sca.filter"})
+}
+
+func TestApplyProjectConfigurationDryRun(t *testing.T) {
+	projectsWrapper := &configuredProjectsWrapper{}
+	views, err := applyProjectConfiguration(projectsWrapper, "P1", map[string]string{"sast-filter": "!**/vendor/**"}, true, true)
+	assert.NilError(t, err)
+	assert.Equal(t, views[0].Status, configStatusWouldSet)
+	assert.Equal(t, views[1].Status, configStatusWouldUnset)
+	assert.Equal(t, len(projectsWrapper.updates)+len(projectsWrapper.unset), 0)
+
+	_, err = applyProjectConfiguration(projectsWrapper, "P1", map[string]string{"scan.config.sast.unknown": "x"}, false, false)
+	assert.ErrorContains(t, err, "unknown configuration key scan.config.sast.unknown")
+}
+
+func TestReadProjectConfigurationFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cx-config.yaml")
+	assert.NilError(t, os.WriteFile(file, []byte(
+		"defaults:\n  preset: ASA Premium\n  incremental: true\nprojects:\n  - projectName: web\n    configuration:\n      incremental: false\n"), 0600))
+	configFile, err := readProjectConfigurationFile(file)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, configFile.Defaults, map[string]string{"preset": "ASA Premium", "incremental": "true"})
+	assert.Equal(t, configFile.Projects[0].ProjectName, "web")
+	assert.Equal(t, configFile.Projects[0].Configuration["incremental"], "false")
+
+	exported := filepath.Join(t.TempDir(), "cx-config.json")
+	assert.NilError(t, writeProjectConfigurationFile(exported, configFile))
+	reread, err := readProjectConfigurationFile(exported)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, reread, configFile)
+}
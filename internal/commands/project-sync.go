@@ -0,0 +1,532 @@
+package commands
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	featureFlagsConstants "github.com/checkmarx/ast-cli/internal/constants/feature-flags"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	failedSyncingProjects  = "Failed syncing projects"
+	syncGitHubAPIURL       = "https://api.github.com"
+	syncGitLabAPIURL       = "https://gitlab.com"
+	syncAzureAPIURL        = "https://dev.azure.com/"
+	syncBitBucketAPIURL    = "https://api.bitbucket.org/2.0/"
+	syncProjectsPageSize   = 100
+	syncStatusCreated      = "CREATED"
+	syncStatusWouldCreate  = "WOULD_CREATE"
+	syncStatusExists       = "EXISTS"
+	syncStatusArchived     = "ARCHIVED"
+	syncStatusDeleted      = "DELETED"
+	syncStatusFailed       = "FAILED"
+	syncMissingSource      = "provide one of --github-org, --gitlab-group, --azure-org or --bitbucket-workspace"
+	syncFailedProjects     = "%s: %d of %d projects failed"
+	azureBranchRefsPrefix  = "refs/heads/"
+	bitBucketHTTPSCloneRef = "https"
+)
+
+// scmRepository is a repository of any of the supported SCMs
+type scmRepository struct {
+	FullName      string
+	URL           string
+	DefaultBranch string
+	Archived      bool
+	Topics        []string
+}
+
+type projectSyncView struct {
+	Repository  string
+	ProjectName string `format:"name:Project Name"`
+	ProjectID   string `format:"name:Project ID"`
+	MainBranch  string `format:"name:Main Branch"`
+	RepoURL     string `format:"name:Repository URL"`
+	Status      string
+	Error       string
+}
+
+// projectSyncOptions are the settings given to every project created by the sync
+type projectSyncOptions struct {
+	tags                    map[string]string
+	topicTags               bool
+	groups                  []*wrappers.Group
+	groupMapping            map[string][]*wrappers.Group
+	includeArchived         bool
+	dryRun                  bool
+	accessManagementEnabled bool
+}
+
+func projectSyncSubCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	gitHubWrapper wrappers.GitHubWrapper,
+	gitLabWrapper wrappers.GitLabWrapper,
+	azureWrapper wrappers.AzureWrapper,
+	bitBucketWrapper wrappers.BitBucketWrapper,
+) *cobra.Command {
+	syncCmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Create projects for the repositories of an SCM organization",
+		Long: "The sync command lists the repositories of a GitHub organization, GitLab group, Azure DevOps organization or Bitbucket workspace " +
+			"and creates the missing projects with the repository URL and main branch. Projects are matched by repository URL or by the full " +
+			"name of the repository. Projects whose repository was archived or deleted are reported.",
+		Example: heredoc.Doc(
+			`
+			$ cx project sync --github-org <org> --token <token> --dry-run
+			$ cx project sync --gitlab-group <group> --token <token> --tags scm:gitlab --topic-tags
+			$ cx project sync --azure-org <org> --azure-project <project> --token <token> --group-mapping "web-*=WebTeam"
+			$ cx project sync --bitbucket-workspace <workspace> --username <user> --password <app password>
+		`,
+		),
+		RunE: runProjectSync(
+			projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper, gitHubWrapper, gitLabWrapper, azureWrapper, bitBucketWrapper,
+		),
+	}
+	syncCmd.PersistentFlags().String(commonParams.GitHubOrgFlag, "", "GitHub organization to sync")
+	syncCmd.PersistentFlags().String(commonParams.GitLabGroupFlag, "", "GitLab group to sync")
+	syncCmd.PersistentFlags().String(commonParams.AzureOrgFlag, "", "Azure DevOps organization to sync")
+	syncCmd.PersistentFlags().StringSlice(commonParams.AzureProjectFlag, []string{}, "Azure DevOps projects to sync, all the projects of the organization by default")
+	syncCmd.PersistentFlags().String(commonParams.BitBucketWorkspaceFlag, "", "Bitbucket workspace to sync")
+	syncCmd.PersistentFlags().String(commonParams.SCMTokenFlag, "", "GitHub, GitLab or Azure DevOps token with read access to the repositories")
+	syncCmd.PersistentFlags().String(commonParams.UsernameFlag, "", "Username for Bitbucket authentication")
+	syncCmd.PersistentFlags().String(commonParams.PasswordFlag, "", "App password for Bitbucket authentication")
+	syncCmd.PersistentFlags().String(commonParams.URLFlag, syncGitHubAPIURL, "GitHub API URL")
+	syncCmd.PersistentFlags().String(commonParams.GitLabURLFlag, syncGitLabAPIURL, "GitLab URL")
+	syncCmd.PersistentFlags().String(commonParams.AzureURLFlag, syncAzureAPIURL, "Azure DevOps URL")
+	syncCmd.PersistentFlags().String(commonParams.BitBucketURLFlag, syncBitBucketAPIURL, "Bitbucket API URL")
+	syncCmd.PersistentFlags().String(commonParams.TagList, "", "List of tags of the created projects, ex: (tagA,tagB:val,etc)")
+	syncCmd.PersistentFlags().Bool(commonParams.TopicTagsFlag, false, "Add the repository topics as tags of the created projects")
+	syncCmd.PersistentFlags().String(commonParams.GroupList, "", "List of groups of the created projects, ex: (PowerUsers,etc)")
+	syncCmd.PersistentFlags().String(commonParams.GroupMappingFlag, "",
+		"Groups of the created projects by repository name pattern, ex: (web-*=WebTeam;payments-*=Payments,Auditors)")
+	syncCmd.PersistentFlags().Bool(commonParams.IncludeArchivedFlag, false, "Create projects for archived repositories too")
+	syncCmd.PersistentFlags().Bool(commonParams.DryRunFlag, false, "Report the changes without creating projects")
+	addFormatFlag(syncCmd, printer.FormatTable, printer.FormatJSON, printer.FormatList)
+	return syncCmd
+}
+
+func runProjectSync(
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	gitHubWrapper wrappers.GitHubWrapper,
+	gitLabWrapper wrappers.GitLabWrapper,
+	azureWrapper wrappers.AzureWrapper,
+	bitBucketWrapper wrappers.BitBucketWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		repositories, err := listSCMRepositories(cmd, gitHubWrapper, gitLabWrapper, azureWrapper, bitBucketWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedSyncingProjects)
+		}
+		options, err := getProjectSyncOptions(cmd, groupsWrapper, featureFlagsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedSyncingProjects)
+		}
+		projects, err := getAllProjects(projectsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedSyncingProjects)
+		}
+
+		views := syncProjects(projectsWrapper, accessManagementWrapper, featureFlagsWrapper, repositories, projects, options)
+		err = printByFormat(cmd, views)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for i := range views {
+			if views[i].Status == syncStatusFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return errors.Errorf(syncFailedProjects, failedSyncingProjects, failed, len(views))
+		}
+		return nil
+	}
+}
+
+// listSCMRepositories reads the repositories of the organization given in the flags, through the wrappers of the user count command
+func listSCMRepositories(
+	cmd *cobra.Command,
+	gitHubWrapper wrappers.GitHubWrapper,
+	gitLabWrapper wrappers.GitLabWrapper,
+	azureWrapper wrappers.AzureWrapper,
+	bitBucketWrapper wrappers.BitBucketWrapper,
+) ([]scmRepository, error) {
+	gitHubOrg, _ := cmd.Flags().GetString(commonParams.GitHubOrgFlag)
+	gitLabGroup, _ := cmd.Flags().GetString(commonParams.GitLabGroupFlag)
+	azureOrg, _ := cmd.Flags().GetString(commonParams.AzureOrgFlag)
+	bitBucketWorkspace, _ := cmd.Flags().GetString(commonParams.BitBucketWorkspaceFlag)
+	token, _ := cmd.Flags().GetString(commonParams.SCMTokenFlag)
+
+	sources := 0
+	for _, source := range []string{gitHubOrg, gitLabGroup, azureOrg, bitBucketWorkspace} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, errors.New(syncMissingSource)
+	}
+
+	switch {
+	case gitHubOrg != "":
+		_ = viper.BindPFlag(commonParams.SCMTokenFlag, cmd.Flags().Lookup(commonParams.SCMTokenFlag))
+		_ = viper.BindPFlag(commonParams.URLFlag, cmd.Flags().Lookup(commonParams.URLFlag))
+		return listGitHubRepositories(gitHubWrapper, gitHubOrg)
+	case gitLabGroup != "":
+		_ = viper.BindPFlag(commonParams.SCMTokenFlag, cmd.Flags().Lookup(commonParams.SCMTokenFlag))
+		_ = viper.BindPFlag(commonParams.GitLabURLFlag, cmd.Flags().Lookup(commonParams.GitLabURLFlag))
+		return listGitLabRepositories(gitLabWrapper, gitLabGroup)
+	case azureOrg != "":
+		azureURL, _ := cmd.Flags().GetString(commonParams.AzureURLFlag)
+		azureProjects, _ := cmd.Flags().GetStringSlice(commonParams.AzureProjectFlag)
+		return listAzureRepositories(azureWrapper, azureURL, azureOrg, azureProjects, token)
+	default:
+		bitBucketURL, _ := cmd.Flags().GetString(commonParams.BitBucketURLFlag)
+		username, _ := cmd.Flags().GetString(commonParams.UsernameFlag)
+		password, _ := cmd.Flags().GetString(commonParams.PasswordFlag)
+		return listBitBucketRepositories(bitBucketWrapper, bitBucketURL, bitBucketWorkspace, username, password)
+	}
+}
+
+func listGitHubRepositories(gitHubWrapper wrappers.GitHubWrapper, org string) ([]scmRepository, error) {
+	organization, err := gitHubWrapper.GetOrganization(org)
+	if err != nil {
+		return nil, err
+	}
+	repositories, err := gitHubWrapper.GetRepositories(organization)
+	if err != nil {
+		return nil, err
+	}
+	var result []scmRepository
+	for i := range repositories {
+		repository := &repositories[i]
+		result = append(result, scmRepository{
+			FullName:      repository.FullName,
+			URL:           repository.CloneURL,
+			DefaultBranch: repository.DefaultBranch,
+			Archived:      repository.Archived,
+			Topics:        repository.Topics,
+		})
+	}
+	return result, nil
+}
+
+func listGitLabRepositories(gitLabWrapper wrappers.GitLabWrapper, group string) ([]scmRepository, error) {
+	projects, err := gitLabWrapper.GetGitLabProjects(group, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	var result []scmRepository
+	for i := range projects {
+		project := &projects[i]
+		if project.EmptyRepo {
+			continue
+		}
+		result = append(result, scmRepository{
+			FullName:      project.PathWithNameSpace,
+			URL:           project.HTTPURLToRepo,
+			DefaultBranch: project.DefaultBranch,
+			Archived:      project.Archived,
+			Topics:        project.Topics,
+		})
+	}
+	return result, nil
+}
+
+func listAzureRepositories(azureWrapper wrappers.AzureWrapper, azureURL, org string, projects []string, token string) ([]scmRepository, error) {
+	if len(projects) == 0 {
+		rootProjects, err := azureWrapper.GetProjects(azureURL, org, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range rootProjects.Projects {
+			projects = append(projects, project.Name)
+		}
+	}
+	var result []scmRepository
+	for _, project := range projects {
+		rootRepos, err := azureWrapper.GetAllRepositories(azureURL, org, project, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range rootRepos.Repos {
+			result = append(result, scmRepository{
+				FullName:      fmt.Sprintf("%s/%s/%s", org, project, repo.Name),
+				URL:           repo.RemoteURL,
+				DefaultBranch: strings.TrimPrefix(repo.DefaultBranch, azureBranchRefsPrefix),
+				Archived:      repo.IsDisabled,
+			})
+		}
+	}
+	return result, nil
+}
+
+func listBitBucketRepositories(bitBucketWrapper wrappers.BitBucketWrapper, bitBucketURL, workspace, username, password string) ([]scmRepository, error) {
+	rootWorkspace, err := bitBucketWrapper.GetworkspaceUUID(bitBucketURL, workspace, username, password)
+	if err != nil {
+		return nil, err
+	}
+	repos, err := bitBucketWrapper.GetRepositories(bitBucketURL, rootWorkspace.UUID, username, password)
+	if err != nil {
+		return nil, err
+	}
+	var result []scmRepository
+	for _, repo := range repos.Values {
+		repository := scmRepository{FullName: repo.Name, DefaultBranch: repo.MainBranch.Name}
+		for _, link := range repo.Links.Clone {
+			if link.Name == bitBucketHTTPSCloneRef {
+				repository.URL = link.Href
+			}
+		}
+		result = append(result, repository)
+	}
+	return result, nil
+}
+
+func getProjectSyncOptions(cmd *cobra.Command, groupsWrapper wrappers.GroupsWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) (*projectSyncOptions, error) {
+	tagList, _ := cmd.Flags().GetString(commonParams.TagList)
+	groupList, _ := cmd.Flags().GetString(commonParams.GroupList)
+	groupMapping, _ := cmd.Flags().GetString(commonParams.GroupMappingFlag)
+	options := &projectSyncOptions{tags: parseTagList(tagList), groupMapping: make(map[string][]*wrappers.Group)}
+	options.topicTags, _ = cmd.Flags().GetBool(commonParams.TopicTagsFlag)
+	options.includeArchived, _ = cmd.Flags().GetBool(commonParams.IncludeArchivedFlag)
+	options.dryRun, _ = cmd.Flags().GetBool(commonParams.DryRunFlag)
+	flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, featureFlagsConstants.AccessManagementEnabled)
+	options.accessManagementEnabled = flagResponse.Status
+
+	var err error
+	if groupList != "" {
+		options.groups, err = services.CreateGroupsMap(groupList, groupsWrapper)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, rule := range strings.Split(groupMapping, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+		pattern, groups, found := strings.Cut(rule, "=")
+		if !found || strings.TrimSpace(pattern) == "" {
+			return nil, errors.Errorf("invalid --%s rule %s, use pattern=group", commonParams.GroupMappingFlag, rule)
+		}
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, errors.Errorf("invalid --%s pattern %s", commonParams.GroupMappingFlag, pattern)
+		}
+		options.groupMapping[strings.TrimSpace(pattern)], err = services.CreateGroupsMap(groups, groupsWrapper)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return options, nil
+}
+
+// getAllProjects reads every project, one page at a time
+func getAllProjects(projectsWrapper wrappers.ProjectsWrapper) ([]wrappers.ProjectResponseModel, error) {
+	var projects []wrappers.ProjectResponseModel
+	for offset := 0; ; offset += syncProjectsPageSize {
+		page, errorModel, err := projectsWrapper.Get(map[string]string{
+			commonParams.LimitQueryParam:  strconv.Itoa(syncProjectsPageSize),
+			commonParams.OffsetQueryParam: strconv.Itoa(offset),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if errorModel != nil {
+			return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+		}
+		projects = append(projects, page.Projects...)
+		if len(page.Projects) < syncProjectsPageSize || len(projects) >= int(page.FilteredTotalCount) {
+			return projects, nil
+		}
+	}
+}
+
+// syncProjects creates the projects of the repositories without one and reports the projects of archived or deleted repositories
+func syncProjects(
+	projectsWrapper wrappers.ProjectsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	repositories []scmRepository,
+	projects []wrappers.ProjectResponseModel,
+	options *projectSyncOptions,
+) []projectSyncView {
+	byURL := make(map[string]*wrappers.ProjectResponseModel)
+	byName := make(map[string]*wrappers.ProjectResponseModel)
+	for i := range projects {
+		if projects[i].RepoURL != "" {
+			byURL[normalizeRepoURL(projects[i].RepoURL)] = &projects[i]
+		}
+		byName[projects[i].Name] = &projects[i]
+	}
+
+	views := []projectSyncView{}
+	seen := make(map[string]bool)
+	orgURLs := make(map[string]bool)
+	for i := range repositories {
+		repository := &repositories[i]
+		if repository.FullName == "" || repository.URL == "" {
+			continue
+		}
+		repoURL := normalizeRepoURL(repository.URL)
+		orgURLs[path.Dir(repoURL)] = true
+		view := projectSyncView{Repository: repository.FullName, ProjectName: repository.FullName, MainBranch: repository.DefaultBranch, RepoURL: repository.URL}
+
+		project, found := byURL[repoURL]
+		if !found {
+			project, found = byName[repository.FullName]
+		}
+		if found {
+			seen[project.ID] = true
+			view.ProjectName, view.ProjectID = project.Name, project.ID
+			view.Status = syncStatusExists
+			if repository.Archived {
+				view.Status = syncStatusArchived
+			}
+			views = append(views, view)
+			continue
+		}
+		if repository.Archived && !options.includeArchived {
+			view.Status = syncStatusArchived
+			views = append(views, view)
+			continue
+		}
+		if options.dryRun {
+			view.Status = syncStatusWouldCreate
+			views = append(views, view)
+			continue
+		}
+		view.ProjectID, view.Error = createSyncedProject(projectsWrapper, accessManagementWrapper, featureFlagsWrapper, repository, options)
+		view.Status = syncStatusCreated
+		if view.Error != "" {
+			view.Status = syncStatusFailed
+		}
+		views = append(views, view)
+	}
+
+	// a project belongs to the organization when its repository sits next to one of the listed repositories
+	for i := range projects {
+		project := &projects[i]
+		if seen[project.ID] || project.RepoURL == "" || !orgURLs[path.Dir(normalizeRepoURL(project.RepoURL))] {
+			continue
+		}
+		views = append(views, projectSyncView{
+			ProjectName: project.Name,
+			ProjectID:   project.ID,
+			MainBranch:  project.MainBranch,
+			RepoURL:     project.RepoURL,
+			Status:      syncStatusDeleted,
+		})
+	}
+	return views
+}
+
+func createSyncedProject(
+	projectsWrapper wrappers.ProjectsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	repository *scmRepository,
+	options *projectSyncOptions,
+) (projectID, failure string) {
+	projModel := wrappers.Project{
+		Name:       repository.FullName,
+		RepoURL:    repository.URL,
+		MainBranch: repository.DefaultBranch,
+		Tags:       make(map[string]string),
+	}
+	for key, value := range options.tags {
+		projModel.Tags[key] = value
+	}
+	if options.topicTags {
+		for _, topic := range repository.Topics {
+			projModel.Tags[topic] = ""
+		}
+	}
+	groups := options.repositoryGroups(repository)
+	if !options.accessManagementEnabled {
+		projModel.Groups = services.GetGroupIds(groups)
+	}
+
+	created, errorModel, err := projectsWrapper.Create(&projModel)
+	if err != nil {
+		return "", err.Error()
+	}
+	if errorModel != nil {
+		return "", fmt.Sprintf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	err = services.AssignGroupsToProjectNewAccessManagement(created.ID, created.Name, groups, accessManagementWrapper, featureFlagsWrapper)
+	if err != nil {
+		return created.ID, err.Error()
+	}
+	urlConf := getProjectConfiguration(repoConfKey, "repository", git, projOriginLevel, repository.URL, "String", true)
+	errorModel, err = projectsWrapper.UpdateConfiguration(created.ID, []wrappers.ProjectConfiguration{urlConf})
+	if err != nil {
+		return created.ID, err.Error()
+	}
+	if errorModel != nil {
+		return created.ID, fmt.Sprintf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	return created.ID, ""
+}
+
+// repositoryGroups returns the groups of every project plus the groups of the mapping rules matching the repository name
+func (o *projectSyncOptions) repositoryGroups(repository *scmRepository) []*wrappers.Group {
+	groups := append([]*wrappers.Group{}, o.groups...)
+	name := path.Base(repository.FullName)
+	patterns := make([]string, 0, len(o.groupMapping))
+	for pattern := range o.groupMapping {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		matchName, _ := path.Match(pattern, name)
+		matchFullName, _ := path.Match(pattern, repository.FullName)
+		if !matchName && !matchFullName {
+			continue
+		}
+		for _, group := range o.groupMapping[pattern] {
+			if !containsGroup(groups, group) {
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups
+}
+
+func containsGroup(groups []*wrappers.Group, group *wrappers.Group) bool {
+	for _, existing := range groups {
+		if existing.ID == group.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeRepoURL compares https repository URLs regardless of credentials, case and the .git suffix
+func normalizeRepoURL(repoURL string) string {
+	normalized := strings.ToLower(strings.TrimSpace(repoURL))
+	if scheme, rest, found := strings.Cut(normalized, "://"); found {
+		if at := strings.Index(rest, "@"); at >= 0 && at < strings.Index(rest+"/", "/") {
+			rest = rest[at+1:]
+		}
+		normalized = scheme + "://" + rest
+	}
+	normalized = strings.TrimSuffix(strings.TrimSuffix(normalized, "/"), ".git")
+	return normalized
+}
@@ -0,0 +1,417 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
+	featureFlagsConstants "github.com/checkmarx/ast-cli/internal/constants/feature-flags"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedUpdatingProject = "Failed updating a project"
+	projectUpToDate       = "Project %s is up to date"
+)
+
+type projectChangeView struct {
+	Field   string
+	Current string
+	New     string
+}
+
+// projectGroups keeps the groups of a project by id, with their names when they are known
+type projectGroups struct {
+	ids   []string
+	names map[string]string
+}
+
+func projectUpdateSubCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) *cobra.Command {
+	updateProjCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update the settings of a project",
+		Long: "The project update command changes the name, main branch, repository, tags, groups, application and private package " +
+			"setting of a project. The changes are printed before they are applied.",
+		Example: heredoc.Doc(
+			`
+			$ cx project update --project-id <project_id> --project-name <Project Name> --branch main
+			$ cx project update --project-id <project_id> --add-tags env:prod --remove-tags legacy --dry-run
+			$ cx project update --project-id <project_id> --repo-url git@github.com:org/repo.git --ssh-key ~/.ssh/id_rsa
+			$ cx project update --project-id <project_id> --application-name <New App> --remove-application-name <Old App>
+		`,
+		),
+		RunE: runUpdateProjectCommand(applicationsWrapper, projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper),
+	}
+	addProjectIDFlag(updateProjCmd, "Project ID to update.")
+	updateProjCmd.PersistentFlags().String(commonParams.ProjectName, "", "New name of the project")
+	updateProjCmd.PersistentFlags().String(commonParams.MainBranchFlag, "", "Main branch")
+	updateProjCmd.PersistentFlags().String(commonParams.RepoURLFlag, "", "Repository URL")
+	updateProjCmd.PersistentFlags().String(commonParams.SSHKeyFlag, "", "Path to ssh private key")
+	updateProjCmd.PersistentFlags().String(commonParams.TagList, "", "List of tags replacing the project tags, ex: (tagA,tagB:val,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.AddTagsFlag, "", "List of tags to add, ex: (tagA,tagB:val,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.RemoveTagsFlag, "", "List of tag keys to remove, ex: (tagA,tagB,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.GroupList, "", "List of groups replacing the project groups, ex: (PowerUsers,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.AddGroupsFlag, "", "List of groups to add, ex: (PowerUsers,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.RemoveGroupsFlag, "", "List of group names or ids to remove, ex: (PowerUsers,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.ApplicationName, "", "Name of the application to assign with the project")
+	updateProjCmd.PersistentFlags().String(commonParams.RemoveApplicationNameFlag, "", "Name of the application to unassign from the project")
+	updateProjCmd.PersistentFlags().Bool(commonParams.ProjecPrivatePackageFlag, false, "Enable or disable the private package setting of the project")
+	updateProjCmd.PersistentFlags().Bool(commonParams.DryRunFlag, false, "Print the changes without applying them")
+	return updateProjCmd
+}
+
+//nolint:gocyclo
+func runUpdateProjectCommand(
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		dryRun, _ := cmd.Flags().GetBool(commonParams.DryRunFlag)
+		if projectID == "" {
+			return errors.Errorf("%s: Please provide a project ID", failedUpdatingProject)
+		}
+		err := validateConfiguration(cmd)
+		if err != nil {
+			return err
+		}
+		current, errorModel, err := projectsWrapper.GetByID(projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProject)
+		}
+		if errorModel != nil {
+			return errors.Errorf(services.ErrorCodeFormat, failedUpdatingProject, errorModel.Code, errorModel.Message)
+		}
+
+		projModel := wrappers.Project{
+			Name:           current.Name,
+			RepoURL:        current.RepoURL,
+			MainBranch:     current.MainBranch,
+			Tags:           current.Tags,
+			Groups:         current.Groups,
+			PrivatePackage: current.PrivatePackage,
+			ApplicationIds: current.ApplicationIds,
+		}
+		if cmd.Flags().Changed(commonParams.ProjectName) {
+			projModel.Name, _ = cmd.Flags().GetString(commonParams.ProjectName)
+			if strings.TrimSpace(projModel.Name) == "" {
+				return errors.Errorf(errorConstants.ProjectNameIsRequired)
+			}
+		}
+		if cmd.Flags().Changed(commonParams.MainBranchFlag) {
+			projModel.MainBranch, _ = cmd.Flags().GetString(commonParams.MainBranchFlag)
+		}
+		if cmd.Flags().Changed(commonParams.RepoURLFlag) {
+			projModel.RepoURL, _ = cmd.Flags().GetString(commonParams.RepoURLFlag)
+		}
+		if cmd.Flags().Changed(commonParams.ProjecPrivatePackageFlag) {
+			projModel.PrivatePackage, _ = cmd.Flags().GetBool(commonParams.ProjecPrivatePackageFlag)
+		}
+		projModel.Tags = updateProjectTags(cmd, current.Tags)
+
+		applicationName, _ := cmd.Flags().GetString(commonParams.ApplicationName)
+		if applicationName != "" {
+			application, getAppErr := getApplication(applicationName, applicationsWrapper)
+			if getAppErr != nil {
+				return getAppErr
+			}
+			if application == nil {
+				return errors.Errorf(errorConstants.ApplicationDoesntExistOrNoPermission)
+			}
+			if !slices.Contains(projModel.ApplicationIds, application.ID) {
+				projModel.ApplicationIds = append(append([]string{}, projModel.ApplicationIds...), application.ID)
+			}
+		}
+		removeApplicationName, _ := cmd.Flags().GetString(commonParams.RemoveApplicationNameFlag)
+		if removeApplicationName != "" {
+			application, getAppErr := getApplication(removeApplicationName, applicationsWrapper)
+			if getAppErr != nil {
+				return getAppErr
+			}
+			if application == nil {
+				return errors.Errorf(errorConstants.ApplicationDoesntExistOrNoPermission)
+			}
+			projModel.ApplicationIds = slices.DeleteFunc(append([]string{}, projModel.ApplicationIds...), func(id string) bool {
+				return id == application.ID
+			})
+		}
+
+		flagResponse, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, featureFlagsConstants.AccessManagementEnabled)
+		accessManagementEnabled := flagResponse.Status
+		currentGroups, err := getProjectGroups(current, accessManagementWrapper, accessManagementEnabled)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProject)
+		}
+		newGroups, err := updateProjectGroups(cmd, currentGroups, groupsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProject)
+		}
+		if !accessManagementEnabled {
+			projModel.Groups = newGroups.ids
+		}
+
+		changes := diffProject(current, &projModel, currentGroups, newGroups)
+		if cmd.Flags().Changed(commonParams.SSHKeyFlag) {
+			changes = append(changes, projectChangeView{Field: "SSH key", Current: "", New: "updated"})
+		}
+		if len(changes) == 0 {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), projectUpToDate+"\n", projectID)
+			return nil
+		}
+		err = printByFormat(cmd, changes)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return nil
+		}
+
+		// the groups are assigned first so a failure there leaves the project untouched
+		if accessManagementEnabled {
+			err = updateProjectGroupsAssignment(projectID, projModel.Name, currentGroups, newGroups, accessManagementWrapper)
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedUpdatingProject)
+			}
+		}
+		err = projectsWrapper.Update(projectID, &projModel)
+		if err != nil {
+			if accessManagementEnabled && currentGroups.format() != newGroups.format() {
+				return errors.Wrapf(err, "%s, the groups were already changed to [%s]", failedUpdatingProject, newGroups.format())
+			}
+			return errors.Wrapf(err, "%s", failedUpdatingProject)
+		}
+		return updateProjectConfigurationIfNeeded(cmd, projectsWrapper, projectID)
+	}
+}
+
+// updateProjectTags replaces, adds and removes tags in that order, without changing the current map
+func updateProjectTags(cmd *cobra.Command, current map[string]string) map[string]string {
+	tags := make(map[string]string, len(current))
+	for key, value := range current {
+		tags[key] = value
+	}
+	if cmd.Flags().Changed(commonParams.TagList) {
+		tagList, _ := cmd.Flags().GetString(commonParams.TagList)
+		tags = parseTagList(tagList)
+	}
+	addTags, _ := cmd.Flags().GetString(commonParams.AddTagsFlag)
+	for key, value := range parseTagList(addTags) {
+		tags[key] = value
+	}
+	removeTags, _ := cmd.Flags().GetString(commonParams.RemoveTagsFlag)
+	for key := range parseTagList(removeTags) {
+		delete(tags, key)
+	}
+	return tags
+}
+
+func parseTagList(tagList string) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(tagList, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		keyValuePair := strings.SplitN(tag, ":", commonParams.KeyValuePairSize)
+		value := ""
+		if len(keyValuePair) > 1 {
+			value = keyValuePair[1]
+		}
+		tags[keyValuePair[0]] = value
+	}
+	return tags
+}
+
+// getProjectGroups reads the groups assigned to the project, from access management when it is enabled
+func getProjectGroups(
+	project *wrappers.ProjectResponseModel,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	accessManagementEnabled bool,
+) (*projectGroups, error) {
+	groups := &projectGroups{names: make(map[string]string)}
+	if !accessManagementEnabled {
+		groups.ids = append(groups.ids, project.Groups...)
+		return groups, nil
+	}
+	assigned, err := accessManagementWrapper.GetGroups(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range assigned {
+		groups.ids = append(groups.ids, group.ID)
+		groups.names[group.ID] = group.Name
+	}
+	return groups, nil
+}
+
+// updateProjectGroups replaces, adds and removes groups by name, in that order. The names of the resolved groups
+// are also kept in the current groups so the diff shows names instead of ids
+func updateProjectGroups(cmd *cobra.Command, current *projectGroups, groupsWrapper wrappers.GroupsWrapper) (*projectGroups, error) {
+	groups := &projectGroups{ids: append([]string{}, current.ids...), names: make(map[string]string)}
+	for id, name := range current.names {
+		groups.names[id] = name
+	}
+	resolve := func(flag string) ([]*wrappers.Group, error) {
+		groupList, _ := cmd.Flags().GetString(flag)
+		if groupList == "" {
+			return nil, nil
+		}
+		resolved, err := services.CreateGroupsMap(groupList, groupsWrapper)
+		for _, group := range resolved {
+			groups.names[group.ID] = group.Name
+			current.names[group.ID] = group.Name
+		}
+		return resolved, err
+	}
+
+	if cmd.Flags().Changed(commonParams.GroupList) {
+		replaced, err := resolve(commonParams.GroupList)
+		if err != nil {
+			return nil, err
+		}
+		groups.ids = services.GetGroupIds(replaced)
+	}
+	added, err := resolve(commonParams.AddGroupsFlag)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range added {
+		if !slices.Contains(groups.ids, group.ID) {
+			groups.ids = append(groups.ids, group.ID)
+		}
+	}
+	// groups can be removed by id or by a known name without looking them up
+	removeList, _ := cmd.Flags().GetString(commonParams.RemoveGroupsFlag)
+	var removedIDs, unknown []string
+	for _, name := range strings.Split(removeList, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if id := groups.find(name); id != "" {
+			removedIDs = append(removedIDs, id)
+			continue
+		}
+		unknown = append(unknown, name)
+	}
+	if len(unknown) > 0 {
+		removed, resolveErr := services.CreateGroupsMap(strings.Join(unknown, ","), groupsWrapper)
+		if resolveErr != nil {
+			return nil, resolveErr
+		}
+		removedIDs = append(removedIDs, services.GetGroupIds(removed)...)
+	}
+	var kept []string
+	for _, id := range groups.ids {
+		if !slices.Contains(removedIDs, id) {
+			kept = append(kept, id)
+		}
+	}
+	groups.ids = kept
+	return groups, nil
+}
+
+func updateProjectGroupsAssignment(
+	projectID, projectName string,
+	current, updated *projectGroups,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+) error {
+	var assigned, unassigned []*wrappers.Group
+	for _, id := range updated.ids {
+		if !slices.Contains(current.ids, id) {
+			assigned = append(assigned, &wrappers.Group{ID: id, Name: updated.names[id]})
+		}
+	}
+	for _, id := range current.ids {
+		if !slices.Contains(updated.ids, id) {
+			unassigned = append(unassigned, &wrappers.Group{ID: id, Name: current.names[id]})
+		}
+	}
+	if len(assigned) > 0 {
+		err := accessManagementWrapper.CreateGroupsAssignment(projectID, projectName, assigned)
+		if err != nil {
+			return err
+		}
+	}
+	if len(unassigned) > 0 {
+		return accessManagementWrapper.DeleteGroupsAssignment(projectID, unassigned)
+	}
+	return nil
+}
+
+// diffProject lists the fields that the update changes
+func diffProject(
+	current *wrappers.ProjectResponseModel,
+	updated *wrappers.Project,
+	currentGroups, updatedGroups *projectGroups,
+) []projectChangeView {
+	var changes []projectChangeView
+	addChange := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, projectChangeView{Field: field, Current: before, New: after})
+		}
+	}
+	addChange("Name", current.Name, updated.Name)
+	addChange("Main branch", current.MainBranch, updated.MainBranch)
+	addChange("Repository URL", current.RepoURL, updated.RepoURL)
+	addChange("Tags", formatProjectTags(current.Tags), formatProjectTags(updated.Tags))
+	addChange("Groups", currentGroups.format(), updatedGroups.format())
+	addChange("Applications", formatSorted(current.ApplicationIds), formatSorted(updated.ApplicationIds))
+	addChange("Private package", strconv.FormatBool(current.PrivatePackage), strconv.FormatBool(updated.PrivatePackage))
+	return changes
+}
+
+// find returns the id of a group of the project by id or name
+func (g *projectGroups) find(group string) string {
+	for _, id := range g.ids {
+		if id == group || g.names[id] == group {
+			return id
+		}
+	}
+	return ""
+}
+
+func (g *projectGroups) format() string {
+	names := make([]string, len(g.ids))
+	for i, id := range g.ids {
+		names[i] = id
+		if name, ok := g.names[id]; ok && name != "" {
+			names[i] = name
+		}
+	}
+	return formatSorted(names)
+}
+
+func formatProjectTags(tags map[string]string) string {
+	var pairs []string
+	for key, value := range tags {
+		if value == "" {
+			pairs = append(pairs, key)
+			continue
+		}
+		pairs = append(pairs, key+":"+value)
+	}
+	return formatSorted(pairs)
+}
+
+func formatSorted(values []string) string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
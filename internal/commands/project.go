@@ -3,6 +3,7 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 
 	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 const (
@@ -58,8 +60,10 @@ var (
 	)
 )
 
-func NewProjectCommand(applicationsWrapper wrappers.ApplicationsWrapper, projectsWrapper wrappers.ProjectsWrapper, groupsWrapper wrappers.GroupsWrapper,
-	accessManagementWrapper wrappers.AccessManagementWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper) *cobra.Command {
+func NewProjectCommand(applicationsWrapper wrappers.ApplicationsWrapper, projectsWrapper wrappers.ProjectsWrapper, scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper, resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper, groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper, featureFlagsWrapper wrappers.FeatureFlagsWrapper, gitHubWrapper wrappers.GitHubWrapper,
+	gitLabWrapper wrappers.GitLabWrapper, azureWrapper wrappers.AzureWrapper, bitBucketWrapper wrappers.BitBucketWrapper) *cobra.Command {
 	projCmd := &cobra.Command{
 		Use:   "project",
 		Short: "Manage projects",
@@ -163,6 +167,8 @@ func NewProjectCommand(applicationsWrapper wrappers.ApplicationsWrapper, project
 		Example: heredoc.Doc(
 			`
 			$ cx project delete --project-id <project_id>
+			$ cx project delete --project-id <project_id> --backup web-backup.zip
+			$ cx project delete --project-id <project_id> --yes
 		`,
 		),
 		Annotations: map[string]string{
@@ -172,9 +178,13 @@ func NewProjectCommand(applicationsWrapper wrappers.ApplicationsWrapper, project
 			`,
 			),
 		},
-		RunE: runDeleteProjectCommand(projectsWrapper),
+		RunE: runDeleteProjectCommand(projectsWrapper, scansWrapper, resultsWrapper, resultsPredicatesWrapper, accessManagementWrapper, featureFlagsWrapper),
 	}
 	addProjectIDFlag(deleteProjCmd, "Project ID to delete.")
+	deleteProjCmd.PersistentFlags().String(commonParams.BackupFlag, "",
+		"Zip file to save the project, its configuration, groups, latest results and triage history to before deleting it")
+	deleteProjCmd.PersistentFlags().Bool(commonParams.YesFlag, false, "Delete without asking for confirmation")
+	deleteProjCmd.PersistentFlags().Int(commonParams.ConcurrencyFlag, triageDefaultConcurrency, "Number of predicate histories read concurrently for the backup")
 
 	tagsCmd := &cobra.Command{
 		Use:   "tags",
@@ -194,13 +204,24 @@ func NewProjectCommand(applicationsWrapper wrappers.ApplicationsWrapper, project
 		RunE: runGetProjectsTagsCommand(projectsWrapper),
 	}
 
+	updateProjCmd := projectUpdateSubCommand(applicationsWrapper, projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper)
+
 	addFormatFlagToMultipleCommands(
-		[]*cobra.Command{showProjectCmd, listProjectsCmd, createProjCmd},
+		[]*cobra.Command{showProjectCmd, listProjectsCmd, createProjCmd, updateProjCmd},
 		printer.FormatTable,
 		printer.FormatJSON,
 		printer.FormatList,
 	)
-	projCmd.AddCommand(createProjCmd, projectBranchesCmd, showProjectCmd, listProjectsCmd, deleteProjCmd, tagsCmd)
+	configCmd := projectConfigSubCommand(projectsWrapper)
+	auditCmd := projectAuditSubCommand(projectsWrapper, scansWrapper, applicationsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper)
+	syncCmd := projectSyncSubCommand(
+		projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper, gitHubWrapper, gitLabWrapper, azureWrapper, bitBucketWrapper,
+	)
+	restoreCmd := projectRestoreSubCommand(projectsWrapper, resultsPredicatesWrapper, accessManagementWrapper, featureFlagsWrapper)
+	addFormatFlagToMultipleCommands([]*cobra.Command{restoreCmd}, printer.FormatTable, printer.FormatJSON, printer.FormatList)
+	projCmd.AddCommand(
+		createProjCmd, updateProjCmd, configCmd, syncCmd, auditCmd, projectBranchesCmd, showProjectCmd, listProjectsCmd, deleteProjCmd, restoreCmd, tagsCmd,
+	)
 	return projCmd
 }
 
@@ -482,7 +503,14 @@ func runGetBranchesByIDCommand(projectsWrapper wrappers.ProjectsWrapper) func(cm
 	}
 }
 
-func runDeleteProjectCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+func runDeleteProjectCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		var errorModel *wrappers.ErrorModel
 		var err error
@@ -490,6 +518,25 @@ func runDeleteProjectCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd
 		if projectID == "" {
 			return errors.Errorf("%s: Please provide a project ID", failedDeletingProj)
 		}
+		backupFile, _ := cmd.Flags().GetString(commonParams.BackupFlag)
+		yes, _ := cmd.Flags().GetBool(commonParams.YesFlag)
+		concurrency, _ := cmd.Flags().GetInt(commonParams.ConcurrencyFlag)
+		if concurrency <= 0 {
+			return errors.Errorf("--%s should be higher than 0", commonParams.ConcurrencyFlag)
+		}
+		// Scripts and pipelines keep deleting without a prompt
+		interactive := !yes && term.IsTerminal(int(os.Stdin.Fd()))
+		if backupFile != "" || interactive {
+			summary, prepareErr := prepareProjectDeletion(projectID, backupFile, concurrency, projectsWrapper, scansWrapper, resultsWrapper,
+				resultsPredicatesWrapper, accessManagementWrapper, featureFlagsWrapper)
+			if prepareErr != nil {
+				return errors.Wrapf(prepareErr, "%s", failedDeletingProj)
+			}
+			if interactive && !confirmProjectDeletion(os.Stdin, cmd.OutOrStdout(), summary) {
+				fmt.Fprintln(cmd.OutOrStdout(), projectDeletionCancelled)
+				return nil
+			}
+		}
 		errorModel, err = projectsWrapper.Delete(projectID)
 		if err != nil {
 			return errors.Wrapf(err, "%s\n", failedDeletingProj)
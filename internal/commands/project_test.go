@@ -3,11 +3,21 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
 	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
+	featureFlagsConstants "github.com/checkmarx/ast-cli/internal/constants/feature-flags"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
 	"github.com/checkmarx/ast-cli/internal/wrappers/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
 
 	"gotest.tools/assert"
 )
@@ -185,3 +195,151 @@ func TestCreateProjectWithSSHKey(t *testing.T) {
 
 	execCmdNilAssertion(t, append(baseArgs, "--ssh-key", "data/Dockerfile", "--repo-url", "git@github.com:dummyRepo/dummyProject.git")...)
 }
+
+func TestUpdateProjectNoProjectID(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "update", "--project-name", "MOCK")
+	assert.Error(t, err, "Failed updating a project: Please provide a project ID")
+}
+
+func TestUpdateProjectSSHKeyWithoutRepoURL(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK", "--ssh-key", "data/Dockerfile")
+	assert.Error(t, err, mandatoryRepoURLError)
+}
+
+func TestUpdateProjectDiff(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "update", "--project-id", "MOCK", "--project-name", "renamed",
+		"--add-tags", "env:prod", "--remove-tags", "a", "--add-groups", "group", "--remove-groups", "b",
+		"--project-private-package", "--dry-run", "--format", "json")
+	assert.NilError(t, err)
+	var changes []projectChangeView
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &changes))
+	assert.DeepEqual(t, changes, []projectChangeView{
+		{Field: "Name", Current: "", New: "renamed"},
+		{Field: "Tags", Current: "a:b,c:d", New: "c:d,env:prod"},
+		{Field: "Groups", Current: "a,b", New: "a,group"},
+		{Field: "Private package", Current: "false", New: "true"},
+	})
+}
+
+func TestUpdateProjectReplaceTagsAndApplication(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "update", "--project-id", "MOCK", "--tags", "team:red,critical",
+		"--application-name", "MOCK", "--repo-url", "git@github.com:dummyRepo/dummyProject.git", "--ssh-key", "data/Dockerfile", "--format", "json")
+	assert.NilError(t, err)
+	output := buffer.String()
+	assert.Assert(t, strings.Contains(output, `"New":"critical,team:red"`), output)
+	assert.Assert(t, strings.Contains(output, `"Field":"Applications"`), output)
+	assert.Assert(t, strings.Contains(output, `"Field":"SSH key"`), output)
+}
+
+func TestUpdateProjectUpToDate(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "update", "--project-id", "MOCK", "--add-tags", "a:b")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "Project MOCK is up to date\n")
+}
+
+func TestUpdateProjectUnknownGroup(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK", "--add-groups", "fake-group-error")
+	assert.ErrorContains(t, err, "Failed updating a project")
+}
+
+func TestProjectConfigShow(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "config", "show", "--project-id", "MOCK", "--key", "preset,scan.handler.git.sshKey", "--format", "json")
+	assert.NilError(t, err)
+	var views []projectConfigView
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &views))
+	assert.DeepEqual(t, views, []projectConfigView{
+		{Key: "scan.config.sast.presetName", Name: "presetName", Value: "ASA Premium", OriginLevel: "Tenant", AllowOverride: true},
+		{Key: "scan.handler.git.sshKey", Name: "sshKey", Value: secretValueMask, OriginLevel: projOriginLevel, AllowOverride: true},
+	})
+}
+
+func TestProjectConfigSetAndUnset(t *testing.T) {
+	execCmdNilAssertion(t, "project", "config", "set", "--project-id", "MOCK", "--key", "preset", "--value", "OWASP TOP 10")
+	execCmdNilAssertion(t, "project", "config", "unset", "--project-id", "MOCK", "--key", "sca-filter,preset")
+
+	err := execCmdNotNilAssertion(t, "project", "config", "unset", "--project-id", "MOCK", "--key", "nothing")
+	assert.Error(t, err, "Failed updating the project configuration: unknown configuration key nothing")
+}
+
+func TestProjectConfigExportAndImport(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "config", "export", "--project-id", "MOCK")
+	assert.NilError(t, err)
+	assert.Equal(t, buffer.String(), "projects:\n    - configuration:\n        incremental: \"true\"\n        sca-filter: '!**/test/**'\n      projectId: MOCK\n")
+
+	file := filepath.Join(t.TempDir(), "cx-config.yaml")
+	assert.NilError(t, os.WriteFile(file, buffer.Bytes(), 0600))
+	buffer, err = executeRedirectedTestCommand("project", "config", "import", "--file", file, "--prune", "--dry-run", "--format", "json")
+	assert.NilError(t, err)
+	var views []projectConfigChangeView
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &views))
+	for _, view := range views {
+		assert.Equal(t, view.Status, configStatusUnchanged)
+	}
+
+	assert.NilError(t, os.WriteFile(file, []byte("projects:\n  - projectId: A\n  - projectId: B\n"), 0600))
+	err = execCmdNotNilAssertion(t, "project", "config", "import", "--file", file, "--project-id", "MOCK")
+	assert.Error(t, err, "Failed updating the project configuration: --project-id needs a file with a single project")
+
+	assert.NilError(t, os.WriteFile(file, []byte("defaults:\n  scan.config.sast.presetName: ASA Premium\nprojects:\n  - projectId: MOCK\n    configuration:\n      incremental: true\n"), 0600))
+	buffer, err = executeRedirectedTestCommand("project", "config", "import", "--file", file, "--dry-run", "--format", "json")
+	assert.NilError(t, err)
+	views = nil
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &views))
+	assert.DeepEqual(t, views, []projectConfigChangeView{
+		{ProjectID: "MOCK", Key: "scan.config.sast.incremental", Current: "true", New: "true", Status: configStatusUnchanged},
+		{ProjectID: "MOCK", Key: "scan.config.sast.presetName", Current: "ASA Premium", New: "ASA Premium", Status: configStatusWouldSet},
+	})
+}
+
+func TestProjectConfigSetSecret(t *testing.T) {
+	buffer, err := executeRedirectedTestCommand("project", "config", "set", "--project-id", "MOCK", "--key", "scan.handler.git.sshKey",
+		"--value", "private-key", "--format", "json")
+	assert.NilError(t, err)
+	var views []projectConfigChangeView
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &views))
+	assert.DeepEqual(t, views, []projectConfigChangeView{
+		{ProjectID: "MOCK", Key: "scan.handler.git.sshKey", Current: secretValueMask, New: secretValueMask, Status: configStatusSet},
+	})
+}
+
+type failingUpdateProjectsWrapper struct {
+	mock.ProjectsMockWrapper
+}
+
+func (p *failingUpdateProjectsWrapper) GetByID(projectID string) (*wrappers.ProjectResponseModel, *wrappers.ErrorModel, error) {
+	project, errorModel, err := p.ProjectsMockWrapper.GetByID(projectID)
+	if project != nil {
+		project.ApplicationIds = []string{"mockID"}
+	}
+	return project, errorModel, err
+}
+
+func (p *failingUpdateProjectsWrapper) Update(_ string, _ *wrappers.Project) error {
+	return errors.New("update failed")
+}
+
+func executeFailingUpdateProjectCommand(args ...string) (*bytes.Buffer, error) {
+	cmd := projectUpdateSubCommand(&mock.ApplicationsMockWrapper{}, &failingUpdateProjectsWrapper{}, &mock.GroupsMockWrapper{},
+		&mock.AccessManagementMockWrapper{}, &mock.FeatureFlagsMockWrapper{})
+	addFormatFlagToMultipleCommands([]*cobra.Command{cmd}, printer.FormatTable, printer.FormatJSON)
+	buffer := bytes.NewBufferString("")
+	cmd.SetOut(buffer)
+	return buffer, executeTestCommand(cmd, args...)
+}
+
+func TestUpdateProjectRemoveApplication(t *testing.T) {
+	buffer, err := executeFailingUpdateProjectCommand("--project-id", "MOCK", "--remove-application-name", "MOCK", "--dry-run", "--format", "json")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(buffer.String(), `{"Field":"Applications","Current":"mockID","New":""}`), buffer.String())
+}
+
+func TestUpdateProjectGroupsChangedBeforeFailedUpdate(t *testing.T) {
+	clearFlags()
+	mock.Flag = wrappers.FeatureFlagResponseModel{Name: featureFlagsConstants.AccessManagementEnabled, Status: true}
+	defer clearFlags()
+	_, err := executeFailingUpdateProjectCommand("--project-id", "MOCK", "--add-groups", "group")
+	assert.Error(t, err, "Failed updating a project, the groups were already changed to [group]: update failed")
+
+	_, err = executeFailingUpdateProjectCommand("--project-id", "MOCK", "--project-name", "renamed")
+	assert.Error(t, err, "Failed updating a project: update failed")
+}
@@ -172,6 +172,10 @@ func NewResultsCommand(
 	scsScanOverviewWrapper wrappers.ScanOverviewWrapper,
 	policyWrapper wrappers.PolicyWrapper,
 	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
 ) *cobra.Command {
 	resultCmd := &cobra.Command{
 		Use:   "results",
@@ -189,8 +193,13 @@ func NewResultsCommand(
 	codeBashingCmd := resultCodeBashing(codeBashingWrapper)
 	bflResultCmd := resultBflSubCommand(bflWrapper)
 	exitCodeSubcommand := exitCodeSubCommand(scanWrapper)
+	trendCmd := resultTrendSubCommand(resultsWrapper, scanWrapper)
+	fixPlanCmd := resultFixPlanSubCommand(resultsWrapper, bflWrapper)
+	slaCmd := resultSLASubCommand(resultsWrapper, scanWrapper)
+	portfolioCmd := resultPortfolioSubCommand(resultsWrapper, scanWrapper, projectsWrapper, applicationsWrapper, groupsWrapper,
+		accessManagementWrapper, featureFlagsWrapper, policyWrapper)
 	resultCmd.AddCommand(
-		showResultCmd, bflResultCmd, codeBashingCmd, exitCodeSubcommand,
+		showResultCmd, bflResultCmd, codeBashingCmd, exitCodeSubcommand, portfolioCmd, trendCmd, fixPlanCmd, slaCmd,
 	)
 	return resultCmd
 }
@@ -231,6 +240,9 @@ func resultShowSubCommand(
 		Example: heredoc.Doc(
 			`
 			$ cx results show --scan-id <scan Id>
+			$ cx results show --scan-id <scan Id> --save-bundle results.zip
+			$ cx results show --from-bundle results.zip --report-format sarif,summaryHTML
+			$ cx results show --scan-id <scan Id> --new-only --pr-base-branch main
 		`,
 		),
 		RunE: runGetResultCommand(resultsWrapper, scanWrapper, exportWrapper, resultsPdfReportsWrapper, risksOverviewWrapper, scsScanOverviewWrapper, policyWrapper, featureFlagsWrapper),
@@ -248,6 +260,11 @@ func resultShowSubCommand(
 		printer.FormatSummaryMarkdown,
 		printer.FormatGLSast,
 		printer.FormatGLSca,
+		printer.FormatDOT,
+		printer.FormatMermaid,
+		printer.FormatComplianceHTML,
+		printer.FormatComplianceJSON,
+		printer.FormatCSV,
 	)
 	resultShowCmd.PersistentFlags().String(commonParams.ReportFormatPdfToEmailFlag, "", pdfToEmailFlagDescription)
 	resultShowCmd.PersistentFlags().String(commonParams.ReportSbomFormatFlag, services.DefaultSbomOption, sbomReportFlagDescription)
@@ -255,6 +272,19 @@ func resultShowSubCommand(
 	resultShowCmd.PersistentFlags().String(commonParams.TargetFlag, "cx_result", "Output file")
 	resultShowCmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
 	resultShowCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterResultsListFlagUsage)
+	resultShowCmd.PersistentFlags().String(commonParams.WhereFlag, "", commonParams.WhereFlagUsage)
+	resultShowCmd.PersistentFlags().String(
+		commonParams.SnippetsSourceDirFlag,
+		"",
+		"Local checkout of the scanned sources, embeds the code around SAST nodes and IaC Security locations into json, markdown and summary reports",
+	)
+	resultShowCmd.PersistentFlags().Int(commonParams.SnippetsContextFlag, defaultSnippetsContext, "Number of source lines around each snippet location")
+	resultShowCmd.PersistentFlags().String(commonParams.ComplianceFrameworkFlag, "", complianceFrameworkFlagDescription)
+	resultShowCmd.PersistentFlags().Bool(commonParams.StreamResultsFlag, false, streamResultsFlagDescription)
+	resultShowCmd.PersistentFlags().Bool(commonParams.NewOnlyFlag, false, "Only report the findings introduced by the scanned branch, requires --"+commonParams.PrBaseBranchFlag)
+	resultShowCmd.PersistentFlags().String(commonParams.PrBaseBranchFlag, "", "Base branch the scanned branch is compared with")
+	resultShowCmd.PersistentFlags().String(commonParams.FromBundleFlag, "", "Create the reports from a results bundle instead of the API")
+	resultShowCmd.PersistentFlags().String(commonParams.SaveBundleFlag, "", "Save the scan, results, SCA export, policy and risk overview to a results bundle zip file")
 
 	resultShowCmd.PersistentFlags().IntP(
 		commonParams.WaitDelayFlag,
@@ -933,13 +963,80 @@ func runGetResultCommand(
 		agent, _ := cmd.Flags().GetString(commonParams.AgentFlag)
 
 		scanID, _ := cmd.Flags().GetString(commonParams.ScanIDFlag)
-		if scanID == "" {
+		fromBundle, _ := cmd.Flags().GetString(commonParams.FromBundleFlag)
+		saveBundle, _ := cmd.Flags().GetString(commonParams.SaveBundleFlag)
+		if scanID == "" && fromBundle == "" {
 			return errors.Errorf("%s: Please provide a scan ID", failedListingResults)
 		}
+		if fromBundle != "" && saveBundle != "" {
+			return errors.Errorf("%s: --%s and --%s can't be used together", failedListingResults, commonParams.FromBundleFlag, commonParams.SaveBundleFlag)
+		}
+		newOnly, _ := cmd.Flags().GetBool(commonParams.NewOnlyFlag)
+		prBaseBranch, _ := cmd.Flags().GetString(commonParams.PrBaseBranchFlag)
+		if newOnly && prBaseBranch == "" {
+			return errors.Errorf("%s: --%s requires --%s", failedListingResults, commonParams.NewOnlyFlag, commonParams.PrBaseBranchFlag)
+		}
+		if newOnly && (fromBundle != "" || saveBundle != "") {
+			return errors.Errorf("%s: --%s can't be used with results bundles", failedListingResults, commonParams.NewOnlyFlag)
+		}
 		params, err := getFilters(cmd)
 		if err != nil {
 			return errors.Wrapf(err, "%s", failedListingResults)
 		}
+		err = addWhereFilter(cmd, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedListingResults)
+		}
+		addComplianceFrameworkFilter(cmd, params)
+		snippetsSourceDir, _ := cmd.Flags().GetString(commonParams.SnippetsSourceDirFlag)
+		snippetsContext, _ := cmd.Flags().GetInt(commonParams.SnippetsContextFlag)
+		err = addSnippetsOptions(snippetsSourceDir, snippetsContext, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedListingResults)
+		}
+		if sastRedundancy {
+			params[commonParams.SastRedundancyFlag] = ""
+		}
+		err = addStreamResultsOption(cmd, format, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedListingResults)
+		}
+
+		if fromBundle != "" {
+			err = validateBundleFormats(format)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed(commonParams.FilterFlag) {
+				logger.PrintIfVerbose("Results filters are not applied to bundle results, use --" + commonParams.WhereFlag)
+			}
+			bundle, bundleErr := loadResultsBundle(fromBundle)
+			if bundleErr != nil {
+				return bundleErr
+			}
+			scan, policyResponseModel, bundleErr := bundle.scanAndPolicy()
+			if bundleErr != nil {
+				return bundleErr
+			}
+			return CreateScanReport(
+				&bundleResultsWrapper{ResultsWrapper: resultsWrapper, bundle: bundle},
+				&bundleRisksOverviewWrapper{bundle: bundle},
+				&bundleScanOverviewWrapper{bundle: bundle},
+				&bundleExportWrapper{bundle: bundle},
+				policyResponseModel,
+				resultsPdfReportsWrapper,
+				scan,
+				format,
+				formatPdfToEmail,
+				formatPdfOptions,
+				formatSbomOptions,
+				targetFile,
+				targetPath,
+				agent,
+				params,
+				&bundleFeatureFlagsWrapper{bundle: bundle})
+		}
+
 		scan, errorModel, scanErr := scanWrapper.GetByID(scanID)
 		if scanErr != nil {
 			return errors.Wrapf(scanErr, "%s", failedGetting)
@@ -947,6 +1044,12 @@ func runGetResultCommand(
 		if errorModel != nil {
 			return errors.Errorf("%s: CODE: %d, %s", failedGettingScan, errorModel.Code, errorModel.Message)
 		}
+		if newOnly {
+			err = addNewOnlyFilter(scanWrapper, scan, prBaseBranch, params)
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedListingResults)
+			}
+		}
 
 		policyResponseModel := &wrappers.PolicyResponseModel{}
 		policyOverrideFlag, _ := cmd.Flags().GetBool(commonParams.IgnorePolicyFlag)
@@ -963,8 +1066,37 @@ func runGetResultCommand(
 		} else {
 			logger.PrintIfVerbose("Skipping policy evaluation")
 		}
-		if sastRedundancy {
-			params[commonParams.SastRedundancyFlag] = ""
+
+		if saveBundle != "" {
+			bundle := newResultsBundle()
+			bundle.add(bundleScanFile, scan)
+			bundle.add(bundlePolicyFile, policyResponseModel)
+			err = bundle.addScanParts(resultsWrapper, risksOverviewWrapper, scsScanOverviewWrapper, exportWrapper, featureFlagsWrapper, scan)
+			if err != nil {
+				return err
+			}
+			err = CreateScanReport(
+				&recordingResultsWrapper{ResultsWrapper: resultsWrapper, bundle: bundle},
+				&bundleRisksOverviewWrapper{bundle: bundle},
+				&bundleScanOverviewWrapper{bundle: bundle},
+				exportWrapper,
+				policyResponseModel,
+				resultsPdfReportsWrapper,
+				scan,
+				format,
+				formatPdfToEmail,
+				formatPdfOptions,
+				formatSbomOptions,
+				targetFile,
+				targetPath,
+				agent,
+				params,
+				featureFlagsWrapper)
+			if err != nil {
+				return err
+			}
+			bundle.addFeatureFlags(featureFlagsWrapper)
+			return bundle.save(saveBundle)
 		}
 
 		return CreateScanReport(
@@ -1057,6 +1189,9 @@ func CreateScanReport(
 ) error {
 	reportList := strings.Split(reportTypes, ",")
 	results := &wrappers.ScanResultsCollection{}
+	// The compliance framework only filters the compliance reports, it must not be sent to the API
+	complianceFramework := params[commonParams.ComplianceFrameworkFlag]
+	delete(params, commonParams.ComplianceFrameworkFlag)
 	setIsSCSEnabled(featureFlagsWrapper)
 	setIsContainersEnabled(agent, featureFlagsWrapper)
 	summary, err := convertScanToResultsSummary(scan, resultsWrapper)
@@ -1070,6 +1205,13 @@ func CreateScanReport(
 	if err != nil {
 		return err
 	}
+	if _, streamResults := params[commonParams.StreamResultsFlag]; streamResults && !scanPending {
+		return createStreamedScanReport(resultsWrapper, exportWrapper, scan, summary, reportList, targetFile, targetPath, params,
+			func(summary *wrappers.ResultSummary) (*wrappers.ResultSummary, error) {
+				return summaryReport(summary, policyResponseModel, risksOverviewWrapper, scsScanOverviewWrapper, featureFlagsWrapper, &wrappers.ScanResultsCollection{})
+			}, featureFlagsWrapper)
+	}
+	delete(params, commonParams.StreamResultsFlag)
 	if !scanPending {
 		results, err = ReadResults(resultsWrapper, exportWrapper, scan, params)
 		if err != nil {
@@ -1082,10 +1224,11 @@ func CreateScanReport(
 		if err != nil {
 			return err
 		}
+		summary.CodeSnippets = collectCodeSnippets(results)
 	}
 	for _, reportType := range reportList {
 		err = createReport(reportType, formatPdfToEmail, formatPdfOptions, formatSbomOptions, targetFile,
-			targetPath, results, summary, exportWrapper, resultsPdfReportsWrapper, featureFlagsWrapper)
+			targetPath, results, summary, exportWrapper, resultsPdfReportsWrapper, featureFlagsWrapper, complianceFramework)
 		if err != nil {
 			return err
 		}
@@ -1220,7 +1363,8 @@ func createReport(format,
 	summary *wrappers.ResultSummary,
 	exportWrapper wrappers.ExportWrapper,
 	resultsPdfReportsWrapper wrappers.ResultsPdfWrapper,
-	featureFlagsWrapper wrappers.FeatureFlagsWrapper) error {
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	complianceFramework string) error {
 	if printer.IsFormat(format, printer.FormatIndentedJSON) {
 		return nil
 	}
@@ -1236,6 +1380,26 @@ func createReport(format,
 		jsonRpt := createTargetName(targetFile, targetPath, printer.FormatJSON)
 		return exportJSONResults(jsonRpt, results)
 	}
+	if printer.IsFormat(format, printer.FormatCSV) && isValidScanStatus(summary.Status, printer.FormatCSV) {
+		csvRpt := createTargetName(targetFile, targetPath, printer.FormatCSV)
+		return exportCSVResults(csvRpt, results)
+	}
+	if printer.IsFormat(format, printer.FormatComplianceJSON) && isValidScanStatus(summary.Status, printer.FormatComplianceJSON) {
+		complianceRpt := createTargetName(fmt.Sprintf("%s%s", targetFile, complianceTypeLabel), targetPath, printer.FormatJSON)
+		return writeJSONReport(complianceRpt, buildComplianceReport(results, summary, complianceFramework))
+	}
+	if printer.IsFormat(format, printer.FormatComplianceHTML) && isValidScanStatus(summary.Status, printer.FormatComplianceHTML) {
+		complianceRpt := createTargetName(fmt.Sprintf("%s%s", targetFile, complianceTypeLabel), targetPath, printer.FormatHTML)
+		return writeComplianceHTML(complianceRpt, buildComplianceReport(results, summary, complianceFramework))
+	}
+	if printer.IsFormat(format, printer.FormatDOT) && isValidScanStatus(summary.Status, printer.FormatDOT) {
+		dotRpt := createTargetName(fmt.Sprintf("%s%s", targetFile, attackFlowTypeLabel), targetPath, printer.FormatDOT)
+		return exportAttackFlowDot(dotRpt, results)
+	}
+	if printer.IsFormat(format, printer.FormatMermaid) && isValidScanStatus(summary.Status, printer.FormatMermaid) {
+		mermaidRpt := createTargetName(fmt.Sprintf("%s%s", targetFile, attackFlowTypeLabel), targetPath, printer.FormatMarkdown)
+		return exportAttackFlowMermaid(mermaidRpt, results)
+	}
 	if printer.IsFormat(format, printer.FormatGLSast) {
 		jsonRpt := createTargetName(fmt.Sprintf("%s%s", targetFile, glSastTypeLabel), targetPath, printer.FormatJSON)
 		return exportGlSastResults(jsonRpt, results, summary)
@@ -1315,6 +1479,15 @@ func ReadResults(
 
 	params[commonParams.ScanIDQueryParam] = scan.ID
 	_, sastRedundancy := params[commonParams.SastRedundancyFlag]
+	// The where expression is evaluated locally, it must not be sent to the API
+	whereExpression, hasWhere := params[commonParams.WhereFlag]
+	delete(params, commonParams.WhereFlag)
+	snippetsSourceDir, hasSnippets := params[commonParams.SnippetsSourceDirFlag]
+	snippetsContext, _ := strconv.Atoi(params[commonParams.SnippetsContextFlag])
+	delete(params, commonParams.SnippetsSourceDirFlag)
+	delete(params, commonParams.SnippetsContextFlag)
+	baseScanID, newOnly := params[newOnlyBaseScanParam]
+	delete(params, newOnlyBaseScanParam)
 
 	resultsModel, errorModel, err = resultsWrapper.GetAllResultsByScanID(params)
 
@@ -1334,6 +1507,21 @@ func ReadResults(
 		if err != nil {
 			return nil, err
 		}
+		if newOnly {
+			resultsModel, err = filterNewResults(resultsWrapper, resultsModel, baseScanID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if hasWhere {
+			resultsModel, err = FilterResultsByWhereExpression(resultsModel, whereExpression)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if hasSnippets {
+			addResultsSnippets(resultsModel, snippetsSourceDir, snippetsContext)
+		}
 
 		resultsModel.ScanID = scan.ID
 		return resultsModel, nil
@@ -1346,21 +1534,40 @@ func enrichScaResults(
 	scan *wrappers.ScanResponseModel,
 	resultsModel *wrappers.ScanResultsCollection,
 ) (*wrappers.ScanResultsCollection, error) {
+	scaExport, err := getScaExportModels(exportWrapper, scan)
+	if err != nil {
+		return nil, err
+	}
+	return scaExport.enrich(scan, resultsModel), nil
+}
+
+// scaExportModels holds the SCA packages and types of a scan, read once to enrich every page of results
+type scaExportModels struct {
+	packages *[]wrappers.ScaPackageCollection
+	types    *[]wrappers.ScaTypeCollection
+}
+
+func getScaExportModels(exportWrapper wrappers.ExportWrapper, scan *wrappers.ScanResponseModel) (*scaExportModels, error) {
+	scaExport := &scaExportModels{}
 	if slices.Contains(scan.Engines, commonParams.ScaType) {
 		scaExportDetails, err := services.GetExportPackage(exportWrapper, scan.ID)
 		if err != nil {
 			return nil, errors.Wrapf(err, "%s", failedListingResults)
 		}
-		scaPackageModel := parseScaExportPackage(scaExportDetails.Packages)
-		scaTypeModel := parseExportScaVulnerability(scaExportDetails.ScaTypes)
-		if scaPackageModel != nil {
-			resultsModel = addPackageInformation(resultsModel, scaPackageModel, scaTypeModel)
-		}
+		scaExport.packages = parseScaExportPackage(scaExportDetails.Packages)
+		scaExport.types = parseExportScaVulnerability(scaExportDetails.ScaTypes)
+	}
+	return scaExport, nil
+}
+
+func (s *scaExportModels) enrich(scan *wrappers.ScanResponseModel, resultsModel *wrappers.ScanResultsCollection) *wrappers.ScanResultsCollection {
+	if s.packages != nil {
+		resultsModel = addPackageInformation(resultsModel, s.packages, s.types)
 	}
 	if slices.Contains(scan.Engines, commonParams.ContainersType) && !wrappers.IsContainersEnabled {
 		resultsModel = removeContainerResults(resultsModel)
 	}
-	return resultsModel, nil
+	return resultsModel
 }
 
 func parseExportScaVulnerability(types []wrappers.ScaType) *[]wrappers.ScaTypeCollection {
@@ -1591,6 +1798,22 @@ func exportJSONResults(targetFile string, results *wrappers.ScanResultsCollectio
 	return nil
 }
 
+func exportCSVResults(targetFile string, results *wrappers.ScanResultsCollection) error {
+	writer, err := newCSVStreamWriter(targetFile)
+	if err != nil {
+		return err
+	}
+	if results == nil {
+		return writer.close("")
+	}
+	err = writer.writePage(results)
+	if err != nil {
+		writer.abort()
+		return err
+	}
+	return writer.close(results.ScanID)
+}
+
 func exportJSONSummaryResults(targetFile string, results *wrappers.ResultSummary) error {
 	var err error
 	var resultsJSON []byte
@@ -1755,8 +1978,8 @@ func translateReportSectionsForImproved(sections []string) []string {
 
 func convertCxResultsToSarif(results *wrappers.ScanResultsCollection) *wrappers.SarifResultsCollection {
 	var sarif = new(wrappers.SarifResultsCollection)
-	sarif.Schema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
-	sarif.Version = "2.1.0"
+	sarif.Schema = sarifSchema
+	sarif.Version = sarifVersion
 	sarif.Runs = []wrappers.SarifRun{}
 	sarif.Runs = append(sarif.Runs, createSarifRun(results))
 	return sarif
@@ -6,15 +6,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
 	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
+	featureFlagsConstants "github.com/checkmarx/ast-cli/internal/constants/feature-flags"
 	"github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"github.com/pkg/errors"
 	"gotest.tools/assert"
 )
 
@@ -973,3 +977,375 @@ func TestGetResultsSummaryConsoleFormatWithCriticalDisabled(t *testing.T) {
 
 	mock.SetScsMockVarsToDefault()
 }
+
+func TestRunGetResultsByScanIdJsonFormatWithWhereExpression(t *testing.T) {
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--report-format", "json", "--where", "type == sast && severity in (HIGH)")
+
+	// Remove generated json file
+	removeFileBySuffix(t, printer.FormatJSON)
+}
+
+func TestRunGetResultsByScanIdWithInvalidWhereExpression(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--where", "severity = high")
+	assertError(t, err, invalidWhereExpression)
+}
+
+func TestRunResultsPortfolio_AllFormats(t *testing.T) {
+	execCmdNilAssertion(t, "results", "portfolio", "--application-name", "MOCK", "--report-format", "json,html,csv")
+
+	for _, format := range []string{printer.FormatJSON, printer.FormatHTML, printer.FormatCSV} {
+		removeFile(t, "cx_portfolio", format)
+	}
+}
+
+func TestRunResultsPortfolio_InvalidFormat(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "portfolio", "--report-format", "pdf")
+	assertError(t, err, "bad report format pdf")
+}
+
+// projectGroupsAccessWrapper serves the groups assigned to each project by access management
+type projectGroupsAccessWrapper struct {
+	mock.AccessManagementMockWrapper
+	groups map[string][]*wrappers.Group
+}
+
+func (a *projectGroupsAccessWrapper) GetGroups(projectID string) ([]*wrappers.Group, error) {
+	return a.groups[projectID], nil
+}
+
+func TestGetPortfolioProjectsGroupsFromAccessManagement(t *testing.T) {
+	clearFlags()
+	mock.Flag = wrappers.FeatureFlagResponseModel{Name: featureFlagsConstants.AccessManagementEnabled, Status: true}
+	defer clearFlags()
+	projectsWrapper := &syncedProjectsWrapper{projects: []wrappers.ProjectResponseModel{
+		{ID: "P1", Name: "legacy-groups", Groups: []string{"1"}},
+		{ID: "P2", Name: "assigned"},
+	}}
+	accessWrapper := &projectGroupsAccessWrapper{groups: map[string][]*wrappers.Group{"P2": {{ID: "1", Name: "group"}}}}
+	cmd := resultPortfolioSubCommand(&mock.ResultsMockWrapper{}, &mock.ScansMockWrapper{}, projectsWrapper, &mock.ApplicationsMockWrapper{},
+		&mock.GroupsMockWrapper{}, accessWrapper, &mock.FeatureFlagsMockWrapper{}, &mock.PolicyMockWrapper{})
+	assert.NilError(t, cmd.ParseFlags([]string{"--project-groups", "group"}))
+
+	projects, err := getPortfolioProjects(cmd, projectsWrapper, &mock.ApplicationsMockWrapper{}, &mock.GroupsMockWrapper{},
+		accessWrapper, &mock.FeatureFlagsMockWrapper{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(projects), 1)
+	assert.Equal(t, projects[0].ID, "P2")
+}
+
+func TestBuildPortfolioSummary(t *testing.T) {
+	resultsModel, _, _ := (&mock.ResultsMockWrapper{}).GetAllResultsByScanID(map[string]string{})
+	projectResults := []*portfolioProjectResults{
+		newPortfolioProjectResults(&wrappers.PortfolioProjectSummary{ProjectName: "b", ScanID: "1", EnginesIssues: map[string]int{}}),
+		newPortfolioProjectResults(&wrappers.PortfolioProjectSummary{ProjectName: "a", ScanID: "2", EnginesIssues: map[string]int{}}),
+		newPortfolioProjectResults(&wrappers.PortfolioProjectSummary{ProjectName: "c", Error: portfolioNoScanMessage}),
+	}
+	// The results of a project are counted page by page
+	projectResults[0].count(resultsModel)
+	projectResults[1].count(&wrappers.ScanResultsCollection{Results: resultsModel.Results[:1]})
+	projectResults[1].count(&wrappers.ScanResultsCollection{Results: resultsModel.Results[1:]})
+
+	portfolio := buildPortfolioSummary(projectResults, 2)
+
+	assert.Equal(t, portfolio.TotalProjects, 3)
+	assert.Equal(t, portfolio.ScannedProjects, 2)
+	assert.Equal(t, portfolio.TotalIssues, 16)
+	assert.Equal(t, portfolio.HighIssues, 10)
+	assert.Equal(t, portfolio.Projects[0].ProjectName, "a")
+	assert.Equal(t, len(portfolio.TopQueries), 2)
+	assert.Equal(t, portfolio.TopQueries[0].Projects, 2)
+	assert.Equal(t, portfolio.TopQueries[0].Occurrences, 4)
+	assert.Equal(t, len(portfolio.TopPackages), 2)
+	assert.Equal(t, portfolio.TopPackages[0].Name, "mock")
+	assert.Equal(t, portfolio.TopPackages[0].Occurrences, 2)
+	assert.Equal(t, portfolio.TopPackages[0].Projects, 2)
+}
+
+func TestHasAllTags(t *testing.T) {
+	projectTags := map[string]string{"team": "payments", "critical": ""}
+	assert.Assert(t, hasAllTags(projectTags, parseTagList("team:payments,critical")))
+	assert.Assert(t, hasAllTags(projectTags, parseTagList("")))
+	assert.Assert(t, !hasAllTags(projectTags, parseTagList("team:billing")))
+	assert.Assert(t, !hasAllTags(projectTags, parseTagList("missing")))
+}
+
+func TestRunResultsTrend_AllFormats(t *testing.T) {
+	execCmdNilAssertion(t, "results", "trend", "--project-id", "MOCK", "--branch", "main", "--report-format", "json,html,csv")
+
+	for _, format := range []string{printer.FormatJSON, printer.FormatHTML, printer.FormatCSV} {
+		removeFile(t, "cx_trend", format)
+	}
+}
+
+func TestRunResultsTrend_InvalidLast(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "trend", "--project-id", "MOCK", "--last", "0")
+	assertError(t, err, "--last should be higher than 0")
+}
+
+type trendResultsWrapper struct {
+	mock.ResultsMockWrapper
+	summaries map[string]wrappers.ScanSumaries
+	results   map[string][]*wrappers.ScanResult
+	readScans []string
+}
+
+func (r *trendResultsWrapper) GetAllResultsByScanID(queryParams map[string]string) (*wrappers.ScanResultsCollection, *wrappers.WebError, error) {
+	scanID := queryParams[params.ScanIDQueryParam]
+	r.readScans = append(r.readScans, scanID)
+	return &wrappers.ScanResultsCollection{Results: r.results[scanID], TotalCount: uint(len(r.results[scanID]))}, nil, nil
+}
+
+func (r *trendResultsWrapper) GetScanSummariesByScanIDS(queryParams map[string]string) (*wrappers.ScanSummariesModel, *wrappers.WebError, error) {
+	summaries := &wrappers.ScanSummariesModel{}
+	for _, scanID := range strings.Split(queryParams[params.ScanIDsQueryParam], ",") {
+		summary := r.summaries[scanID]
+		summary.ScanID = scanID
+		summaries.ScansSummaries = append(summaries.ScansSummaries, summary)
+	}
+	return summaries, nil, nil
+}
+
+func TestBuildResultsTrend(t *testing.T) {
+	firstScanDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	scans := []wrappers.ScanResponseModel{
+		{ID: "1", CreatedAt: firstScanDate},
+		{ID: "2", CreatedAt: firstScanDate.AddDate(0, 0, 10)},
+		{ID: "3", CreatedAt: firstScanDate.AddDate(0, 0, 20)},
+	}
+	secondSummary := wrappers.ScanSumaries{
+		SastCounters: wrappers.SastCounters{
+			SeverityCounters: []wrappers.SeverityCounters{{Severity: "LOW", Counter: 1}},
+			StatusCounters:   []wrappers.StatusCounters{{Status: "RECURRENT", Counter: 1}},
+		},
+		KicsCounters: wrappers.KicsCounters{
+			SeverityCounters: []wrappers.SeverityCounters{{Severity: "MEDIUM", Counter: 1}},
+			StatusCounters:   []wrappers.StatusCounters{{Status: "NEW", Counter: 1}},
+		},
+	}
+	resultsWrapper := &trendResultsWrapper{
+		summaries: map[string]wrappers.ScanSumaries{
+			"1": {SastCounters: wrappers.SastCounters{
+				SeverityCounters: []wrappers.SeverityCounters{{Severity: "HIGH", Counter: 1}, {Severity: "LOW", Counter: 1}},
+				StatusCounters:   []wrappers.StatusCounters{{Status: "NEW", Counter: 2}},
+			}},
+			"2": secondSummary,
+			// Nothing is new or fixed in the third scan
+			"3": {SastCounters: secondSummary.SastCounters, KicsCounters: wrappers.KicsCounters{
+				SeverityCounters: []wrappers.SeverityCounters{{Severity: "MEDIUM", Counter: 1}},
+				StatusCounters:   []wrappers.StatusCounters{{Status: "RECURRENT", Counter: 1}},
+			}},
+		},
+		results: map[string][]*wrappers.ScanResult{
+			"1": {
+				{Type: "sast", Severity: "HIGH", Status: "NEW", SimilarityID: "a", FirstFoundAt: firstScanDate.Format(time.RFC3339)},
+				{Type: "sast", Severity: "LOW", Status: "NEW", SimilarityID: "b", FirstFoundAt: firstScanDate.Format(time.RFC3339)},
+				{Type: "sca", Severity: "CRITICAL", Status: "NEW", SimilarityID: "c", State: "NOT_EXPLOITABLE"},
+			},
+			"2": {
+				{Type: "sast", Severity: "LOW", Status: "RECURRENT", SimilarityID: "b"},
+				{Type: "kics", Severity: "MEDIUM", Status: "NEW", SimilarityID: "d"},
+			},
+		},
+	}
+
+	trend, err := buildResultsTrend("MOCK", "main", scans, resultsWrapper)
+
+	assert.NilError(t, err)
+	assert.Equal(t, len(trend.Scans), 3)
+	assert.Equal(t, trend.Scans[0].TotalIssues, 2)
+	assert.Equal(t, trend.Scans[0].NewIssues, 2)
+	assert.Equal(t, trend.Scans[0].EnginesIssues["sast"].High, 1)
+	assert.Equal(t, trend.Scans[1].NewIssues, 1)
+	assert.Equal(t, trend.Scans[1].FixedIssues, 1)
+	assert.Equal(t, trend.Scans[1].EnginesIssues["kics"].Medium, 1)
+	assert.Equal(t, trend.Scans[2].NewIssues, 0)
+	assert.Equal(t, trend.Scans[2].FixedIssues, 0)
+	assert.Equal(t, trend.RemediatedIssues, 1)
+	assert.Equal(t, trend.MeanTimeToRemediateDays, float64(10))
+	assert.Equal(t, trend.MaxIssues, 2)
+	// Only the scans around the fixed issue are read
+	assert.DeepEqual(t, resultsWrapper.readScans, []string{"1", "2"})
+}
+
+func TestRunGetResultsByScanIdWithCodeSnippets(t *testing.T) {
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--report-format", "json,markdown,summaryHTML", "--snippets-source-dir", ".")
+
+	removeFile(t, fileName, printer.FormatJSON)
+	removeFile(t, fileName, "md")
+	removeFile(t, fileName, printer.FormatHTML)
+}
+
+func TestRunGetResultsSaveAndRenderBundle(t *testing.T) {
+	bundleFile := filepath.Join(t.TempDir(), "results.zip")
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--report-format", "json", "--save-bundle", bundleFile)
+	removeFile(t, fileName, printer.FormatJSON)
+
+	execCmdNilAssertion(t, "results", "show", "--from-bundle", bundleFile, "--report-format", "json,sarif,summaryHTML")
+
+	reportJSON, err := os.ReadFile(fmt.Sprintf("%s.%s", fileName, printer.FormatJSON))
+	assert.NilError(t, err)
+	var results wrappers.ScanResultsCollection
+	assert.NilError(t, json.Unmarshal(reportJSON, &results))
+	assert.Equal(t, results.ScanID, "MOCK")
+	assert.Assert(t, len(results.Results) > 0)
+	removeFile(t, fileName, printer.FormatJSON)
+	removeFile(t, fileName, printer.FormatSarif)
+	removeFile(t, fileName, printer.FormatHTML)
+}
+
+// offlineResultsWrapper fails on every call, the reports created from a bundle must not need the API
+type offlineResultsWrapper struct{}
+
+func (r *offlineResultsWrapper) GetAllResultsByScanID(_ map[string]string) (*wrappers.ScanResultsCollection, *wrappers.WebError, error) {
+	return nil, nil, errors.New("offline")
+}
+
+func (r *offlineResultsWrapper) GetResultsURL(_ string) (string, error) {
+	return "", errors.New("offline")
+}
+
+func (r *offlineResultsWrapper) GetScanSummariesByScanIDS(_ map[string]string) (*wrappers.ScanSummariesModel, *wrappers.WebError, error) {
+	return nil, nil, errors.New("offline")
+}
+
+func TestCreateScanReportFromBundleOffline(t *testing.T) {
+	clearFlags()
+	mock.HasScs = true
+	defer func() { mock.HasScs = false }()
+	mock.Flag = wrappers.FeatureFlagResponseModel{Name: wrappers.SCSEngineCLIEnabled, Status: true}
+	bundleFile := filepath.Join(t.TempDir(), "results.zip")
+	// The json report doesn't read the overviews, the bundle must still have them for the summaries
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--report-format", "json", "--save-bundle", bundleFile)
+	removeFile(t, fileName, printer.FormatJSON)
+
+	bundle, err := loadResultsBundle(bundleFile)
+	assert.NilError(t, err)
+	scan, policyResponseModel, err := bundle.scanAndPolicy()
+	assert.NilError(t, err)
+	err = CreateScanReport(
+		&bundleResultsWrapper{ResultsWrapper: &offlineResultsWrapper{}, bundle: bundle},
+		&bundleRisksOverviewWrapper{bundle: bundle},
+		&bundleScanOverviewWrapper{bundle: bundle},
+		&bundleExportWrapper{bundle: bundle},
+		policyResponseModel,
+		nil,
+		scan,
+		"summaryJSON,summaryHTML,sarif",
+		"", "", "",
+		fileName,
+		".",
+		"",
+		map[string]string{},
+		&bundleFeatureFlagsWrapper{bundle: bundle})
+	assert.NilError(t, err)
+
+	summaryJSON, err := os.ReadFile(fmt.Sprintf("%s.%s", fileName, printer.FormatJSON))
+	assert.NilError(t, err)
+	var summary wrappers.ResultSummary
+	assert.NilError(t, json.Unmarshal(summaryJSON, &summary))
+	assert.Equal(t, summary.BaseURI, "projects//scans?id=MOCK&branch=")
+	removeFile(t, fileName, printer.FormatJSON)
+	removeFile(t, fileName, printer.FormatSarif)
+	removeFile(t, fileName, printer.FormatHTML)
+}
+
+func TestRunGetResultsFromBundleWithoutOverviews(t *testing.T) {
+	bundleFile := filepath.Join(t.TempDir(), "results.zip")
+	bundle := newResultsBundle()
+	bundle.add(bundleScanFile, &wrappers.ScanResponseModel{ID: "MOCK", Status: "Completed", Engines: []string{"sast", "apisec"}})
+	assert.NilError(t, bundle.save(bundleFile))
+
+	err := execCmdNotNilAssertion(t, "results", "show", "--from-bundle", bundleFile, "--report-format", "summaryJSON")
+	assertError(t, err, "Failed reading results bundle: base-uri.json not found")
+
+	bundle.add(bundleBaseURIFile, "projects/overview")
+	assert.NilError(t, bundle.save(bundleFile))
+	err = execCmdNotNilAssertion(t, "results", "show", "--from-bundle", bundleFile, "--report-format", "summaryJSON")
+	assertError(t, err, "Failed listing results: Failed reading results bundle: risks-overview.json not found")
+
+	// The missing SCS overview and SCA export are read as empty ones
+	bundle.add(bundleScanFile, &wrappers.ScanResponseModel{ID: "MOCK", Status: "Completed", Engines: []string{"sast", "sca", "microengines"}})
+	assert.NilError(t, bundle.save(bundleFile))
+	execCmdNilAssertion(t, "results", "show", "--from-bundle", bundleFile, "--report-format", "summaryJSON")
+	removeFile(t, fileName, printer.FormatJSON)
+}
+
+func TestRunGetResultsFromBundleWithUnsupportedFormat(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "show", "--from-bundle", "results.zip", "--report-format", "pdf")
+	assertError(t, err, "Failed reading results bundle: report format pdf can't be created from a bundle")
+}
+
+func TestRunGetResultsFromBundleAndSaveBundle(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "show", "--from-bundle", "a.zip", "--save-bundle", "b.zip")
+	assertError(t, err, "Failed listing results: --from-bundle and --save-bundle can't be used together")
+}
+
+func TestRunGetResultsByScanIdAttackFlowFormats(t *testing.T) {
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--report-format", "dot,mermaid")
+
+	removeFile(t, fileName+"_attack_flow", printer.FormatDOT)
+	removeFile(t, fileName+"_attack_flow", printer.FormatMarkdown)
+}
+
+func TestRunGetResultsByScanIdComplianceFormats(t *testing.T) {
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--report-format", "complianceJSON,complianceHTML",
+		"--compliance-framework", "OWASP Top 10 2021")
+
+	removeFile(t, fileName+"_compliance", printer.FormatJSON)
+	removeFile(t, fileName+"_compliance", printer.FormatHTML)
+}
+
+func TestRunResultsFixPlan(t *testing.T) {
+	execCmdNilAssertion(t, "results", "fix-plan", "--scan-id", "MOCK", "--best-fix-locations", "--report-format", "json,markdown")
+
+	removeFile(t, "cx_fix_plan", printer.FormatJSON)
+	removeFile(t, "cx_fix_plan", printer.FormatMarkdown)
+}
+
+func TestRunResultsFixPlan_InvalidCoverage(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "fix-plan", "--scan-id", "MOCK", "--coverage", "101")
+	assertError(t, err, "--coverage should be between 1 and 100")
+}
+
+func TestRunGetResultsByScanIdNewOnly(t *testing.T) {
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--new-only", "--pr-base-branch", "main", "--report-format", "json")
+
+	removeFile(t, fileName, printer.FormatJSON)
+}
+
+func TestRunGetResultsByScanIdNewOnly_MissingBaseBranch(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--new-only")
+	assertError(t, err, "Failed listing results: --new-only requires --pr-base-branch")
+}
+
+func TestRunGetResultsByScanIdStreamResults(t *testing.T) {
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--stream-results", "--report-format", "json,sarif,csv,summaryJSON")
+
+	removeFile(t, fileName, printer.FormatJSON)
+	removeFile(t, fileName, printer.FormatSarif)
+	removeFile(t, fileName, printer.FormatCSV)
+}
+
+func TestRunGetResultsByScanIdStreamResults_UnsupportedFormat(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--stream-results", "--report-format", "json,summaryHTML")
+	assertError(t, err, "Failed listing results: report format summaryHTML can't be streamed, use one of json, sarif, csv, summaryConsole, summaryJSON")
+}
+
+func TestRunGetResultsByScanIdCSVFormat(t *testing.T) {
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--report-format", "csv")
+
+	removeFile(t, fileName, printer.FormatCSV)
+}
+
+func TestRunResultsSLA(t *testing.T) {
+	execCmdNilAssertion(t, "results", "sla", "--scan-id", "MOCK", "--sla", "critical=7,high=30", "--fail-on-breach",
+		"--report-format", "table,json,markdown")
+
+	removeFile(t, "cx_sla", printer.FormatJSON)
+	removeFile(t, "cx_sla", printer.FormatMarkdown)
+}
+
+func TestRunResultsSLA_InvalidSLA(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "sla", "--scan-id", "MOCK", "--sla", "critical=0")
+	assertError(t, err, "Invalid value for SLA critical. SLA should be greater or equal to 1 day")
+}
@@ -0,0 +1,258 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+const (
+	attackFlowTypeLabel = "_attack_flow"
+	attackFlowNodeIDFmt = "n%d"
+)
+
+// attackFlowGraph merges the data flows of the SAST results of a query into a single graph
+type attackFlowGraph struct {
+	Language string
+	Query    string
+	Results  int
+	Nodes    []*attackFlowNode
+	Edges    []*attackFlowEdge
+	BestFix  *attackFlowNode
+}
+
+type attackFlowNode struct {
+	ID      string
+	Key     string
+	Label   string
+	Results int
+	Source  bool
+	Sink    bool
+}
+
+type attackFlowEdge struct {
+	From    string
+	To      string
+	Results int
+}
+
+func (n *attackFlowNode) shared() bool {
+	return n.Results > 1
+}
+
+func buildAttackFlowGraphs(resultsModel *wrappers.ScanResultsCollection) []*attackFlowGraph {
+	sastResults := &wrappers.ScanResultsCollection{}
+	for _, result := range resultsModel.Results {
+		if strings.TrimSpace(result.Type) == commonParams.SastType && isExploitable(result.State) && len(result.ScanResultData.Nodes) > 0 {
+			sastResults.Results = append(sastResults.Results, result)
+		}
+	}
+	queriesByLanguage := GetQueries(sastResults, GetLanguages(sastResults))
+
+	var graphs []*attackFlowGraph
+	for _, language := range sortedKeys(queriesByLanguage) {
+		for _, query := range sortedKeys(queriesByLanguage[language]) {
+			queryResults := GetResultsForQuery(sastResults, language, query)
+			graphs = append(graphs, buildAttackFlowGraph(language, query, queryResults))
+		}
+	}
+	return graphs
+}
+
+func buildAttackFlowGraph(language, query string, queryResults []*wrappers.ScanResult) *attackFlowGraph {
+	graph := &attackFlowGraph{Language: language, Query: query, Results: len(queryResults)}
+	flows := buildFlows(queryResults)
+	nodesByKey := make(map[string]*attackFlowNode)
+	edgesByKey := make(map[string]*attackFlowEdge)
+	for _, result := range queryResults {
+		flow := flows[result.ID]
+		seenNodes := make(map[string]bool)
+		seenEdges := make(map[string]bool)
+		var previous *attackFlowNode
+		for i, key := range flow {
+			node, ok := nodesByKey[key]
+			if !ok {
+				resultNode := result.ScanResultData.Nodes[i]
+				node = &attackFlowNode{
+					ID:    fmt.Sprintf(attackFlowNodeIDFmt, len(graph.Nodes)+1),
+					Key:   key,
+					Label: fmt.Sprintf("%s\n%s:%d", resultNode.Name, resultNode.FileName, resultNode.Line),
+				}
+				nodesByKey[key] = node
+				graph.Nodes = append(graph.Nodes, node)
+			}
+			if !seenNodes[key] {
+				seenNodes[key] = true
+				node.Results++
+			}
+			if i == 0 {
+				node.Source = true
+			}
+			if i == len(flow)-1 {
+				node.Sink = true
+			}
+			if previous != nil && previous != node {
+				edgeKey := previous.ID + "->" + node.ID
+				edge, edgeExists := edgesByKey[edgeKey]
+				if !edgeExists {
+					edge = &attackFlowEdge{From: previous.ID, To: node.ID}
+					edgesByKey[edgeKey] = edge
+					graph.Edges = append(graph.Edges, edge)
+				}
+				if !seenEdges[edgeKey] {
+					seenEdges[edgeKey] = true
+					edge.Results++
+				}
+			}
+			previous = node
+		}
+	}
+	graph.BestFix = getBestFixNode(graph.Nodes)
+	return graph
+}
+
+// getBestFixNode returns the node shared by most results, the first one found on ties
+func getBestFixNode(nodes []*attackFlowNode) *attackFlowNode {
+	var bestFix *attackFlowNode
+	for _, node := range nodes {
+		if bestFix == nil || node.Results > bestFix.Results {
+			bestFix = node
+		}
+	}
+	return bestFix
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func exportAttackFlowDot(targetFile string, results *wrappers.ScanResultsCollection) error {
+	log.Println("Creating Attack Flow DOT Report: ", targetFile)
+	var dot strings.Builder
+	dot.WriteString("digraph attack_flow {\n\trankdir=LR;\n\tnode [shape=box, style=rounded, fontname=Helvetica];\n")
+	for i, graph := range buildAttackFlowGraphs(results) {
+		prefix := fmt.Sprintf("q%d_", i+1)
+		fmt.Fprintf(&dot, "\tsubgraph cluster_%d {\n", i+1)
+		fmt.Fprintf(&dot, "\t\tlabel=%s;\n", dotQuote(fmt.Sprintf("%s (%s) - %d results", graph.Query, graph.Language, graph.Results)))
+		for _, node := range graph.Nodes {
+			fmt.Fprintf(&dot, "\t\t%s%s [label=%s%s];\n", prefix, node.ID, dotQuote(attackFlowNodeLabel(graph, node)), dotNodeStyle(graph, node))
+		}
+		for _, edge := range graph.Edges {
+			fmt.Fprintf(&dot, "\t\t%s%s -> %s%s", prefix, edge.From, prefix, edge.To)
+			if edge.Results > 1 {
+				fmt.Fprintf(&dot, " [label=\"%d\", penwidth=2]", edge.Results)
+			}
+			dot.WriteString(";\n")
+		}
+		dot.WriteString("\t}\n")
+	}
+	dot.WriteString("}\n")
+	return writeAttackFlowFile(targetFile, dot.String())
+}
+
+func exportAttackFlowMermaid(targetFile string, results *wrappers.ScanResultsCollection) error {
+	log.Println("Creating Attack Flow Mermaid Report: ", targetFile)
+	var markdown strings.Builder
+	markdown.WriteString("# Attack Flows\n")
+	for _, graph := range buildAttackFlowGraphs(results) {
+		fmt.Fprintf(&markdown, "\n### %s (%s) - %d results\n", graph.Query, graph.Language, graph.Results)
+		if graph.BestFix != nil && graph.BestFix.shared() {
+			fmt.Fprintf(&markdown, "\nBest fix location `%s` is shared by %d results\n",
+				strings.ReplaceAll(graph.BestFix.Label, "\n", " "), graph.BestFix.Results)
+		}
+		markdown.WriteString("\n```mermaid\nflowchart LR\n")
+		markdown.WriteString("    classDef source fill:#e3f2fd,stroke:#1e88e5\n")
+		markdown.WriteString("    classDef sink fill:#ffebee,stroke:#e53935\n")
+		markdown.WriteString("    classDef shared stroke-width:3px\n")
+		markdown.WriteString("    classDef bestfix fill:#e8f5e9,stroke:#43a047,stroke-width:3px\n")
+		for _, node := range graph.Nodes {
+			fmt.Fprintf(&markdown, "    %s[\"%s\"]", node.ID, mermaidEscape(attackFlowNodeLabel(graph, node)))
+			if class := attackFlowNodeClass(graph, node); class != "" {
+				fmt.Fprintf(&markdown, ":::%s", class)
+			}
+			markdown.WriteString("\n")
+		}
+		for _, edge := range graph.Edges {
+			if edge.Results > 1 {
+				fmt.Fprintf(&markdown, "    %s ==>|%d| %s\n", edge.From, edge.Results, edge.To)
+			} else {
+				fmt.Fprintf(&markdown, "    %s --> %s\n", edge.From, edge.To)
+			}
+		}
+		markdown.WriteString("```\n")
+	}
+	return writeAttackFlowFile(targetFile, markdown.String())
+}
+
+func attackFlowNodeLabel(graph *attackFlowGraph, node *attackFlowNode) string {
+	label := node.Label
+	if node.shared() {
+		label += fmt.Sprintf("\n%d results", node.Results)
+	}
+	if node == graph.BestFix && node.shared() {
+		label += "\nbest fix location"
+	}
+	return label
+}
+
+func attackFlowNodeClass(graph *attackFlowGraph, node *attackFlowNode) string {
+	switch {
+	case node == graph.BestFix && node.shared():
+		return "bestfix"
+	case node.Source:
+		return "source"
+	case node.Sink:
+		return "sink"
+	case node.shared():
+		return "shared"
+	}
+	return ""
+}
+
+func dotNodeStyle(graph *attackFlowGraph, node *attackFlowNode) string {
+	switch attackFlowNodeClass(graph, node) {
+	case "bestfix":
+		return ", style=\"rounded,filled,bold\", fillcolor=\"#e8f5e9\", color=\"#43a047\""
+	case "source":
+		return ", style=\"rounded,filled\", fillcolor=\"#e3f2fd\", color=\"#1e88e5\""
+	case "sink":
+		return ", style=\"rounded,filled\", fillcolor=\"#ffebee\", color=\"#e53935\""
+	case "shared":
+		return ", style=\"rounded,bold\""
+	}
+	return ""
+}
+
+func dotQuote(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "\"", "\\\"")
+	return "\"" + strings.ReplaceAll(value, "\n", "\\n") + "\""
+}
+
+func mermaidEscape(value string) string {
+	value = strings.ReplaceAll(value, "\"", "#quot;")
+	value = strings.ReplaceAll(value, "<", "#lt;")
+	value = strings.ReplaceAll(value, ">", "#gt;")
+	return strings.ReplaceAll(value, "\n", "<br/>")
+}
+
+func writeAttackFlowFile(targetFile, content string) error {
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to create target file  ", failedGettingAll)
+	}
+	_, _ = fmt.Fprint(f, content)
+	_ = f.Close()
+	return nil
+}
@@ -0,0 +1,104 @@
+//go:build !integration
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func createAttackFlowNode(name string, line uint) *wrappers.ScanResultNode {
+	return &wrappers.ScanResultNode{Name: name, FileName: "/src/Main.java", Line: line, Column: 1}
+}
+
+func createAttackFlowTestResults() *wrappers.ScanResultsCollection {
+	sanitize := createAttackFlowNode("query", 20)
+	return &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			{
+				Type: "sast",
+				ID:   "1",
+				ScanResultData: wrappers.ScanResultData{
+					LanguageName: "Java",
+					QueryName:    "SQL_Injection",
+					Nodes:        []*wrappers.ScanResultNode{createAttackFlowNode("id", 10), sanitize, createAttackFlowNode("execute", 30)},
+				},
+			},
+			{
+				Type: "sast",
+				ID:   "2",
+				ScanResultData: wrappers.ScanResultData{
+					LanguageName: "Java",
+					QueryName:    "SQL_Injection",
+					Nodes:        []*wrappers.ScanResultNode{createAttackFlowNode("name", 11), sanitize, createAttackFlowNode("execute", 30)},
+				},
+			},
+			{
+				Type:  "sast",
+				ID:    "3",
+				State: "NOT_EXPLOITABLE",
+				ScanResultData: wrappers.ScanResultData{
+					LanguageName: "Java",
+					QueryName:    "SQL_Injection",
+					Nodes:        []*wrappers.ScanResultNode{createAttackFlowNode("other", 40)},
+				},
+			},
+			{
+				Type: "sast",
+				ID:   "4",
+				ScanResultData: wrappers.ScanResultData{
+					LanguageName: "Java",
+					QueryName:    "XSS",
+					Nodes:        []*wrappers.ScanResultNode{createAttackFlowNode("input", 50), createAttackFlowNode("write", 60)},
+				},
+			},
+			{
+				Type: "kics",
+				ID:   "5",
+			},
+		},
+	}
+}
+
+func TestBuildAttackFlowGraphs(t *testing.T) {
+	graphs := buildAttackFlowGraphs(createAttackFlowTestResults())
+
+	assert.Equal(t, len(graphs), 2)
+	sqlInjection := graphs[0]
+	assert.Equal(t, sqlInjection.Query, "SQL_Injection")
+	assert.Equal(t, sqlInjection.Results, 2)
+	assert.Equal(t, len(sqlInjection.Nodes), 4)
+	assert.Equal(t, len(sqlInjection.Edges), 3)
+	assert.Equal(t, sqlInjection.BestFix.Label, "query\n/src/Main.java:20")
+	assert.Equal(t, sqlInjection.BestFix.Results, 2)
+	assert.Assert(t, sqlInjection.Nodes[0].Source)
+	assert.Assert(t, sqlInjection.Nodes[2].Sink)
+	assert.Equal(t, sqlInjection.Edges[1].Results, 2)
+	assert.Equal(t, graphs[1].Query, "XSS")
+	assert.Assert(t, !graphs[1].BestFix.shared())
+}
+
+func TestExportAttackFlowDotAndMermaid(t *testing.T) {
+	targetPath := t.TempDir()
+	dotFile := filepath.Join(targetPath, "flow.dot")
+	mermaidFile := filepath.Join(targetPath, "flow.md")
+
+	assert.NilError(t, exportAttackFlowDot(dotFile, createAttackFlowTestResults()))
+	assert.NilError(t, exportAttackFlowMermaid(mermaidFile, createAttackFlowTestResults()))
+
+	dot, err := os.ReadFile(dotFile)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(string(dot), "digraph attack_flow {"))
+	assert.Assert(t, strings.Contains(string(dot), `q1_n2 [label="query\n/src/Main.java:20\n2 results\nbest fix location"`))
+	assert.Assert(t, strings.Contains(string(dot), `q1_n2 -> q1_n3 [label="2", penwidth=2];`))
+	mermaid, err := os.ReadFile(mermaidFile)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(mermaid), "### SQL_Injection (Java) - 2 results"))
+	assert.Assert(t, strings.Contains(string(mermaid), `n2["query<br/>/src/Main.java:20<br/>2 results<br/>best fix location"]:::bestfix`))
+	assert.Assert(t, strings.Contains(string(mermaid), "n2 ==>|2| n3"))
+}
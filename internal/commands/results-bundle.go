@@ -0,0 +1,352 @@
+package commands
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+const (
+	bundleScanFile          = "scan.json"
+	bundleResultsFile       = "results.json"
+	bundleScaExportFile     = "sca-export.json"
+	bundlePolicyFile        = "policy.json"
+	bundleRisksOverviewFile = "risks-overview.json"
+	bundleScsOverviewFile   = "scs-overview.json"
+	bundleFeatureFlagsFile  = "feature-flags.json"
+	bundleBaseURIFile       = "base-uri.json"
+	bundleExportID          = "bundle"
+	bundleExportCompleted   = "Completed"
+	failedReadingBundle     = "Failed reading results bundle"
+	failedSavingBundle      = "Failed saving results bundle"
+	maxBundleFileSize       = 1024 * 1024 * 1024
+)
+
+// bundleFormatsNotSupported need the API to render and can't be created from a bundle
+var bundleFormatsNotSupported = []string{printer.FormatPDF, printer.FormatSbom}
+
+// bundleFeatureFlags are the feature flags read while rendering the reports
+var bundleFeatureFlags = []string{
+	wrappers.SCSEngineCLIEnabled,
+	wrappers.ContainerEngineCLIEnabled,
+	wrappers.CVSSV3Enabled,
+	wrappers.NewScanReportEnabled,
+}
+
+// resultsBundle holds the API responses needed to render the results reports of a scan, one json file per response
+type resultsBundle struct {
+	files map[string][]byte
+}
+
+func newResultsBundle() *resultsBundle {
+	return &resultsBundle{files: make(map[string][]byte)}
+}
+
+func (b *resultsBundle) add(name string, value interface{}) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Failed adding %s to the results bundle: %v", name, err)
+		return
+	}
+	b.files[name] = content
+}
+
+// get decodes a bundle file into value, returning false when the bundle doesn't have it
+func (b *resultsBundle) get(name string, value interface{}) (bool, error) {
+	content, ok := b.files[name]
+	if !ok {
+		return false, nil
+	}
+	err := json.Unmarshal(content, value)
+	if err != nil {
+		return false, errors.Wrapf(err, "%s: invalid %s", failedReadingBundle, name)
+	}
+	return true, nil
+}
+
+// getRequired decodes a bundle file into value, failing when the bundle doesn't have it
+func (b *resultsBundle) getRequired(name string, value interface{}) error {
+	found, err := b.get(name, value)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.Errorf("%s: %s not found", failedReadingBundle, name)
+	}
+	return nil
+}
+
+func (b *resultsBundle) save(targetFile string) error {
+	log.Println("Creating Results Bundle: ", targetFile)
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedSavingBundle)
+	}
+	defer f.Close()
+	zipWriter := zip.NewWriter(f)
+	for name, content := range b.files {
+		fileWriter, createErr := zipWriter.Create(name)
+		if createErr != nil {
+			return errors.Wrapf(createErr, "%s", failedSavingBundle)
+		}
+		_, createErr = fileWriter.Write(content)
+		if createErr != nil {
+			return errors.Wrapf(createErr, "%s", failedSavingBundle)
+		}
+	}
+	return errors.Wrapf(zipWriter.Close(), "%s", failedSavingBundle)
+}
+
+func loadResultsBundle(bundleFile string) (*resultsBundle, error) {
+	zipReader, err := zip.OpenReader(bundleFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedReadingBundle)
+	}
+	defer zipReader.Close()
+	bundle := newResultsBundle()
+	for _, file := range zipReader.File {
+		if file.UncompressedSize64 > maxBundleFileSize {
+			return nil, errors.Errorf("%s: %s is too large", failedReadingBundle, file.Name)
+		}
+		reader, openErr := file.Open()
+		if openErr != nil {
+			return nil, errors.Wrapf(openErr, "%s", failedReadingBundle)
+		}
+		content, readErr := io.ReadAll(io.LimitReader(reader, maxBundleFileSize))
+		_ = reader.Close()
+		if readErr != nil {
+			return nil, errors.Wrapf(readErr, "%s", failedReadingBundle)
+		}
+		bundle.files[file.Name] = content
+	}
+	return bundle, nil
+}
+
+// scanAndPolicy returns the scan of the bundle and its policy evaluation, empty when it wasn't saved
+func (b *resultsBundle) scanAndPolicy() (*wrappers.ScanResponseModel, *wrappers.PolicyResponseModel, error) {
+	scan := &wrappers.ScanResponseModel{}
+	found, err := b.get(bundleScanFile, scan)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, errors.Errorf("%s: %s not found", failedReadingBundle, bundleScanFile)
+	}
+	policy := &wrappers.PolicyResponseModel{}
+	_, err = b.get(bundlePolicyFile, policy)
+	if err != nil {
+		return nil, nil, err
+	}
+	return scan, policy, nil
+}
+
+func (b *resultsBundle) addFeatureFlags(featureFlagsWrapper wrappers.FeatureFlagsWrapper) {
+	featureFlags := make(map[string]bool)
+	for _, flagName := range bundleFeatureFlags {
+		flag, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, flagName)
+		featureFlags[flagName] = flag.Status
+	}
+	b.add(bundleFeatureFlagsFile, featureFlags)
+}
+
+// addScanParts adds the parts of the scan read by the reports, whatever report formats are created while saving,
+// so any supported format can be created from the bundle later
+func (b *resultsBundle) addScanParts(
+	resultsWrapper wrappers.ResultsWrapper,
+	risksOverviewWrapper wrappers.RisksOverviewWrapper,
+	scsScanOverviewWrapper wrappers.ScanOverviewWrapper,
+	exportWrapper wrappers.ExportWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	scan *wrappers.ScanResponseModel,
+) error {
+	baseURI, err := resultsWrapper.GetResultsURL(scan.ProjectID)
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedSavingBundle)
+	}
+	b.add(bundleBaseURIFile, baseURI)
+
+	risksOverview := &wrappers.APISecResult{}
+	if slices.Contains(scan.Engines, commonParams.APISecType) {
+		apiSecRisks, risksErr := getResultsForAPISecScanner(risksOverviewWrapper, scan.ID)
+		if risksErr != nil {
+			return risksErr
+		}
+		if apiSecRisks != nil {
+			risksOverview = apiSecRisks
+		}
+	}
+	b.add(bundleRisksOverviewFile, risksOverview)
+
+	scsOverview := &wrappers.SCSOverview{}
+	scsEnabled, _ := wrappers.GetSpecificFeatureFlag(featureFlagsWrapper, wrappers.SCSEngineCLIEnabled)
+	if scsEnabled.Status && slices.Contains(scan.Engines, commonParams.ScsType) {
+		overview, overviewErr := getScanOverviewForSCSScanner(scsScanOverviewWrapper, scan.ID)
+		if overviewErr != nil {
+			return overviewErr
+		}
+		if overview != nil {
+			scsOverview = overview
+		}
+	}
+	b.add(bundleScsOverviewFile, scsOverview)
+
+	scaExport := &wrappers.ScaPackageCollectionExport{
+		Packages: []wrappers.ScaPackage{},
+		ScaTypes: []wrappers.ScaType{},
+	}
+	if slices.Contains(scan.Engines, commonParams.ScaType) {
+		export, exportErr := services.GetExportPackage(exportWrapper, scan.ID)
+		if exportErr != nil {
+			return errors.Wrapf(exportErr, "%s", failedSavingBundle)
+		}
+		if export != nil {
+			scaExport = export
+		}
+	}
+	b.add(bundleScaExportFile, scaExport)
+	return nil
+}
+
+func validateBundleFormats(reportFormats string) error {
+	for _, reportFormat := range strings.Split(reportFormats, ",") {
+		for _, notSupported := range bundleFormatsNotSupported {
+			if printer.IsFormat(strings.TrimSpace(reportFormat), notSupported) {
+				return errors.Errorf("%s: report format %s can't be created from a bundle", failedReadingBundle, notSupported)
+			}
+		}
+	}
+	return nil
+}
+
+// recordingResultsWrapper adds the results to the bundle while the reports are created, with the filters of the command
+type recordingResultsWrapper struct {
+	wrappers.ResultsWrapper
+	bundle *resultsBundle
+}
+
+func (r *recordingResultsWrapper) GetAllResultsByScanID(params map[string]string) (*wrappers.ScanResultsCollection, *wrappers.WebError, error) {
+	results, webError, err := r.ResultsWrapper.GetAllResultsByScanID(params)
+	if err == nil && webError == nil && results != nil {
+		r.bundle.add(bundleResultsFile, results)
+	}
+	return results, webError, err
+}
+
+// Bundle wrappers answer from the bundle instead of the API, a missing SCS overview or SCA export is read as an empty one
+
+type bundleResultsWrapper struct {
+	wrappers.ResultsWrapper
+	bundle *resultsBundle
+}
+
+func (r *bundleResultsWrapper) GetAllResultsByScanID(_ map[string]string) (*wrappers.ScanResultsCollection, *wrappers.WebError, error) {
+	results := &wrappers.ScanResultsCollection{}
+	_, err := r.bundle.get(bundleResultsFile, results)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, nil, nil
+}
+
+func (r *bundleResultsWrapper) GetResultsURL(_ string) (string, error) {
+	var baseURI string
+	err := r.bundle.getRequired(bundleBaseURIFile, &baseURI)
+	return baseURI, err
+}
+
+type bundleExportWrapper struct {
+	bundle *resultsBundle
+}
+
+func (r *bundleExportWrapper) InitiateExportRequest(_ *wrappers.ExportRequestPayload) (*wrappers.ExportResponse, error) {
+	return &wrappers.ExportResponse{ExportID: bundleExportID}, nil
+}
+
+func (r *bundleExportWrapper) GetExportReportStatus(_ string) (*wrappers.ExportPollingResponse, error) {
+	return &wrappers.ExportPollingResponse{ExportID: bundleExportID, ExportStatus: bundleExportCompleted, FileURL: bundleScaExportFile}, nil
+}
+
+func (r *bundleExportWrapper) DownloadExportReport(_, _ string) error {
+	return errors.Errorf("%s: exports can't be downloaded from a bundle", failedReadingBundle)
+}
+
+func (r *bundleExportWrapper) GetScaPackageCollectionExport(_ string) (*wrappers.ScaPackageCollectionExport, error) {
+	scaExport := &wrappers.ScaPackageCollectionExport{
+		Packages: []wrappers.ScaPackage{},
+		ScaTypes: []wrappers.ScaType{},
+	}
+	_, err := r.bundle.get(bundleScaExportFile, scaExport)
+	if err != nil {
+		return nil, err
+	}
+	return scaExport, nil
+}
+
+type bundleRisksOverviewWrapper struct {
+	bundle *resultsBundle
+}
+
+func (r *bundleRisksOverviewWrapper) GetAllAPISecRisksByScanID(_ string) (*wrappers.APISecResult, *wrappers.WebError, error) {
+	risksOverview := &wrappers.APISecResult{}
+	err := r.bundle.getRequired(bundleRisksOverviewFile, risksOverview)
+	if err != nil {
+		return nil, nil, err
+	}
+	return risksOverview, nil, nil
+}
+
+type bundleScanOverviewWrapper struct {
+	bundle *resultsBundle
+}
+
+func (r *bundleScanOverviewWrapper) GetSCSOverviewByScanID(_ string) (*wrappers.SCSOverview, *wrappers.WebError, error) {
+	scsOverview := &wrappers.SCSOverview{}
+	_, err := r.bundle.get(bundleScsOverviewFile, scsOverview)
+	if err != nil {
+		return nil, nil, err
+	}
+	return scsOverview, nil, nil
+}
+
+type bundleFeatureFlagsWrapper struct {
+	bundle *resultsBundle
+}
+
+func (r *bundleFeatureFlagsWrapper) featureFlags() (map[string]bool, error) {
+	featureFlags := make(map[string]bool)
+	_, err := r.bundle.get(bundleFeatureFlagsFile, &featureFlags)
+	return featureFlags, err
+}
+
+func (r *bundleFeatureFlagsWrapper) GetAll() (*wrappers.FeatureFlagsResponseModel, error) {
+	featureFlags, err := r.featureFlags()
+	if err != nil {
+		return nil, err
+	}
+	allFlags := wrappers.FeatureFlagsResponseModel{}
+	for name, status := range featureFlags {
+		allFlags = append(allFlags, struct {
+			Name   string `json:"name"`
+			Status bool   `json:"status"`
+		}{Name: name, Status: status})
+	}
+	return &allFlags, nil
+}
+
+func (r *bundleFeatureFlagsWrapper) GetSpecificFlag(specificFlag string) (*wrappers.FeatureFlagResponseModel, error) {
+	featureFlags, err := r.featureFlags()
+	if err != nil {
+		return nil, err
+	}
+	return &wrappers.FeatureFlagResponseModel{Name: specificFlag, Status: featureFlags[specificFlag]}, nil
+}
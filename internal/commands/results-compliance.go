@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	compliancePassStatus    = "PASS"
+	complianceFailStatus    = "FAIL"
+	complianceOtherCategory = "Other"
+	owaspTop10Framework     = "OWASP Top 10 2021"
+	cweTop25Framework       = "CWE top 25"
+	complianceTypeLabel     = "_compliance"
+
+	complianceFrameworkFlagDescription = "Only report this framework in the compliance reports, ex: \"OWASP Top 10 2021\""
+)
+
+// complianceCategory is a category of a framework with a catalog, mapped from the CWE of the findings
+type complianceCategory struct {
+	name string
+	cwes []int
+}
+
+// complianceCatalogs lists the categories of the frameworks that can be mapped from CWEs, so passing categories are reported too
+var complianceCatalogs = map[string][]complianceCategory{
+	owaspTop10Framework: {
+		{"A01:2021 Broken Access Control", []int{
+			22, 23, 35, 59, 200, 201, 219, 264, 275, 276, 284, 285, 352, 359, 377, 402, 425, 441, 497, 538, 540, 548,
+			552, 566, 601, 639, 651, 668, 706, 862, 863, 913, 922, 1275,
+		}},
+		{"A02:2021 Cryptographic Failures", []int{
+			261, 296, 310, 319, 321, 322, 323, 324, 325, 326, 327, 328, 329, 330, 331, 335, 336, 337, 338, 340, 347, 523,
+			720, 757, 759, 760, 780, 818, 916,
+		}},
+		{"A03:2021 Injection", []int{
+			20, 74, 75, 77, 78, 79, 80, 83, 87, 88, 89, 90, 91, 93, 94, 95, 96, 97, 98, 99, 100, 113, 116, 138, 184, 470,
+			471, 564, 610, 643, 644, 652, 917,
+		}},
+		{"A04:2021 Insecure Design", []int{
+			73, 183, 209, 213, 235, 256, 257, 266, 269, 280, 311, 312, 313, 316, 419, 430, 434, 444, 451, 472, 501, 522,
+			525, 539, 579, 598, 602, 642, 646, 650, 653, 656, 657, 799, 807, 840, 841, 927, 1021, 1173,
+		}},
+		{"A05:2021 Security Misconfiguration", []int{
+			2, 11, 13, 15, 16, 260, 315, 520, 526, 537, 541, 547, 611, 614, 756, 776, 942, 1004, 1032, 1174,
+		}},
+		{"A06:2021 Vulnerable and Outdated Components", []int{937, 1035, 1104}},
+		{"A07:2021 Identification and Authentication Failures", []int{
+			255, 259, 287, 288, 290, 294, 295, 297, 300, 302, 304, 306, 307, 346, 384, 521, 613, 620, 640, 798, 940, 1216,
+		}},
+		{"A08:2021 Software and Data Integrity Failures", []int{345, 353, 426, 494, 502, 565, 784, 829, 830, 915}},
+		{"A09:2021 Security Logging and Monitoring Failures", []int{117, 223, 532, 778}},
+		{"A10:2021 Server-Side Request Forgery", []int{918}},
+	},
+	cweTop25Framework: cweTop25Categories(
+		787, 79, 89, 416, 78, 20, 125, 22, 352, 434, 862, 476, 287, 190, 502, 77, 119, 798, 918, 306, 362, 269, 94, 863, 276,
+	),
+}
+
+func cweTop25Categories(cwes ...int) []complianceCategory {
+	categories := make([]complianceCategory, 0, len(cwes))
+	for _, cwe := range cwes {
+		categories = append(categories, complianceCategory{name: fmt.Sprintf("CWE-%d", cwe), cwes: []int{cwe}})
+	}
+	return categories
+}
+
+func addComplianceFrameworkFilter(cmd *cobra.Command, params map[string]string) {
+	complianceFramework, _ := cmd.Flags().GetString(commonParams.ComplianceFrameworkFlag)
+	if complianceFramework != "" {
+		params[commonParams.ComplianceFrameworkFlag] = complianceFramework
+	}
+}
+
+// buildComplianceReport groups the findings by the compliances of their vulnerability details.
+// Frameworks with a catalog are grouped by their categories, the others by query.
+func buildComplianceReport(
+	results *wrappers.ScanResultsCollection,
+	summary *wrappers.ResultSummary,
+	complianceFramework string,
+) *wrappers.ComplianceReport {
+	report := &wrappers.ComplianceReport{
+		ScanID:      summary.ScanID,
+		ProjectName: summary.ProjectName,
+		BranchName:  summary.BranchName,
+		CreatedAt:   time.Now().Format(summaryCreatedAtLayout),
+	}
+	frameworks := make(map[string]*wrappers.ComplianceFramework)
+	categories := make(map[string]map[string]*wrappers.ComplianceCategory)
+	getFramework := func(name string) *wrappers.ComplianceFramework {
+		framework, ok := frameworks[name]
+		if !ok {
+			framework = &wrappers.ComplianceFramework{Name: name, Status: compliancePassStatus}
+			frameworks[name] = framework
+			categories[name] = make(map[string]*wrappers.ComplianceCategory)
+			for _, catalogCategory := range complianceCatalogs[name] {
+				categories[name][catalogCategory.name] = &wrappers.ComplianceCategory{Name: catalogCategory.name, Status: compliancePassStatus}
+			}
+		}
+		return framework
+	}
+	for name := range complianceCatalogs {
+		if complianceFramework == "" || strings.EqualFold(name, complianceFramework) {
+			getFramework(name)
+		}
+	}
+
+	if results != nil {
+		for _, result := range results.Results {
+			if !isExploitable(result.State) {
+				continue
+			}
+			for _, compliance := range result.VulnerabilityDetails.Compliances {
+				if compliance == nil || *compliance == "" {
+					continue
+				}
+				if complianceFramework != "" && !strings.EqualFold(*compliance, complianceFramework) {
+					continue
+				}
+				framework := getFramework(*compliance)
+				categoryName := getComplianceCategory(framework.Name, result)
+				category, ok := categories[framework.Name][categoryName]
+				if !ok {
+					category = &wrappers.ComplianceCategory{Name: categoryName}
+					categories[framework.Name][categoryName] = category
+				}
+				countComplianceResult(framework, category, result)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(frameworks) {
+		framework := frameworks[name]
+		for _, categoryName := range sortedKeys(categories[name]) {
+			framework.Categories = append(framework.Categories, categories[name][categoryName])
+		}
+		report.Frameworks = append(report.Frameworks, framework)
+	}
+	return report
+}
+
+func getComplianceCategory(framework string, result *wrappers.ScanResult) string {
+	catalog, hasCatalog := complianceCatalogs[framework]
+	if !hasCatalog {
+		return result.ScanResultData.QueryName
+	}
+	cwe, ok := parseCweID(result.VulnerabilityDetails.CweID)
+	if ok {
+		for _, category := range catalog {
+			for _, categoryCwe := range category.cwes {
+				if categoryCwe == cwe {
+					return category.name
+				}
+			}
+		}
+	}
+	return complianceOtherCategory
+}
+
+// parseCweID reads the CWE of a result, sent as a number or as a "CWE-<number>" string
+func parseCweID(cweID interface{}) (int, bool) {
+	switch value := cweID.(type) {
+	case float64:
+		return int(value), true
+	case int:
+		return value, true
+	case string:
+		cwe, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "CWE-"))
+		return cwe, err == nil
+	}
+	return 0, false
+}
+
+func countComplianceResult(framework *wrappers.ComplianceFramework, category *wrappers.ComplianceCategory, result *wrappers.ScanResult) {
+	framework.Status = complianceFailStatus
+	framework.TotalIssues++
+	category.Status = complianceFailStatus
+	category.TotalIssues++
+	switch strings.ToLower(result.Severity) {
+	case criticalLabel:
+		category.CriticalIssues++
+	case highLabel:
+		category.HighIssues++
+	case mediumLabel:
+		category.MediumIssues++
+	case lowLabel:
+		category.LowIssues++
+	case infoLabel:
+		category.InfoIssues++
+	}
+	queryName := result.ScanResultData.QueryName
+	if queryName != "" && !slices.Contains(category.Queries, queryName) {
+		category.Queries = append(category.Queries, queryName)
+		sort.Strings(category.Queries)
+	}
+}
+
+func writeComplianceHTML(targetFile string, report *wrappers.ComplianceReport) error {
+	log.Println("Creating Compliance Report: ", targetFile)
+	complianceTemplate, err := template.New("complianceTemplate").Parse(wrappers.ComplianceTemplate)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return complianceTemplate.ExecuteTemplate(f, "ComplianceTemplate", report)
+}
@@ -0,0 +1,114 @@
+//go:build !integration
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func createComplianceResult(query, severity string, cweID interface{}, compliances ...string) *wrappers.ScanResult {
+	result := &wrappers.ScanResult{
+		Type:           "sast",
+		Severity:       severity,
+		State:          "TO_VERIFY",
+		ScanResultData: wrappers.ScanResultData{QueryName: query},
+	}
+	result.VulnerabilityDetails.CweID = cweID
+	for i := range compliances {
+		result.VulnerabilityDetails.Compliances = append(result.VulnerabilityDetails.Compliances, &compliances[i])
+	}
+	return result
+}
+
+func createComplianceTestResults() *wrappers.ScanResultsCollection {
+	return &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			createComplianceResult("SQL_Injection", "HIGH", float64(89), owaspTop10Framework, cweTop25Framework, "PCI DSS v3.2.1"),
+			createComplianceResult("Stored_XSS", "MEDIUM", "CWE-79", owaspTop10Framework, cweTop25Framework),
+			createComplianceResult("Unknown_CWE", "LOW", 1234, owaspTop10Framework),
+			{
+				Type:           "sast",
+				Severity:       "HIGH",
+				State:          "NOT_EXPLOITABLE",
+				ScanResultData: wrappers.ScanResultData{QueryName: "Ignored"},
+				VulnerabilityDetails: wrappers.VulnerabilityDetails{
+					CweID:       float64(22),
+					Compliances: []*string{nil},
+				},
+			},
+		},
+	}
+}
+
+func findComplianceFramework(report *wrappers.ComplianceReport, name string) *wrappers.ComplianceFramework {
+	for _, framework := range report.Frameworks {
+		if framework.Name == name {
+			return framework
+		}
+	}
+	return nil
+}
+
+func findComplianceCategory(framework *wrappers.ComplianceFramework, name string) *wrappers.ComplianceCategory {
+	for _, category := range framework.Categories {
+		if category.Name == name {
+			return category
+		}
+	}
+	return nil
+}
+
+func TestBuildComplianceReport(t *testing.T) {
+	summary := &wrappers.ResultSummary{ScanID: "MOCK", ProjectName: "project", BranchName: "main"}
+	report := buildComplianceReport(createComplianceTestResults(), summary, "")
+	assert.Equal(t, report.ScanID, "MOCK")
+	assert.Equal(t, len(report.Frameworks), 3)
+
+	owasp := findComplianceFramework(report, owaspTop10Framework)
+	assert.Assert(t, owasp != nil)
+	assert.Equal(t, owasp.Status, complianceFailStatus)
+	assert.Equal(t, owasp.TotalIssues, 3)
+	assert.Equal(t, len(owasp.Categories), 11)
+	injection := findComplianceCategory(owasp, "A03:2021 Injection")
+	assert.Equal(t, injection.Status, complianceFailStatus)
+	assert.Equal(t, injection.TotalIssues, 2)
+	assert.Equal(t, injection.HighIssues, 1)
+	assert.Equal(t, injection.MediumIssues, 1)
+	assert.DeepEqual(t, injection.Queries, []string{"SQL_Injection", "Stored_XSS"})
+	assert.Equal(t, findComplianceCategory(owasp, "A01:2021 Broken Access Control").Status, compliancePassStatus)
+	assert.Equal(t, findComplianceCategory(owasp, complianceOtherCategory).LowIssues, 1)
+
+	cwe := findComplianceFramework(report, cweTop25Framework)
+	assert.Equal(t, len(cwe.Categories), 25)
+	assert.Equal(t, findComplianceCategory(cwe, "CWE-89").TotalIssues, 1)
+	assert.Equal(t, findComplianceCategory(cwe, "CWE-22").Status, compliancePassStatus)
+
+	pci := findComplianceFramework(report, "PCI DSS v3.2.1")
+	assert.Equal(t, len(pci.Categories), 1)
+	assert.Equal(t, pci.Categories[0].Name, "SQL_Injection")
+}
+
+func TestBuildComplianceReport_Framework(t *testing.T) {
+	report := buildComplianceReport(createComplianceTestResults(), &wrappers.ResultSummary{}, "cwe TOP 25")
+	assert.Equal(t, len(report.Frameworks), 1)
+	assert.Equal(t, report.Frameworks[0].Name, cweTop25Framework)
+	assert.Equal(t, report.Frameworks[0].TotalIssues, 2)
+
+	report = buildComplianceReport(nil, &wrappers.ResultSummary{}, "HIPAA")
+	assert.Equal(t, len(report.Frameworks), 0)
+}
+
+func TestParseCweID(t *testing.T) {
+	for _, cweID := range []interface{}{float64(79), 79, "79", "CWE-79", " cwe-79"} {
+		cwe, ok := parseCweID(cweID)
+		assert.Assert(t, ok)
+		assert.Equal(t, cwe, 79)
+	}
+	_, ok := parseCweID(nil)
+	assert.Assert(t, !ok)
+	_, ok = parseCweID("unknown")
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,265 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedCreatingFixPlan  = "Failed creating fix plan"
+	fixPlanDefaultName     = "cx_fix_plan"
+	fixPlanDefaultCoverage = 80
+	fixPlanMaxCoverage     = 100
+	percentage             = 100
+)
+
+func resultFixPlanSubCommand(resultsWrapper wrappers.ResultsWrapper, bflWrapper wrappers.BflWrapper) *cobra.Command {
+	fixPlanCmd := &cobra.Command{
+		Use:   "fix-plan",
+		Short: "Rank the code locations that eliminate most SAST findings",
+		Long: "The fix-plan command looks at the data flows of all the SAST queries of a scan and ranks the code locations " +
+			"shared by most flows, until fixing them eliminates the target coverage of the findings.",
+		Example: heredoc.Doc(
+			`
+			$ cx results fix-plan --scan-id <scan Id> --coverage 80 --report-format json,markdown
+			$ cx results fix-plan --scan-id <scan Id> --best-fix-locations
+		`,
+		),
+		RunE: runResultsFixPlanCommand(resultsWrapper, bflWrapper),
+	}
+	addScanIDFlag(fixPlanCmd, "ID to report on.")
+	markFlagAsRequired(fixPlanCmd, commonParams.ScanIDFlag)
+	fixPlanCmd.PersistentFlags().Int(commonParams.CoverageFlag, fixPlanDefaultCoverage, "Percentage of the SAST findings the plan should eliminate")
+	fixPlanCmd.PersistentFlags().Bool(commonParams.BestFixLocationsFlag, false, "Prefer the best fix locations reported by Checkmarx One")
+	fixPlanCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterResultsListFlagUsage)
+	fixPlanCmd.PersistentFlags().String(commonParams.WhereFlag, "", commonParams.WhereFlagUsage)
+	addResultFormatFlag(fixPlanCmd, printer.FormatJSON, printer.FormatSummaryMarkdown)
+	fixPlanCmd.PersistentFlags().String(commonParams.TargetFlag, fixPlanDefaultName, "Output file")
+	fixPlanCmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
+	return fixPlanCmd
+}
+
+func runResultsFixPlanCommand(resultsWrapper wrappers.ResultsWrapper, bflWrapper wrappers.BflWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scanID, _ := cmd.Flags().GetString(commonParams.ScanIDFlag)
+		coverage, _ := cmd.Flags().GetInt(commonParams.CoverageFlag)
+		useBestFixLocations, _ := cmd.Flags().GetBool(commonParams.BestFixLocationsFlag)
+		targetFile, _ := cmd.Flags().GetString(commonParams.TargetFlag)
+		targetPath, _ := cmd.Flags().GetString(commonParams.TargetPathFlag)
+		reportFormats, _ := cmd.Flags().GetString(commonParams.TargetFormatFlag)
+		if coverage <= 0 || coverage > fixPlanMaxCoverage {
+			return errors.Errorf("--%s should be between 1 and %d", commonParams.CoverageFlag, fixPlanMaxCoverage)
+		}
+
+		params, err := getFilters(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingFixPlan)
+		}
+		err = addWhereFilter(cmd, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingFixPlan)
+		}
+		whereExpression, hasWhere := params[commonParams.WhereFlag]
+		delete(params, commonParams.WhereFlag)
+		params[commonParams.ScanIDQueryParam] = scanID
+		resultsModel, errorModel, err := resultsWrapper.GetAllResultsByScanID(params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedListingResults)
+		}
+		if errorModel != nil {
+			return errors.Errorf("%s: CODE: %d, %s", failedListingResults, errorModel.Code, errorModel.Message)
+		}
+		if resultsModel == nil {
+			resultsModel = &wrappers.ScanResultsCollection{}
+		}
+		if hasWhere {
+			resultsModel, err = FilterResultsByWhereExpression(resultsModel, whereExpression)
+			if err != nil {
+				return err
+			}
+		}
+
+		var bestFixLocations map[string]bool
+		if useBestFixLocations {
+			bestFixLocations, err = getBestFixLocations(bflWrapper, scanID, resultsModel)
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedCreatingFixPlan)
+			}
+		}
+		fixPlan := buildFixPlan(resultsModel, bestFixLocations, float64(coverage))
+		fixPlan.ScanID = scanID
+
+		err = createDirectory(targetPath)
+		if err != nil {
+			return err
+		}
+		for _, reportFormat := range strings.Split(reportFormats, ",") {
+			err = createFixPlanReport(strings.TrimSpace(reportFormat), targetFile, targetPath, fixPlan)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// getBestFixLocations returns the keys of the best fix location nodes of every SAST query of the results
+func getBestFixLocations(bflWrapper wrappers.BflWrapper, scanID string, resultsModel *wrappers.ScanResultsCollection) (map[string]bool, error) {
+	queryIDs := make(map[string]bool)
+	for _, result := range getFixPlanResults(resultsModel) {
+		if result.ScanResultData.QueryID != nil {
+			queryIDs[queryIDString(result.ScanResultData.QueryID)] = true
+		}
+	}
+	bestFixLocations := make(map[string]bool)
+	for _, queryID := range sortedKeys(queryIDs) {
+		bflResponseModel, errorModel, err := bflWrapper.GetBflByScanIDAndQueryID(map[string]string{
+			commonParams.ScanIDQueryParam:  scanID,
+			commonParams.QueryIDQueryParam: queryID,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", failedGettingBfl)
+		}
+		if errorModel != nil {
+			return nil, errors.Errorf("%s: CODE: %d, %s", failedGettingBfl, errorModel.Code, errorModel.Message)
+		}
+		if bflResponseModel == nil {
+			continue
+		}
+		for _, tree := range bflResponseModel.Trees {
+			if tree.BFL != nil {
+				bestFixLocations[fixPlanNodeKey(tree.BFL)] = true
+			}
+		}
+	}
+	logger.PrintfIfVerbose("Found %d best fix locations for %d queries", len(bestFixLocations), len(queryIDs))
+	return bestFixLocations, nil
+}
+
+func queryIDString(queryID interface{}) string {
+	if value, ok := queryID.(float64); ok {
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	}
+	return fmt.Sprint(queryID)
+}
+
+func getFixPlanResults(resultsModel *wrappers.ScanResultsCollection) []*wrappers.ScanResult {
+	var sastResults []*wrappers.ScanResult
+	for _, result := range resultsModel.Results {
+		if strings.TrimSpace(result.Type) == commonParams.SastType && isExploitable(result.State) && len(result.ScanResultData.Nodes) > 0 {
+			sastResults = append(sastResults, result)
+		}
+	}
+	return sastResults
+}
+
+func fixPlanNodeKey(node *wrappers.ScanResultNode) string {
+	return fmt.Sprintf("%s:%d:%d", node.FileName, node.Line, node.Column)
+}
+
+type fixPlanCandidate struct {
+	node    *wrappers.ScanResultNode
+	results map[int]bool
+	queries map[string]bool
+}
+
+// buildFixPlan greedily picks the location eliminating most of the remaining results until the target coverage is reached
+func buildFixPlan(resultsModel *wrappers.ScanResultsCollection, bestFixLocations map[string]bool, targetCoverage float64) *wrappers.FixPlan {
+	sastResults := getFixPlanResults(resultsModel)
+	fixPlan := &wrappers.FixPlan{
+		TotalResults:     len(sastResults),
+		TargetCoverage:   targetCoverage,
+		BestFixLocations: bestFixLocations != nil,
+	}
+	candidates := make(map[string]*fixPlanCandidate)
+	for index, result := range sastResults {
+		for _, node := range result.ScanResultData.Nodes {
+			key := fixPlanNodeKey(node)
+			candidate, ok := candidates[key]
+			if !ok {
+				candidate = &fixPlanCandidate{node: node, results: make(map[int]bool), queries: make(map[string]bool)}
+				candidates[key] = candidate
+			}
+			candidate.results[index] = true
+			candidate.queries[result.ScanResultData.QueryName] = true
+		}
+	}
+	keys := sortedKeys(candidates)
+
+	covered := make(map[int]bool)
+	for len(sastResults) > 0 && fixPlan.CoveragePercentage < targetCoverage {
+		bestKey := ""
+		bestEliminated := 0
+		for _, key := range keys {
+			eliminated := 0
+			for index := range candidates[key].results {
+				if !covered[index] {
+					eliminated++
+				}
+			}
+			if eliminated > bestEliminated || (eliminated == bestEliminated && eliminated > 0 && bestFixLocations[key] && !bestFixLocations[bestKey]) {
+				bestKey = key
+				bestEliminated = eliminated
+			}
+		}
+		if bestEliminated == 0 {
+			break
+		}
+		candidate := candidates[bestKey]
+		for index := range candidate.results {
+			covered[index] = true
+		}
+		fixPlan.CoveredResults = len(covered)
+		fixPlan.CoveragePercentage = roundFloat(float64(len(covered))*percentage/float64(len(sastResults)), precision)
+		fixPlan.Locations = append(fixPlan.Locations, &wrappers.FixPlanLocation{
+			Rank:               len(fixPlan.Locations) + 1,
+			FileName:           candidate.node.FileName,
+			Line:               candidate.node.Line,
+			Column:             candidate.node.Column,
+			Name:               candidate.node.Name,
+			Method:             candidate.node.Method,
+			BestFixLocation:    bestFixLocations[bestKey],
+			Queries:            sortedKeys(candidate.queries),
+			EliminatedResults:  bestEliminated,
+			SharedResults:      len(candidate.results),
+			CumulativeCoverage: fixPlan.CoveragePercentage,
+		})
+	}
+	return fixPlan
+}
+
+func createFixPlanReport(format, targetFile, targetPath string, fixPlan *wrappers.FixPlan) error {
+	if printer.IsFormat(format, printer.FormatJSON) {
+		return writeJSONReport(createTargetName(targetFile, targetPath, printer.FormatJSON), fixPlan)
+	}
+	if printer.IsFormat(format, printer.FormatSummaryMarkdown) {
+		return writeFixPlanMarkdown(createTargetName(targetFile, targetPath, printer.FormatMarkdown), fixPlan)
+	}
+	return errors.Errorf("bad report format %s", format)
+}
+
+func writeFixPlanMarkdown(targetFile string, fixPlan *wrappers.FixPlan) error {
+	log.Println("Creating Fix Plan Markdown Report: ", targetFile)
+	tmpl, err := template.New(printer.FormatSummaryMarkdown).Parse(wrappers.FixPlanMarkdownTemplate)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(targetFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return tmpl.Execute(file, fixPlan)
+}
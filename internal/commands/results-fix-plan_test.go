@@ -0,0 +1,64 @@
+//go:build !integration
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func createFixPlanResult(query string, nodes ...string) *wrappers.ScanResult {
+	result := &wrappers.ScanResult{Type: "sast", ScanResultData: wrappers.ScanResultData{QueryName: query}}
+	for i, name := range nodes {
+		result.ScanResultData.Nodes = append(result.ScanResultData.Nodes, &wrappers.ScanResultNode{FileName: "/" + name, Line: uint(i + 1), Name: name})
+	}
+	return result
+}
+
+func createFixPlanTestResults() *wrappers.ScanResultsCollection {
+	return &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			createFixPlanResult("SQL_Injection", "input", "query"),
+			createFixPlanResult("XSS", "input", "write"),
+			createFixPlanResult("Path_Traversal", "file"),
+			createFixPlanResult("Path_Traversal", "file"),
+			createFixPlanResult("Log_Forging", "log"),
+			{Type: "kics"},
+		},
+	}
+}
+
+func TestBuildFixPlan(t *testing.T) {
+	fixPlan := buildFixPlan(createFixPlanTestResults(), nil, 80)
+
+	assert.Equal(t, fixPlan.TotalResults, 5)
+	assert.Equal(t, fixPlan.CoveredResults, 4)
+	assert.Equal(t, fixPlan.CoveragePercentage, float64(80))
+	assert.Equal(t, len(fixPlan.Locations), 2)
+	assert.Equal(t, fixPlan.Locations[0].Name, "file")
+	assert.Equal(t, fixPlan.Locations[0].EliminatedResults, 2)
+	assert.Equal(t, fixPlan.Locations[1].Name, "input")
+	assert.DeepEqual(t, fixPlan.Locations[1].Queries, []string{"SQL_Injection", "XSS"})
+	assert.Equal(t, fixPlan.Locations[1].CumulativeCoverage, float64(80))
+}
+
+func TestBuildFixPlanPrefersBestFixLocations(t *testing.T) {
+	results := &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{createFixPlanResult("SQL_Injection", "input", "query")},
+	}
+	bestFixLocations := map[string]bool{"/query:2:0": true}
+
+	fixPlan := buildFixPlan(results, bestFixLocations, 100)
+
+	assert.Equal(t, len(fixPlan.Locations), 1)
+	assert.Equal(t, fixPlan.Locations[0].Name, "query")
+	assert.Assert(t, fixPlan.Locations[0].BestFixLocation)
+	assert.Equal(t, fixPlan.CoveragePercentage, float64(100))
+}
+
+func TestQueryIDString(t *testing.T) {
+	assert.Equal(t, queryIDString(float64(1234567890123)), "1234567890123")
+	assert.Equal(t, queryIDString("123"), "123")
+}
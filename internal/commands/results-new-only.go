@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+// newOnlyBaseScanParam carries the base branch scan in the results params, it is never sent to the API
+const newOnlyBaseScanParam = "new-only-base-scan-id"
+
+// newOnlyBaseScansPage is the number of base branch scans read at once while looking for one older than the reported scan
+const newOnlyBaseScansPage = 20
+
+const prBaseBranchFlagDescription = "Only report and apply thresholds to the findings not found in the latest completed scan of this branch"
+
+// addNewOnlyFilter looks for the latest completed scan of the base branch, so only the findings introduced by the scanned branch are read
+func addNewOnlyFilter(scanWrapper wrappers.ScansWrapper, scan *wrappers.ScanResponseModel, baseBranch string, params map[string]string) error {
+	if strings.TrimSpace(baseBranch) == "" {
+		return nil
+	}
+	baseScan, err := getLatestBaseScan(scanWrapper, scan, baseBranch)
+	if err != nil {
+		return err
+	}
+	if baseScan == nil {
+		log.Printf("No completed scan found for base branch %s, all the findings are new", baseBranch)
+		return nil
+	}
+	logger.PrintfIfVerbose("Comparing scan %s with scan %s of base branch %s", scan.ID, baseScan.ID, baseBranch)
+	params[newOnlyBaseScanParam] = baseScan.ID
+	return nil
+}
+
+// getLatestBaseScan returns the latest completed scan of the base branch created before the reported scan, so a scan
+// is never compared with itself or with a base branch scan that already has its findings
+func getLatestBaseScan(scanWrapper wrappers.ScansWrapper, scan *wrappers.ScanResponseModel, baseBranch string) (*wrappers.ScanResponseModel, error) {
+	for offset := 0; ; offset += newOnlyBaseScansPage {
+		page, errorModel, err := scanWrapper.Get(map[string]string{
+			commonParams.ProjectIDQueryParam: scan.ProjectID,
+			commonParams.BranchQueryParam:    baseBranch,
+			commonParams.StatusesQueryParam:  latestScanStatusesFilter,
+			commonParams.SortQueryParam:      latestScanSort,
+			commonParams.LimitQueryParam:     strconv.Itoa(newOnlyBaseScansPage),
+			commonParams.OffsetQueryParam:    strconv.Itoa(offset),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", failedGettingAll)
+		}
+		if errorModel != nil {
+			return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+		}
+		if page == nil {
+			return nil, nil
+		}
+		for i := range page.Scans {
+			if page.Scans[i].ID != scan.ID && page.Scans[i].CreatedAt.Before(scan.CreatedAt) {
+				return &page.Scans[i], nil
+			}
+		}
+		if len(page.Scans) < newOnlyBaseScansPage {
+			return nil, nil
+		}
+	}
+}
+
+// filterNewResults removes the results already found by the base scan, matching them by engine and similarity id
+func filterNewResults(
+	resultsWrapper wrappers.ResultsWrapper,
+	resultsModel *wrappers.ScanResultsCollection,
+	baseScanID string,
+) (*wrappers.ScanResultsCollection, error) {
+	baseKeys, err := getBaseResultKeys(resultsWrapper, baseScanID)
+	if err != nil {
+		return nil, err
+	}
+	return filterResultsByBaseKeys(resultsModel, baseKeys), nil
+}
+
+func getBaseResultKeys(resultsWrapper wrappers.ResultsWrapper, baseScanID string) (map[string]bool, error) {
+	baseKeys := make(map[string]bool)
+	err := forEachResultsPage(resultsWrapper, map[string]string{commonParams.ScanIDQueryParam: baseScanID}, func(page *wrappers.ScanResultsCollection) error {
+		for _, result := range page.Results {
+			baseKeys[resultMatchKey(result)] = true
+		}
+		return nil
+	})
+	return baseKeys, err
+}
+
+func filterResultsByBaseKeys(resultsModel *wrappers.ScanResultsCollection, baseKeys map[string]bool) *wrappers.ScanResultsCollection {
+	var newResults []*wrappers.ScanResult
+	for _, result := range resultsModel.Results {
+		if !baseKeys[resultMatchKey(result)] {
+			newResults = append(newResults, result)
+		}
+	}
+	logger.PrintfIfVerbose("%d of %d findings are new", len(newResults), len(resultsModel.Results))
+	resultsModel.Results = newResults
+	resultsModel.TotalCount = uint(len(newResults))
+	return resultsModel
+}
+
+// resultMatchKey identifies a result across the scans of a project
+func resultMatchKey(result *wrappers.ScanResult) string {
+	key := result.SimilarityID
+	if key == "" {
+		key = result.ID
+	}
+	return strings.TrimSpace(result.Type) + "/" + key
+}
@@ -0,0 +1,76 @@
+//go:build !integration
+
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+// branchScansWrapper returns the scans of a branch, latest first
+type branchScansWrapper struct {
+	mock.ScansMockWrapper
+	scans map[string][]wrappers.ScanResponseModel
+}
+
+func (s *branchScansWrapper) Get(queryParams map[string]string) (*wrappers.ScansCollectionResponseModel, *wrappers.ErrorModel, error) {
+	scans := s.scans[queryParams[params.BranchQueryParam]]
+	return &wrappers.ScansCollectionResponseModel{Scans: scans, TotalCount: uint(len(scans))}, nil, nil
+}
+
+func TestAddNewOnlyFilter(t *testing.T) {
+	now := time.Now()
+	scanWrapper := &branchScansWrapper{scans: map[string][]wrappers.ScanResponseModel{
+		"main":    {{ID: "main-3", CreatedAt: now}, {ID: "main-2", CreatedAt: now.Add(-2 * time.Hour)}, {ID: "main-1", CreatedAt: now.Add(-4 * time.Hour)}},
+		"feature": {{ID: "feature-2", CreatedAt: now.Add(time.Hour)}, {ID: "feature-1", CreatedAt: now.Add(-3 * time.Hour)}},
+	}}
+
+	queryParams := map[string]string{}
+	assert.NilError(t, addNewOnlyFilter(scanWrapper, &scanWrapper.scans["feature"][0], "main", queryParams))
+	assert.Equal(t, queryParams[newOnlyBaseScanParam], "main-3")
+
+	// The base branch scans created after the reported scan are skipped
+	queryParams = map[string]string{}
+	assert.NilError(t, addNewOnlyFilter(scanWrapper, &scanWrapper.scans["feature"][1], "main", queryParams))
+	assert.Equal(t, queryParams[newOnlyBaseScanParam], "main-1")
+
+	// A scan of the base branch is compared with the previous scan of the branch, not with itself
+	queryParams = map[string]string{}
+	assert.NilError(t, addNewOnlyFilter(scanWrapper, &scanWrapper.scans["main"][1], "main", queryParams))
+	assert.Equal(t, queryParams[newOnlyBaseScanParam], "main-1")
+
+	queryParams = map[string]string{}
+	assert.NilError(t, addNewOnlyFilter(scanWrapper, &scanWrapper.scans["feature"][1], "feature", queryParams))
+	_, ok := queryParams[newOnlyBaseScanParam]
+	assert.Assert(t, !ok)
+}
+
+func TestFilterNewResults(t *testing.T) {
+	resultsWrapper := &trendResultsWrapper{results: map[string][]*wrappers.ScanResult{
+		"base": {
+			{Type: "sast", SimilarityID: "a"},
+			{Type: "sca", ID: "CVE-1"},
+		},
+	}}
+	results := &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			{Type: "sast", SimilarityID: "a"},
+			{Type: "sast", SimilarityID: "b"},
+			{Type: "kics", SimilarityID: "a"},
+			{Type: "sca", ID: "CVE-1"},
+			{Type: "sca", ID: "CVE-2"},
+		},
+		TotalCount: 5,
+	}
+	newResults, err := filterNewResults(resultsWrapper, results, "base")
+	assert.NilError(t, err)
+	assert.Equal(t, newResults.TotalCount, uint(3))
+	assert.Equal(t, resultMatchKey(newResults.Results[0]), "sast/b")
+	assert.Equal(t, resultMatchKey(newResults.Results[1]), "kics/a")
+	assert.Equal(t, resultMatchKey(newResults.Results[2]), "sca/CVE-2")
+}
@@ -0,0 +1,512 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/semaphore"
+)
+
+const (
+	failedCreatingPortfolio  = "Failed creating portfolio report"
+	portfolioDefaultName     = "cx_portfolio"
+	portfolioDefaultTop      = 10
+	portfolioMaxConcurrency  = 10
+	portfolioNoScanMessage   = "No completed scan found"
+	portfolioNotEvaluated    = "NOT_EVALUATED"
+	latestScanStatusesFilter = wrappers.ScanCompleted + "," + wrappers.ScanPartial
+	latestScanSort           = "-created_at"
+)
+
+func resultPortfolioSubCommand(
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+) *cobra.Command {
+	portfolioCmd := &cobra.Command{
+		Use:   "portfolio",
+		Short: "Aggregate the results of many projects",
+		Long: "The portfolio command aggregates the latest main branch scan of every project matching the filters " +
+			"into a single report with per project severity counts, policy status and the top recurring queries and packages.",
+		Example: heredoc.Doc(
+			`
+			$ cx results portfolio --project-tags team:payments --report-format html,json
+			$ cx results portfolio --application-name <Application Name> --project-groups <Group Name>
+		`,
+		),
+		RunE: runResultsPortfolioCommand(resultsWrapper, scanWrapper, projectsWrapper, applicationsWrapper, groupsWrapper,
+			accessManagementWrapper, featureFlagsWrapper, policyWrapper),
+	}
+	portfolioCmd.PersistentFlags().String(commonParams.ProjectTagList, "", "Only include projects with these tags, ex: (tagA,tagB:val,etc)")
+	portfolioCmd.PersistentFlags().String(commonParams.ApplicationName, "", "Only include projects associated with this application")
+	portfolioCmd.PersistentFlags().String(commonParams.ProjectGroupList, "", "Only include projects assigned to one of these groups, ex: (PowerUsers,etc)")
+	portfolioCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterProjectsListFlagUsage)
+	portfolioCmd.PersistentFlags().Int(commonParams.TopFlag, portfolioDefaultTop, "Number of recurring queries and packages to report")
+	addResultFormatFlag(portfolioCmd, printer.FormatJSON, printer.FormatHTML, printer.FormatCSV)
+	portfolioCmd.PersistentFlags().String(commonParams.TargetFlag, portfolioDefaultName, "Output file")
+	portfolioCmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
+	return portfolioCmd
+}
+
+func runResultsPortfolioCommand(
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		targetFile, _ := cmd.Flags().GetString(commonParams.TargetFlag)
+		targetPath, _ := cmd.Flags().GetString(commonParams.TargetPathFlag)
+		reportFormats, _ := cmd.Flags().GetString(commonParams.TargetFormatFlag)
+		top, _ := cmd.Flags().GetInt(commonParams.TopFlag)
+		if top < 0 {
+			return errors.Errorf("--%s should be equal or higher than 0", commonParams.TopFlag)
+		}
+
+		projects, err := getPortfolioProjects(cmd, projectsWrapper, applicationsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingPortfolio)
+		}
+		logger.PrintfIfVerbose("Portfolio contains %d projects", len(projects))
+
+		projectResults, err := getPortfolioProjectsResults(projects, resultsWrapper, scanWrapper, policyWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingPortfolio)
+		}
+		portfolio := buildPortfolioSummary(projectResults, top)
+
+		err = createDirectory(targetPath)
+		if err != nil {
+			return err
+		}
+		for _, reportFormat := range strings.Split(reportFormats, ",") {
+			err = createPortfolioReport(strings.TrimSpace(reportFormat), targetFile, targetPath, portfolio)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func getPortfolioProjects(
+	cmd *cobra.Command,
+	projectsWrapper wrappers.ProjectsWrapper,
+	applicationsWrapper wrappers.ApplicationsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	accessManagementWrapper wrappers.AccessManagementWrapper,
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) ([]wrappers.ProjectResponseModel, error) {
+	projectTags, _ := cmd.Flags().GetString(commonParams.ProjectTagList)
+	applicationName, _ := cmd.Flags().GetString(commonParams.ApplicationName)
+	projectGroups, _ := cmd.Flags().GetString(commonParams.ProjectGroupList)
+
+	params, err := getFilters(cmd)
+	if err != nil {
+		return nil, err
+	}
+	projectsModel, errorModel, err := projectsWrapper.Get(params)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+	}
+
+	var applicationProjectIDs []string
+	if applicationName != "" {
+		application, appErr := getApplication(applicationName, applicationsWrapper)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if application == nil {
+			return nil, errors.Errorf("application %s not found", applicationName)
+		}
+		applicationProjectIDs = application.ProjectIds
+	}
+	var groupIDs []string
+	if projectGroups != "" {
+		groups, groupsErr := services.CreateGroupsMap(projectGroups, groupsWrapper)
+		if groupsErr != nil {
+			return nil, groupsErr
+		}
+		groupIDs = services.GetGroupIds(groups)
+	}
+	tags := parseTagList(projectTags)
+	accessManagementEnabled := isAccessManagementEnabled(featureFlagsWrapper)
+
+	var projects []wrappers.ProjectResponseModel
+	for i := range projectsModel.Projects {
+		project := projectsModel.Projects[i]
+		if applicationName != "" && !slices.Contains(applicationProjectIDs, project.ID) {
+			continue
+		}
+		if !hasAllTags(project.Tags, tags) {
+			continue
+		}
+		if len(groupIDs) > 0 {
+			assigned, groupsErr := getProjectGroups(&project, accessManagementWrapper, accessManagementEnabled)
+			if groupsErr != nil {
+				return nil, groupsErr
+			}
+			if !containsAny(assigned.ids, groupIDs) {
+				continue
+			}
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+func hasAllTags(projectTags, requiredTags map[string]string) bool {
+	for key, value := range requiredTags {
+		projectValue, ok := projectTags[key]
+		if !ok || (value != "" && projectValue != value) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, candidate := range candidates {
+		if slices.Contains(values, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// portfolioProjectResults keeps the counters of a project, the results are dropped once counted
+type portfolioProjectResults struct {
+	summary  *wrappers.PortfolioProjectSummary
+	queries  map[string]*wrappers.PortfolioOccurrence
+	packages map[string]*wrappers.PortfolioOccurrence
+}
+
+func newPortfolioProjectResults(summary *wrappers.PortfolioProjectSummary) *portfolioProjectResults {
+	return &portfolioProjectResults{
+		summary:  summary,
+		queries:  make(map[string]*wrappers.PortfolioOccurrence),
+		packages: make(map[string]*wrappers.PortfolioOccurrence),
+	}
+}
+
+func (p *portfolioProjectResults) count(results *wrappers.ScanResultsCollection) {
+	countPortfolioProjectResults(p.summary, results)
+	countPortfolioOccurrences(results, p.queries, p.packages)
+}
+
+func getPortfolioProjectsResults(
+	projects []wrappers.ProjectResponseModel,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+) ([]*portfolioProjectResults, error) {
+	sem := semaphore.NewWeighted(portfolioMaxConcurrency)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	projectResults := make([]*portfolioProjectResults, len(projects))
+	for i := range projects {
+		err := sem.Acquire(ctx, 1)
+		if err != nil {
+			return nil, err
+		}
+		wg.Add(1)
+		go func(index int, project wrappers.ProjectResponseModel) {
+			defer wg.Done()
+			defer sem.Release(1)
+			projectResults[index] = getPortfolioProjectResults(&project, resultsWrapper, scanWrapper, policyWrapper)
+		}(i, projects[i])
+	}
+	wg.Wait()
+	return projectResults, nil
+}
+
+func getPortfolioProjectResults(
+	project *wrappers.ProjectResponseModel,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+	policyWrapper wrappers.PolicyWrapper,
+) *portfolioProjectResults {
+	summary := &wrappers.PortfolioProjectSummary{
+		ProjectID:     project.ID,
+		ProjectName:   project.Name,
+		BranchName:    project.MainBranch,
+		EnginesIssues: make(map[string]int),
+		PolicyStatus:  portfolioNotEvaluated,
+	}
+	projectResults := newPortfolioProjectResults(summary)
+
+	scan, err := getLatestScan(scanWrapper, project.ID, project.MainBranch)
+	if err != nil {
+		summary.Error = err.Error()
+		return projectResults
+	}
+	if scan == nil {
+		summary.Error = portfolioNoScanMessage
+		return projectResults
+	}
+	summary.ScanID = scan.ID
+	summary.ScanStatus = string(scan.Status)
+	summary.ScanCreatedAt = scan.CreatedAt.Format(summaryCreatedAtLayout)
+	if summary.BranchName == "" {
+		summary.BranchName = scan.Branch
+	}
+
+	err = forEachResultsPage(resultsWrapper, map[string]string{commonParams.ScanIDQueryParam: scan.ID}, func(page *wrappers.ScanResultsCollection) error {
+		projectResults.count(page)
+		return nil
+	})
+	if err != nil {
+		summary.Error = err.Error()
+		return projectResults
+	}
+
+	policy, webError, err := policyWrapper.EvaluatePolicy(map[string]string{"scanId": scan.ID, "astProjectId": project.ID})
+	if err == nil && webError == nil && policy != nil {
+		summary.PolicyStatus = policy.Status
+		summary.BreakBuild = policy.BreakBuild
+		violated := filterViolatedRules(*policy)
+		summary.PolicyViolated = violated != nil && len(violated.Policies) > 0
+	}
+	return projectResults
+}
+
+// getLatestScan returns the latest completed scan of the branch, or of the project when the branch is empty
+func getLatestScan(scanWrapper wrappers.ScansWrapper, projectID, branch string) (*wrappers.ScanResponseModel, error) {
+	params := map[string]string{
+		commonParams.ProjectIDQueryParam: projectID,
+		commonParams.StatusesQueryParam:  latestScanStatusesFilter,
+		commonParams.SortQueryParam:      latestScanSort,
+		commonParams.LimitQueryParam:     "1",
+	}
+	if branch != "" {
+		params[commonParams.BranchQueryParam] = branch
+	}
+	scans, errorModel, err := scanWrapper.Get(params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingAll)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+	}
+	if scans == nil || len(scans.Scans) == 0 {
+		return nil, nil
+	}
+	return &scans.Scans[0], nil
+}
+
+func countPortfolioProjectResults(summary *wrappers.PortfolioProjectSummary, results *wrappers.ScanResultsCollection) {
+	if results == nil {
+		return
+	}
+	for _, result := range results.Results {
+		if !isExploitable(result.State) {
+			continue
+		}
+		summary.TotalIssues++
+		summary.EnginesIssues[strings.TrimSpace(result.Type)]++
+		switch strings.ToLower(result.Severity) {
+		case criticalLabel:
+			summary.CriticalIssues++
+		case highLabel:
+			summary.HighIssues++
+		case mediumLabel:
+			summary.MediumIssues++
+		case lowLabel:
+			summary.LowIssues++
+		case infoLabel:
+			summary.InfoIssues++
+		}
+	}
+}
+
+func buildPortfolioSummary(projectResults []*portfolioProjectResults, top int) *wrappers.PortfolioSummary {
+	portfolio := &wrappers.PortfolioSummary{
+		CreatedAt:     time.Now().Format(summaryCreatedAtLayout),
+		TotalProjects: len(projectResults),
+	}
+	queries := make(map[string]*wrappers.PortfolioOccurrence)
+	packages := make(map[string]*wrappers.PortfolioOccurrence)
+	for _, projectResult := range projectResults {
+		summary := projectResult.summary
+		portfolio.Projects = append(portfolio.Projects, summary)
+		if summary.ScanID != "" {
+			portfolio.ScannedProjects++
+		}
+		portfolio.TotalIssues += summary.TotalIssues
+		portfolio.CriticalIssues += summary.CriticalIssues
+		portfolio.HighIssues += summary.HighIssues
+		portfolio.MediumIssues += summary.MediumIssues
+		portfolio.LowIssues += summary.LowIssues
+		portfolio.InfoIssues += summary.InfoIssues
+		mergePortfolioOccurrences(queries, projectResult.queries)
+		mergePortfolioOccurrences(packages, projectResult.packages)
+	}
+	sort.SliceStable(portfolio.Projects, func(i, j int) bool {
+		return portfolio.Projects[i].ProjectName < portfolio.Projects[j].ProjectName
+	})
+	portfolio.TopQueries = topPortfolioOccurrences(queries, top)
+	portfolio.TopPackages = topPortfolioOccurrences(packages, top)
+	return portfolio
+}
+
+// countPortfolioOccurrences counts the queries and packages found in a page of results of one project
+func countPortfolioOccurrences(results *wrappers.ScanResultsCollection, queries, packages map[string]*wrappers.PortfolioOccurrence) {
+	if results == nil {
+		return
+	}
+	for _, result := range results.Results {
+		if !isExploitable(result.State) {
+			continue
+		}
+		engine := strings.TrimSpace(result.Type)
+		var name string
+		var occurrences map[string]*wrappers.PortfolioOccurrence
+		if engine == commonParams.ScaType || engine == commonParams.ContainersType {
+			name = result.ScanResultData.PackageIdentifier
+			occurrences = packages
+		} else {
+			name = result.ScanResultData.QueryName
+			occurrences = queries
+		}
+		if name == "" {
+			continue
+		}
+		key := engine + "/" + name
+		occurrence, ok := occurrences[key]
+		if !ok {
+			occurrence = &wrappers.PortfolioOccurrence{Name: name, Engine: engine, Projects: 1}
+			occurrences[key] = occurrence
+		}
+		occurrence.Occurrences++
+	}
+}
+
+// mergePortfolioOccurrences adds the occurrences of one project to the ones of the portfolio
+func mergePortfolioOccurrences(occurrences, projectOccurrences map[string]*wrappers.PortfolioOccurrence) {
+	for key, projectOccurrence := range projectOccurrences {
+		occurrence, ok := occurrences[key]
+		if !ok {
+			occurrence = &wrappers.PortfolioOccurrence{Name: projectOccurrence.Name, Engine: projectOccurrence.Engine}
+			occurrences[key] = occurrence
+		}
+		occurrence.Occurrences += projectOccurrence.Occurrences
+		occurrence.Projects++
+	}
+}
+
+func topPortfolioOccurrences(occurrences map[string]*wrappers.PortfolioOccurrence, top int) []*wrappers.PortfolioOccurrence {
+	sorted := make([]*wrappers.PortfolioOccurrence, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		sorted = append(sorted, occurrence)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Projects != sorted[j].Projects {
+			return sorted[i].Projects > sorted[j].Projects
+		}
+		if sorted[i].Occurrences != sorted[j].Occurrences {
+			return sorted[i].Occurrences > sorted[j].Occurrences
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	if len(sorted) > top {
+		sorted = sorted[:top]
+	}
+	return sorted
+}
+
+func createPortfolioReport(format, targetFile, targetPath string, portfolio *wrappers.PortfolioSummary) error {
+	if printer.IsFormat(format, printer.FormatJSON) {
+		return writeJSONReport(createTargetName(targetFile, targetPath, printer.FormatJSON), portfolio)
+	}
+	if printer.IsFormat(format, printer.FormatHTML) {
+		return writePortfolioHTML(createTargetName(targetFile, targetPath, printer.FormatHTML), portfolio)
+	}
+	if printer.IsFormat(format, printer.FormatCSV) {
+		return writePortfolioCSV(createTargetName(targetFile, targetPath, printer.FormatCSV), portfolio)
+	}
+	return errors.Errorf("bad report format %s", format)
+}
+
+func writeJSONReport(targetFile string, report interface{}) error {
+	log.Println("Creating JSON Report: ", targetFile)
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to serialize report ", failedGettingAll)
+	}
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to create target file  ", failedGettingAll)
+	}
+	_, _ = fmt.Fprintln(f, string(reportJSON))
+	_ = f.Close()
+	return nil
+}
+
+func writePortfolioHTML(targetFile string, portfolio *wrappers.PortfolioSummary) error {
+	log.Println("Creating Portfolio Report: ", targetFile)
+	portfolioTemplate, err := template.New("portfolioTemplate").Parse(wrappers.PortfolioTemplate)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return portfolioTemplate.ExecuteTemplate(f, "PortfolioTemplate", portfolio)
+}
+
+func writePortfolioCSV(targetFile string, portfolio *wrappers.PortfolioSummary) error {
+	log.Println("Creating Portfolio Report: ", targetFile)
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	writer := csv.NewWriter(f)
+	_ = writer.Write([]string{
+		"Project ID", "Project Name", "Branch", "Scan ID", "Scan Status", "Scan Created At",
+		"Critical", "High", "Medium", "Low", "Info", "Total", "Policy Status", "Policy Violated", "Break Build", "Error",
+	})
+	for _, project := range portfolio.Projects {
+		_ = writer.Write([]string{
+			project.ProjectID, project.ProjectName, project.BranchName, project.ScanID, project.ScanStatus, project.ScanCreatedAt,
+			strconv.Itoa(project.CriticalIssues), strconv.Itoa(project.HighIssues), strconv.Itoa(project.MediumIssues),
+			strconv.Itoa(project.LowIssues), strconv.Itoa(project.InfoIssues), strconv.Itoa(project.TotalIssues),
+			project.PolicyStatus, strconv.FormatBool(project.PolicyViolated), strconv.FormatBool(project.BreakBuild), project.Error,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
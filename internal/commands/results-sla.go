@@ -0,0 +1,297 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedCreatingSLA = "Failed creating SLA report"
+	failedGettingSLA  = "SLA check finished with status Failed : %d findings breach their SLA"
+	slaDefaultName    = "cx_sla"
+	slaDefault        = "critical=7;high=30;medium=90;low=180"
+	slaDaySuffix      = "d"
+)
+
+var slaSeverityOrder = map[string]int{criticalLabel: 0, highLabel: 1, mediumLabel: 2, lowLabel: 3, infoLabel: 4}
+
+func resultSLASubCommand(resultsWrapper wrappers.ResultsWrapper, scanWrapper wrappers.ScansWrapper) *cobra.Command {
+	slaCmd := &cobra.Command{
+		Use:   "sla",
+		Short: "Report the open findings of a scan older than their severity SLA",
+		Long: "The sla command computes the age of every open finding of a scan from the first time it was found, " +
+			"compares it with the SLA of its severity and lists the findings breaching it.",
+		Example: heredoc.Doc(
+			`
+			$ cx results sla --scan-id <scan Id> --sla "critical=7,high=30" --report-format table,markdown
+			$ cx results sla --scan-id <scan Id> --fail-on-breach
+		`,
+		),
+		RunE: runResultsSLACommand(resultsWrapper, scanWrapper),
+	}
+	addScanIDFlag(slaCmd, "ID to report on.")
+	markFlagAsRequired(slaCmd, commonParams.ScanIDFlag)
+	slaCmd.PersistentFlags().String(commonParams.SLAFlag, slaDefault, "SLA in days per severity. Format <severity>=<days>")
+	slaCmd.PersistentFlags().Bool(commonParams.FailOnBreachFlag, false, "Fail when any finding breaches its SLA")
+	slaCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterResultsListFlagUsage)
+	slaCmd.PersistentFlags().String(commonParams.WhereFlag, "", commonParams.WhereFlagUsage)
+	slaCmd.PersistentFlags().String(commonParams.TargetFormatFlag, printer.FormatTable,
+		fmt.Sprintf(
+			"Format for the output. One or more of %s",
+			printer.FormatTable+", "+printer.FormatJSON+", "+printer.FormatSummaryMarkdown,
+		),
+	)
+	slaCmd.PersistentFlags().String(commonParams.TargetFlag, slaDefaultName, "Output file")
+	slaCmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
+	return slaCmd
+}
+
+func runResultsSLACommand(resultsWrapper wrappers.ResultsWrapper, scanWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scanID, _ := cmd.Flags().GetString(commonParams.ScanIDFlag)
+		sla, _ := cmd.Flags().GetString(commonParams.SLAFlag)
+		failOnBreach, _ := cmd.Flags().GetBool(commonParams.FailOnBreachFlag)
+		targetFile, _ := cmd.Flags().GetString(commonParams.TargetFlag)
+		targetPath, _ := cmd.Flags().GetString(commonParams.TargetPathFlag)
+		reportFormats, _ := cmd.Flags().GetString(commonParams.TargetFormatFlag)
+		slaMap, err := parseSLA(sla)
+		if err != nil {
+			return err
+		}
+
+		params, err := getFilters(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingSLA)
+		}
+		err = addWhereFilter(cmd, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingSLA)
+		}
+		whereExpression, hasWhere := params[commonParams.WhereFlag]
+		delete(params, commonParams.WhereFlag)
+		params[commonParams.ScanIDQueryParam] = scanID
+		resultsModel, errorModel, err := resultsWrapper.GetAllResultsByScanID(params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedListingResults)
+		}
+		if errorModel != nil {
+			return errors.Errorf("%s: CODE: %d, %s", failedListingResults, errorModel.Code, errorModel.Message)
+		}
+		if resultsModel == nil {
+			resultsModel = &wrappers.ScanResultsCollection{}
+		}
+		if hasWhere {
+			resultsModel, err = FilterResultsByWhereExpression(resultsModel, whereExpression)
+			if err != nil {
+				return err
+			}
+		}
+
+		report, err := buildSLAReport(resultsModel, slaMap, scanWrapper, time.Now())
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingSLA)
+		}
+		report.ScanID = scanID
+
+		err = createDirectory(targetPath)
+		if err != nil {
+			return err
+		}
+		for _, reportFormat := range strings.Split(reportFormats, ",") {
+			err = createSLAReport(cmd, strings.TrimSpace(reportFormat), targetFile, targetPath, report)
+			if err != nil {
+				return err
+			}
+		}
+		if failOnBreach && report.BreachedResults > 0 {
+			return errors.Errorf(failedGettingSLA, report.BreachedResults)
+		}
+		return nil
+	}
+}
+
+// parseSLA reads the days allowed per severity, ex: "critical=7;high=30d"
+func parseSLA(sla string) (map[string]int, error) {
+	slaMap := make(map[string]int)
+	sla = strings.ReplaceAll(strings.ReplaceAll(sla, " ", ""), ",", ";")
+	for _, limit := range strings.Split(strings.ToLower(sla), ";") {
+		if limit == "" {
+			continue
+		}
+		parts := strings.Split(limit, "=")
+		if len(parts) != 2 { //nolint:gomnd
+			return nil, errors.Errorf("Invalid SLA %s. Format should be <severity>=<days>", limit)
+		}
+		if _, ok := slaSeverityOrder[parts[0]]; !ok {
+			return nil, errors.Errorf("Invalid SLA severity %s", parts[0])
+		}
+		days, err := strconv.Atoi(strings.TrimSuffix(parts[1], slaDaySuffix))
+		if err != nil || days < 1 {
+			return nil, errors.Errorf("Invalid value for SLA %s. SLA should be greater or equal to 1 day", parts[0])
+		}
+		slaMap[parts[0]] = days
+	}
+	if len(slaMap) == 0 {
+		return nil, errors.Errorf("--%s should define at least one severity", commonParams.SLAFlag)
+	}
+	return slaMap, nil
+}
+
+// buildSLAReport computes the age of the open findings with an SLA, from their first found date or the date of their first scan
+func buildSLAReport(
+	resultsModel *wrappers.ScanResultsCollection,
+	slaMap map[string]int,
+	scanWrapper wrappers.ScansWrapper,
+	now time.Time,
+) (*wrappers.SLAReport, error) {
+	report := &wrappers.SLAReport{CreatedAt: now.Format(summaryCreatedAtLayout)}
+	severities := make(map[string]*wrappers.SLASeverity)
+	for severity, days := range slaMap {
+		severities[severity] = &wrappers.SLASeverity{Severity: strings.ToUpper(severity), SLADays: days}
+	}
+	firstScans := make(map[string]time.Time)
+	for _, result := range resultsModel.Results {
+		severity := strings.ToLower(result.Severity)
+		slaSeverity, ok := severities[severity]
+		if !ok || !isExploitable(result.State) {
+			continue
+		}
+		report.TotalResults++
+		slaSeverity.OpenResults++
+		firstFoundAt, found, err := getFirstFoundAt(result, scanWrapper, firstScans)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			logger.PrintfIfVerbose("Unknown age of result %s", result.ID)
+			report.UnknownAge++
+			continue
+		}
+		ageDays := int(now.Sub(firstFoundAt).Hours() / hoursPerDay)
+		if ageDays > slaSeverity.OldestAgeDays {
+			slaSeverity.OldestAgeDays = ageDays
+		}
+		if ageDays <= slaSeverity.SLADays {
+			continue
+		}
+		report.BreachedResults++
+		slaSeverity.BreachedResults++
+		report.Findings = append(report.Findings, &wrappers.SLAFinding{
+			ID:           result.ID,
+			Type:         strings.TrimSpace(result.Type),
+			Severity:     strings.ToUpper(severity),
+			Name:         slaResultName(result),
+			Location:     slaResultLocation(result),
+			FirstFoundAt: firstFoundAt.Format(summaryCreatedAtLayout),
+			AgeDays:      ageDays,
+			SLADays:      slaSeverity.SLADays,
+			OverdueDays:  ageDays - slaSeverity.SLADays,
+		})
+	}
+
+	for _, severity := range sortedKeys(severities) {
+		report.Severities = append(report.Severities, severities[severity])
+	}
+	sort.SliceStable(report.Severities, func(i, j int) bool {
+		return slaSeverityOrder[strings.ToLower(report.Severities[i].Severity)] < slaSeverityOrder[strings.ToLower(report.Severities[j].Severity)]
+	})
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].OverdueDays > report.Findings[j].OverdueDays
+	})
+	return report, nil
+}
+
+// getFirstFoundAt returns when a result was first found, falling back to the creation of its first scan
+func getFirstFoundAt(result *wrappers.ScanResult, scanWrapper wrappers.ScansWrapper, firstScans map[string]time.Time) (time.Time, bool, error) {
+	if result.FirstFoundAt != "" {
+		firstFoundAt, err := time.Parse(trendFoundAtLayout, result.FirstFoundAt)
+		if err == nil {
+			return firstFoundAt, true, nil
+		}
+	}
+	if result.FirstScanID == "" {
+		return time.Time{}, false, nil
+	}
+	if createdAt, ok := firstScans[result.FirstScanID]; ok {
+		return createdAt, !createdAt.IsZero(), nil
+	}
+	scan, errorModel, err := scanWrapper.GetByID(result.FirstScanID)
+	if err != nil {
+		return time.Time{}, false, errors.Wrapf(err, "%s", failedGettingScan)
+	}
+	if errorModel != nil {
+		return time.Time{}, false, errors.Errorf("%s: CODE: %d, %s", failedGettingScan, errorModel.Code, errorModel.Message)
+	}
+	var createdAt time.Time
+	if scan != nil {
+		createdAt = scan.CreatedAt
+	}
+	firstScans[result.FirstScanID] = createdAt
+	return createdAt, !createdAt.IsZero(), nil
+}
+
+func slaResultName(result *wrappers.ScanResult) string {
+	if result.ScanResultData.QueryName != "" {
+		return result.ScanResultData.QueryName
+	}
+	if result.VulnerabilityDetails.CveName != "" {
+		return result.VulnerabilityDetails.CveName
+	}
+	return result.ID
+}
+
+func slaResultLocation(result *wrappers.ScanResult) string {
+	data := result.ScanResultData
+	switch {
+	case len(data.Nodes) > 0 && data.Nodes[0] != nil:
+		return fmt.Sprintf("%s:%d", data.Nodes[0].FileName, data.Nodes[0].Line)
+	case data.Filename != "":
+		return fmt.Sprintf("%s:%d", data.Filename, data.Line)
+	case data.PackageIdentifier != "":
+		return data.PackageIdentifier
+	case data.ImageName != "":
+		return data.ImageName + ":" + data.ImageTag
+	}
+	return ""
+}
+
+func createSLAReport(cmd *cobra.Command, format, targetFile, targetPath string, report *wrappers.SLAReport) error {
+	if printer.IsFormat(format, printer.FormatTable) {
+		return printer.Print(cmd.OutOrStdout(), report.Findings, printer.FormatTable)
+	}
+	if printer.IsFormat(format, printer.FormatJSON) {
+		return writeJSONReport(createTargetName(targetFile, targetPath, printer.FormatJSON), report)
+	}
+	if printer.IsFormat(format, printer.FormatSummaryMarkdown) {
+		return writeSLAMarkdown(createTargetName(targetFile, targetPath, printer.FormatMarkdown), report)
+	}
+	return errors.Errorf("bad report format %s", format)
+}
+
+func writeSLAMarkdown(targetFile string, report *wrappers.SLAReport) error {
+	log.Println("Creating SLA Markdown Report: ", targetFile)
+	tmpl, err := template.New(printer.FormatSummaryMarkdown).Parse(wrappers.SLAMarkdownTemplate)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(targetFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return tmpl.Execute(file, report)
+}
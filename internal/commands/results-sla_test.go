@@ -0,0 +1,64 @@
+//go:build !integration
+
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+type slaScansWrapper struct {
+	mock.ScansMockWrapper
+	calls int
+}
+
+func (s *slaScansWrapper) GetByID(scanID string) (*wrappers.ScanResponseModel, *wrappers.ErrorModel, error) {
+	s.calls++
+	return &wrappers.ScanResponseModel{ID: scanID, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, nil, nil
+}
+
+func TestParseSLA(t *testing.T) {
+	slaMap, err := parseSLA("critical=7, HIGH=30d;medium=90")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, slaMap, map[string]int{"critical": 7, "high": 30, "medium": 90})
+
+	for _, sla := range []string{"", "critical", "critical=0", "critical=abc", "urgent=7"} {
+		_, err = parseSLA(sla)
+		assert.Assert(t, err != nil, sla)
+	}
+}
+
+func TestBuildSLAReport(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	results := &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			{ID: "1", Type: "sast", Severity: "CRITICAL", State: "TO_VERIFY", FirstFoundAt: "2024-02-20T00:00:00Z"},
+			{ID: "2", Type: "sast", Severity: "HIGH", State: "CONFIRMED", FirstFoundAt: "2024-02-25T00:00:00Z"},
+			{ID: "3", Type: "sca", Severity: "HIGH", FirstScanID: "first"},
+			{ID: "4", Type: "sca", Severity: "HIGH", FirstScanID: "first"},
+			{ID: "5", Type: "kics", Severity: "HIGH", State: "NOT_EXPLOITABLE", FirstFoundAt: "2020-01-01T00:00:00Z"},
+			{ID: "6", Type: "kics", Severity: "LOW", FirstFoundAt: "2020-01-01T00:00:00Z"},
+			{ID: "7", Type: "sast", Severity: "CRITICAL"},
+		},
+	}
+	scansWrapper := &slaScansWrapper{}
+	report, err := buildSLAReport(results, map[string]int{"critical": 7, "high": 30}, scansWrapper, now)
+	assert.NilError(t, err)
+	assert.Equal(t, scansWrapper.calls, 1)
+	assert.Equal(t, report.TotalResults, 5)
+	assert.Equal(t, report.UnknownAge, 1)
+	assert.Equal(t, report.BreachedResults, 3)
+	assert.Equal(t, len(report.Severities), 2)
+	assert.Equal(t, report.Severities[0].Severity, "CRITICAL")
+	assert.Equal(t, report.Severities[0].BreachedResults, 1)
+	assert.Equal(t, report.Severities[1].OpenResults, 3)
+	assert.Equal(t, report.Severities[1].OldestAgeDays, 60)
+	assert.Equal(t, report.Findings[0].ID, "3")
+	assert.Equal(t, report.Findings[0].OverdueDays, 30)
+	assert.Equal(t, report.Findings[2].ID, "1")
+	assert.Equal(t, report.Findings[2].AgeDays, 10)
+}
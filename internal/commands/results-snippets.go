@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultSnippetsContext     = 3
+	invalidSnippetsSourceDir   = "Invalid snippets source directory"
+	invalidSnippetsContextFlag = "--%s should be equal or higher than 0"
+	maxSnippetLineSize         = 1024 * 1024
+	maxSummaryCodeSnippets     = 50
+)
+
+// addSnippetsOptions validates the snippets flags and adds them to the results params
+func addSnippetsOptions(sourceDir string, contextLines int, params map[string]string) error {
+	if sourceDir == "" {
+		return nil
+	}
+	if contextLines < 0 {
+		return errors.Errorf(invalidSnippetsContextFlag, commonParams.SnippetsContextFlag)
+	}
+	info, err := os.Stat(sourceDir)
+	if err != nil {
+		return errors.Wrapf(err, "%s", invalidSnippetsSourceDir)
+	}
+	if !info.IsDir() {
+		return errors.Errorf("%s: %s is not a directory", invalidSnippetsSourceDir, sourceDir)
+	}
+	params[commonParams.SnippetsSourceDirFlag] = sourceDir
+	params[commonParams.SnippetsContextFlag] = strconv.Itoa(contextLines)
+	return nil
+}
+
+// addResultsSnippets embeds the source lines around every SAST node and KICS location found in sourceDir
+func addResultsSnippets(resultsModel *wrappers.ScanResultsCollection, sourceDir string, contextLines int) {
+	files := make(map[string][]string)
+	for _, result := range resultsModel.Results {
+		switch strings.TrimSpace(result.Type) {
+		case commonParams.SastType:
+			for _, node := range result.ScanResultData.Nodes {
+				if node != nil {
+					node.Snippet = readSourceSnippet(files, sourceDir, node.FileName, node.Line, contextLines)
+				}
+			}
+		case commonParams.KicsType:
+			data := &result.ScanResultData
+			data.Snippet = readSourceSnippet(files, sourceDir, data.Filename, data.Line, contextLines)
+		}
+	}
+}
+
+func readSourceSnippet(files map[string][]string, sourceDir, fileName string, line uint, contextLines int) *wrappers.SourceSnippet {
+	if fileName == "" || line == 0 {
+		return nil
+	}
+	lines, ok := files[fileName]
+	if !ok {
+		lines = readSourceLines(sourceDir, fileName)
+		files[fileName] = lines
+	}
+	if int(line) > len(lines) {
+		return nil
+	}
+	start := int(line) - contextLines
+	if start < 1 {
+		start = 1
+	}
+	end := int(line) + contextLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return &wrappers.SourceSnippet{
+		StartLine: uint(start),
+		Line:      line,
+		Lines:     lines[start-1 : end],
+	}
+}
+
+// readSourceLines reads a result file relative to the source directory, ignoring paths outside of it
+func readSourceLines(sourceDir, fileName string) []string {
+	filePath := filepath.Join(sourceDir, filepath.FromSlash(strings.TrimPrefix(fileName, "/")))
+	relativePath, err := filepath.Rel(sourceDir, filePath)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return nil
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		logger.PrintfIfVerbose("Source file %s not found for code snippets", filePath)
+		return nil
+	}
+	defer file.Close()
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxSnippetLineSize)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+// collectCodeSnippets gathers the results with snippets for the markdown and HTML summaries, keeping the first
+// maxSummaryCodeSnippets of them so the summaries stay readable
+func collectCodeSnippets(resultsModel *wrappers.ScanResultsCollection) []*wrappers.ResultCodeSnippets {
+	if resultsModel == nil {
+		return nil
+	}
+	var codeSnippets []*wrappers.ResultCodeSnippets
+	for _, result := range resultsModel.Results {
+		if len(codeSnippets) == maxSummaryCodeSnippets {
+			logger.PrintfIfVerbose("Only the first %d results with code snippets are added to the summary", maxSummaryCodeSnippets)
+			break
+		}
+		if !isExploitable(result.State) {
+			continue
+		}
+		resultSnippets := &wrappers.ResultCodeSnippets{
+			Type:      strings.TrimSpace(result.Type),
+			Severity:  result.Severity,
+			QueryName: result.ScanResultData.QueryName,
+		}
+		for _, node := range result.ScanResultData.Nodes {
+			if node != nil && node.Snippet != nil {
+				resultSnippets.Locations = append(resultSnippets.Locations, &wrappers.CodeSnippetLocation{
+					FileName: node.FileName,
+					Line:     node.Line,
+					Name:     node.Name,
+					Snippet:  node.Snippet,
+				})
+			}
+		}
+		data := &result.ScanResultData
+		if data.Snippet != nil {
+			resultSnippets.Locations = append(resultSnippets.Locations, &wrappers.CodeSnippetLocation{
+				FileName: data.Filename,
+				Line:     data.Line,
+				Name:     data.IssueType,
+				Snippet:  data.Snippet,
+			})
+		}
+		if len(resultSnippets.Locations) > 0 {
+			codeSnippets = append(codeSnippets, resultSnippets)
+		}
+	}
+	return codeSnippets
+}
@@ -0,0 +1,141 @@
+//go:build !integration
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func createSnippetsSourceDir(t *testing.T) string {
+	sourceDir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(sourceDir, "src"), os.ModePerm)
+	assert.NilError(t, err)
+	source := "line 1\nline 2\nline 3\nline 4\nline 5\nline 6\n"
+	err = os.WriteFile(filepath.Join(sourceDir, "src", "Main.java"), []byte(source), os.ModePerm)
+	assert.NilError(t, err)
+	err = os.WriteFile(filepath.Join(sourceDir, "main.tf"), []byte(source), os.ModePerm)
+	assert.NilError(t, err)
+	return sourceDir
+}
+
+func createSnippetsTestResults() *wrappers.ScanResultsCollection {
+	return &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			{
+				Type:     "sast",
+				Severity: "HIGH",
+				ScanResultData: wrappers.ScanResultData{
+					QueryName: "SQL_Injection",
+					Nodes: []*wrappers.ScanResultNode{
+						{FileName: "/src/Main.java", Line: 1, Name: "input"},
+						{FileName: "/src/Main.java", Line: 5, Name: "execute"},
+						{FileName: "/src/Missing.java", Line: 2},
+						{FileName: "/../outside.java", Line: 2},
+						nil,
+					},
+				},
+			},
+			{
+				Type:     "kics",
+				Severity: "MEDIUM",
+				ScanResultData: wrappers.ScanResultData{
+					QueryName: "Public Bucket",
+					Filename:  "main.tf",
+					Line:      6,
+					IssueType: "IncorrectValue",
+				},
+			},
+			{
+				Type:     "sca",
+				Severity: "LOW",
+			},
+		},
+	}
+}
+
+func TestAddResultsSnippets(t *testing.T) {
+	results := createSnippetsTestResults()
+
+	addResultsSnippets(results, createSnippetsSourceDir(t), 1)
+
+	nodes := results.Results[0].ScanResultData.Nodes
+	assert.DeepEqual(t, nodes[0].Snippet, &wrappers.SourceSnippet{StartLine: 1, Line: 1, Lines: []string{"line 1", "line 2"}})
+	assert.DeepEqual(t, nodes[1].Snippet, &wrappers.SourceSnippet{StartLine: 4, Line: 5, Lines: []string{"line 4", "line 5", "line 6"}})
+	assert.Assert(t, nodes[2].Snippet == nil)
+	assert.Assert(t, nodes[3].Snippet == nil)
+	kicsSnippet := results.Results[1].ScanResultData.Snippet
+	assert.DeepEqual(t, kicsSnippet, &wrappers.SourceSnippet{StartLine: 5, Line: 6, Lines: []string{"line 5", "line 6"}})
+	assert.DeepEqual(t, kicsSnippet.NumberedLines(), []string{"     5 | line 5", ">    6 | line 6"})
+}
+
+func TestCollectCodeSnippets(t *testing.T) {
+	results := createSnippetsTestResults()
+	addResultsSnippets(results, createSnippetsSourceDir(t), 0)
+
+	codeSnippets := collectCodeSnippets(results)
+
+	assert.Equal(t, len(codeSnippets), 2)
+	assert.Equal(t, codeSnippets[0].QueryName, "SQL_Injection")
+	assert.Equal(t, len(codeSnippets[0].Locations), 2)
+	assert.Equal(t, codeSnippets[0].Locations[1].Name, "execute")
+	assert.Equal(t, codeSnippets[1].Locations[0].FileName, "main.tf")
+}
+
+func TestCollectCodeSnippetsLimit(t *testing.T) {
+	results := &wrappers.ScanResultsCollection{}
+	for i := 0; i < maxSummaryCodeSnippets+1; i++ {
+		results.Results = append(results.Results, &wrappers.ScanResult{
+			Type:           "kics",
+			ScanResultData: wrappers.ScanResultData{Filename: "main.tf", Line: 1, Snippet: &wrappers.SourceSnippet{Line: 1}},
+		})
+	}
+
+	assert.Equal(t, len(collectCodeSnippets(results)), maxSummaryCodeSnippets)
+}
+
+func TestCodeSnippetFence(t *testing.T) {
+	location := &wrappers.CodeSnippetLocation{Snippet: &wrappers.SourceSnippet{Lines: []string{"plain"}}}
+	assert.Equal(t, location.Fence(), "```")
+	location.Snippet.Lines = append(location.Snippet.Lines, "doc := `a ```` b`")
+	assert.Equal(t, location.Fence(), "`````")
+}
+
+func TestWriteMarkdownSummaryWithCodeSnippets(t *testing.T) {
+	results := createSnippetsTestResults()
+	addResultsSnippets(results, createSnippetsSourceDir(t), 0)
+	containersIssues := 0
+	summary := &wrappers.ResultSummary{Status: "Completed", ContainersIssues: &containersIssues, CodeSnippets: collectCodeSnippets(results)}
+	targetFile := filepath.Join(t.TempDir(), "summary.md")
+
+	err := writeMarkdownSummary(targetFile, summary)
+
+	assert.NilError(t, err)
+	markdown, err := os.ReadFile(targetFile)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(markdown), "### Code Snippets"))
+	assert.Assert(t, strings.Contains(string(markdown), "`/src/Main.java:5` execute"))
+	assert.Assert(t, strings.Contains(string(markdown), ">    5 | line 5"))
+}
+
+func TestAddSnippetsOptions(t *testing.T) {
+	params := make(map[string]string)
+	assert.NilError(t, addSnippetsOptions("", -1, params))
+	assert.Equal(t, len(params), 0)
+
+	err := addSnippetsOptions(t.TempDir(), -1, params)
+	assert.ErrorContains(t, err, "--snippets-context should be equal or higher than 0")
+
+	err = addSnippetsOptions(filepath.Join(t.TempDir(), "missing"), 1, params)
+	assert.ErrorContains(t, err, invalidSnippetsSourceDir)
+
+	sourceDir := t.TempDir()
+	assert.NilError(t, addSnippetsOptions(sourceDir, 2, params))
+	assert.Equal(t, params["snippets-source-dir"], sourceDir)
+	assert.Equal(t, params["snippets-context"], "2")
+}
@@ -0,0 +1,413 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	streamResultsFlagDescription = "Read the results page by page and stream them to the json, sarif, csv and summary reports, " +
+		"keeping the memory bounded for very large scans"
+	sarifSchema  = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// streamResultsFormats can be created without holding all the results in memory
+var streamResultsFormats = []string{
+	printer.FormatJSON,
+	printer.FormatSarif,
+	printer.FormatCSV,
+	printer.FormatSummaryConsole,
+	printer.FormatSummaryJSON,
+}
+
+var resultsCSVHeader = []string{
+	"ID", "Type", "Severity", "State", "Status", "Name", "File", "Line", "Package", "CWE", "Similarity ID", "First Found At",
+}
+
+// addStreamResultsOption validates the report formats can be streamed and carries --stream-results in the results params
+func addStreamResultsOption(cmd *cobra.Command, reportFormats string, params map[string]string) error {
+	streamResults, _ := cmd.Flags().GetBool(commonParams.StreamResultsFlag)
+	if !streamResults {
+		return nil
+	}
+	if _, ok := params[commonParams.SastRedundancyFlag]; ok {
+		return errors.Errorf("--%s can't be used with --%s", commonParams.StreamResultsFlag, commonParams.SastRedundancyFlag)
+	}
+	for _, reportFormat := range strings.Split(reportFormats, ",") {
+		reportFormat = strings.TrimSpace(reportFormat)
+		if reportFormat != "" && !verifyFormatsByReportList(streamResultsFormats, reportFormat) {
+			return errors.Errorf("report format %s can't be streamed, use one of %s", reportFormat, strings.Join(streamResultsFormats, ", "))
+		}
+	}
+	params[commonParams.StreamResultsFlag] = ""
+	return nil
+}
+
+// forEachResultsPage hands the results page by page when the wrapper supports it, all at once otherwise
+func forEachResultsPage(
+	resultsWrapper wrappers.ResultsWrapper,
+	params map[string]string,
+	handlePage func(page *wrappers.ScanResultsCollection) error,
+) error {
+	var webError *wrappers.WebError
+	var err error
+	if pagesWrapper, ok := resultsWrapper.(wrappers.ResultsPagesWrapper); ok {
+		webError, err = pagesWrapper.GetResultsPagesByScanID(params, handlePage)
+	} else {
+		var resultsModel *wrappers.ScanResultsCollection
+		resultsModel, webError, err = resultsWrapper.GetAllResultsByScanID(params)
+		if err == nil && webError == nil && resultsModel != nil {
+			err = handlePage(resultsModel)
+		}
+	}
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedListingResults)
+	}
+	if webError != nil {
+		return errors.Errorf("%s: CODE: %d, %s", failedListingResults, webError.Code, webError.Message)
+	}
+	return nil
+}
+
+// StreamResults reads the results of a scan page by page, enriching and filtering every page like ReadResults does
+func StreamResults(
+	resultsWrapper wrappers.ResultsWrapper,
+	exportWrapper wrappers.ExportWrapper,
+	scan *wrappers.ScanResponseModel,
+	params map[string]string,
+	handlePage func(page *wrappers.ScanResultsCollection) error,
+) error {
+	params[commonParams.ScanIDQueryParam] = scan.ID
+	delete(params, commonParams.StreamResultsFlag)
+	whereExpression, hasWhere := params[commonParams.WhereFlag]
+	delete(params, commonParams.WhereFlag)
+	snippetsSourceDir, hasSnippets := params[commonParams.SnippetsSourceDirFlag]
+	snippetsContext, _ := strconv.Atoi(params[commonParams.SnippetsContextFlag])
+	delete(params, commonParams.SnippetsSourceDirFlag)
+	delete(params, commonParams.SnippetsContextFlag)
+	baseScanID, newOnly := params[newOnlyBaseScanParam]
+	delete(params, newOnlyBaseScanParam)
+
+	var baseKeys map[string]bool
+	var err error
+	if newOnly {
+		baseKeys, err = getBaseResultKeys(resultsWrapper, baseScanID)
+		if err != nil {
+			return err
+		}
+	}
+	scaExport, err := getScaExportModels(exportWrapper, scan)
+	if err != nil {
+		return err
+	}
+	return forEachResultsPage(resultsWrapper, params, func(page *wrappers.ScanResultsCollection) error {
+		page = scaExport.enrich(scan, page)
+		if newOnly {
+			page = filterResultsByBaseKeys(page, baseKeys)
+		}
+		if hasWhere {
+			var filterErr error
+			page, filterErr = FilterResultsByWhereExpression(page, whereExpression)
+			if filterErr != nil {
+				return filterErr
+			}
+		}
+		if hasSnippets {
+			addResultsSnippets(page, snippetsSourceDir, snippetsContext)
+		}
+		page.ScanID = scan.ID
+		return handlePage(page)
+	})
+}
+
+// createStreamedScanReport writes the reports while the results are read, the summary is counted along
+func createStreamedScanReport(
+	resultsWrapper wrappers.ResultsWrapper,
+	exportWrapper wrappers.ExportWrapper,
+	scan *wrappers.ScanResponseModel,
+	summary *wrappers.ResultSummary,
+	reportList []string,
+	targetFile,
+	targetPath string,
+	params map[string]string,
+	finishSummary func(summary *wrappers.ResultSummary) (*wrappers.ResultSummary, error),
+	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+) error {
+	var writers []resultsStreamWriter
+	defer func() {
+		for _, writer := range writers {
+			writer.abort()
+		}
+	}()
+	for _, reportType := range reportList {
+		var writer resultsStreamWriter
+		var err error
+		switch {
+		case printer.IsFormat(reportType, printer.FormatJSON) && isValidScanStatus(summary.Status, printer.FormatJSON):
+			writer, err = newJSONStreamWriter(createTargetName(targetFile, targetPath, printer.FormatJSON))
+		case printer.IsFormat(reportType, printer.FormatSarif) && isValidScanStatus(summary.Status, printer.FormatSarif):
+			writer, err = newSarifStreamWriter(createTargetName(targetFile, targetPath, printer.FormatSarif))
+		case printer.IsFormat(reportType, printer.FormatCSV) && isValidScanStatus(summary.Status, printer.FormatCSV):
+			writer, err = newCSVStreamWriter(createTargetName(targetFile, targetPath, printer.FormatCSV))
+		}
+		if err != nil {
+			return err
+		}
+		if writer != nil {
+			writers = append(writers, writer)
+		}
+	}
+
+	err := StreamResults(resultsWrapper, exportWrapper, scan, params, func(page *wrappers.ScanResultsCollection) error {
+		for _, result := range page.Results {
+			countResult(summary, result)
+		}
+		for _, writer := range writers {
+			if writeErr := writer.writePage(page); writeErr != nil {
+				return writeErr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for len(writers) > 0 {
+		err = writers[0].close(scan.ID)
+		writers = writers[1:]
+		if err != nil {
+			return err
+		}
+	}
+
+	if verifyFormatsByReportList(reportList, summaryFormats...) {
+		summary, err = finishSummary(summary)
+		if err != nil {
+			return err
+		}
+	}
+	for _, reportType := range reportList {
+		if verifyFormatsByReportList([]string{reportType}, printer.FormatJSON, printer.FormatSarif, printer.FormatCSV) {
+			continue
+		}
+		err = createReport(reportType, "", "", "", targetFile, targetPath, &wrappers.ScanResultsCollection{}, summary,
+			nil, nil, featureFlagsWrapper, "")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resultsStreamWriter writes a report a page of results at a time
+type resultsStreamWriter interface {
+	writePage(page *wrappers.ScanResultsCollection) error
+	close(scanID string) error
+	abort()
+}
+
+// streamFile is the buffered target file of a stream writer
+type streamFile struct {
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func createStreamFile(targetFile string) (*streamFile, error) {
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: failed to create target file  ", failedGettingAll)
+	}
+	return &streamFile{file: f, writer: bufio.NewWriter(f)}, nil
+}
+
+func (s *streamFile) close() error {
+	err := s.writer.Flush()
+	closeErr := s.file.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+func (s *streamFile) abort() {
+	_ = s.file.Close()
+}
+
+// jsonStreamWriter writes the same document as exportJSONResults
+type jsonStreamWriter struct {
+	*streamFile
+	count uint
+}
+
+func newJSONStreamWriter(targetFile string) (*jsonStreamWriter, error) {
+	log.Println("Creating JSON Report: ", targetFile)
+	f, err := createStreamFile(targetFile)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = f.writer.WriteString(`{"results":[`)
+	return &jsonStreamWriter{streamFile: f}, nil
+}
+
+func (w *jsonStreamWriter) writePage(page *wrappers.ScanResultsCollection) error {
+	decodeHTMLEntitiesInResults(page)
+	for _, result := range page.Results {
+		err := writeStreamItem(w.writer, w.count, result)
+		if err != nil {
+			return err
+		}
+		w.count++
+	}
+	return nil
+}
+
+func (w *jsonStreamWriter) close(scanID string) error {
+	_, _ = fmt.Fprintf(w.writer, "],\"totalCount\":%d,\"scanID\":%q}\n", w.count, scanID)
+	return w.streamFile.close()
+}
+
+// sarifStreamWriter writes the sarif results first and the rules seen along once all the results are written
+type sarifStreamWriter struct {
+	*streamFile
+	count   uint
+	ruleIDs map[interface{}]bool
+	rules   []wrappers.SarifDriverRule
+}
+
+func newSarifStreamWriter(targetFile string) (*sarifStreamWriter, error) {
+	log.Println("Creating SARIF Report: ", targetFile)
+	f, err := createStreamFile(targetFile)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprintf(f.writer, "{\"$schema\":%q,\"version\":%q,\"runs\":[{\"results\":[", sarifSchema, sarifVersion)
+	return &sarifStreamWriter{streamFile: f, ruleIDs: map[interface{}]bool{}, rules: []wrappers.SarifDriverRule{}}, nil
+}
+
+func (w *sarifStreamWriter) writePage(page *wrappers.ScanResultsCollection) error {
+	for _, result := range page.Results {
+		if rule := findRule(w.ruleIDs, result); rule != nil {
+			w.rules = append(w.rules, *rule)
+		}
+		for _, sarifResult := range findResult(result) {
+			err := writeStreamItem(w.writer, w.count, sarifResult)
+			if err != nil {
+				return err
+			}
+			w.count++
+		}
+	}
+	return nil
+}
+
+func (w *sarifStreamWriter) close(_ string) error {
+	var tool wrappers.SarifTool
+	tool.Driver.Name = wrappers.SarifName
+	tool.Driver.Version = wrappers.SarifVersion
+	tool.Driver.InformationURI = wrappers.SarifInformationURI
+	tool.Driver.Rules = w.rules
+	toolJSON, err := json.Marshal(tool)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to serialize results response ", failedGettingAll)
+	}
+	_, _ = fmt.Fprintf(w.writer, "],\"tool\":%s}]}\n", toolJSON)
+	return w.streamFile.close()
+}
+
+type csvStreamWriter struct {
+	*streamFile
+	csvWriter *csv.Writer
+}
+
+func newCSVStreamWriter(targetFile string) (*csvStreamWriter, error) {
+	log.Println("Creating CSV Report: ", targetFile)
+	f, err := createStreamFile(targetFile)
+	if err != nil {
+		return nil, err
+	}
+	csvWriter := csv.NewWriter(f.writer)
+	err = csvWriter.Write(resultsCSVHeader)
+	if err != nil {
+		f.abort()
+		return nil, err
+	}
+	return &csvStreamWriter{streamFile: f, csvWriter: csvWriter}, nil
+}
+
+func (w *csvStreamWriter) writePage(page *wrappers.ScanResultsCollection) error {
+	for _, result := range page.Results {
+		err := w.csvWriter.Write(resultCSVRecord(result))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *csvStreamWriter) close(_ string) error {
+	w.csvWriter.Flush()
+	if err := w.csvWriter.Error(); err != nil {
+		return err
+	}
+	return w.streamFile.close()
+}
+
+func resultCSVRecord(result *wrappers.ScanResult) []string {
+	data := result.ScanResultData
+	fileName := data.Filename
+	line := data.Line
+	if len(data.Nodes) > 0 && data.Nodes[0] != nil {
+		fileName = data.Nodes[0].FileName
+		line = data.Nodes[0].Line
+	}
+	if fileName == "" {
+		fileName = data.ImageFilePath
+	}
+	lineValue := ""
+	if line > 0 {
+		lineValue = strconv.FormatUint(uint64(line), 10)
+	}
+	cwe := ""
+	if result.VulnerabilityDetails.CweID != nil {
+		cwe = fmt.Sprint(result.VulnerabilityDetails.CweID)
+	}
+	return []string{
+		result.ID,
+		strings.TrimSpace(result.Type),
+		result.Severity,
+		result.State,
+		result.Status,
+		slaResultName(result),
+		fileName,
+		lineValue,
+		data.PackageIdentifier,
+		cwe,
+		result.SimilarityID,
+		result.FirstFoundAt,
+	}
+}
+
+func writeStreamItem(writer *bufio.Writer, index uint, item interface{}) error {
+	itemJSON, err := json.Marshal(item)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to serialize results response ", failedGettingAll)
+	}
+	if index > 0 {
+		_ = writer.WriteByte(',')
+	}
+	_, err = writer.Write(itemJSON)
+	return err
+}
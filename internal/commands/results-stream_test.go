@@ -0,0 +1,122 @@
+//go:build !integration
+
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"gotest.tools/assert"
+)
+
+// pagedResultsWrapper hands the mock results two at a time
+type pagedResultsWrapper struct {
+	mock.ResultsMockWrapper
+	pages int
+}
+
+func (r *pagedResultsWrapper) GetResultsPagesByScanID(
+	queryParams map[string]string,
+	handlePage func(page *wrappers.ScanResultsCollection) error,
+) (*wrappers.WebError, error) {
+	results, webError, err := r.GetAllResultsByScanID(queryParams)
+	if err != nil || webError != nil {
+		return webError, err
+	}
+	for start := 0; start < len(results.Results); start += 2 {
+		end := start + 2
+		if end > len(results.Results) {
+			end = len(results.Results)
+		}
+		r.pages++
+		err = handlePage(&wrappers.ScanResultsCollection{Results: results.Results[start:end], TotalCount: results.TotalCount})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func TestStreamResults(t *testing.T) {
+	resultsWrapper := &pagedResultsWrapper{}
+	scan := &wrappers.ScanResponseModel{ID: "MOCK", Engines: []string{params.SastType}}
+	var streamed []*wrappers.ScanResult
+	err := StreamResults(resultsWrapper, &mock.ExportMockWrapper{}, scan, map[string]string{params.WhereFlag: "type == sast"},
+		func(page *wrappers.ScanResultsCollection) error {
+			assert.Equal(t, page.ScanID, "MOCK")
+			streamed = append(streamed, page.Results...)
+			return nil
+		})
+	assert.NilError(t, err)
+	assert.Assert(t, resultsWrapper.pages > 1)
+	assert.Assert(t, len(streamed) > 0)
+	for _, result := range streamed {
+		assert.Equal(t, result.Type, params.SastType)
+	}
+}
+
+func TestCreateStreamedScanReport(t *testing.T) {
+	scan := &wrappers.ScanResponseModel{ID: "MOCK", Status: "Completed", Engines: []string{params.SastType, params.KicsType}}
+	expected, err := ReadResults(&mock.ResultsMockWrapper{}, &mock.ExportMockWrapper{}, scan, map[string]string{})
+	assert.NilError(t, err)
+
+	summary, err := convertScanToResultsSummary(scan, &mock.ResultsMockWrapper{})
+	assert.NilError(t, err)
+	finishSummary := func(summary *wrappers.ResultSummary) (*wrappers.ResultSummary, error) {
+		return summary, nil
+	}
+	err = createStreamedScanReport(&pagedResultsWrapper{}, &mock.ExportMockWrapper{}, scan, summary,
+		[]string{printer.FormatJSON, printer.FormatSarif, printer.FormatCSV}, "cx_stream", ".", map[string]string{}, finishSummary, &mock.FeatureFlagsMockWrapper{})
+	assert.NilError(t, err)
+	defer removeFile(t, "cx_stream", printer.FormatJSON)
+	defer removeFile(t, "cx_stream", printer.FormatSarif)
+	defer removeFile(t, "cx_stream", printer.FormatCSV)
+	assert.Equal(t, summary.SastIssues+summary.KicsIssues > 0, true)
+
+	content, err := os.ReadFile("cx_stream.json")
+	assert.NilError(t, err)
+	streamed := wrappers.ScanResultsCollection{}
+	assert.NilError(t, json.Unmarshal(content, &streamed))
+	assert.Equal(t, streamed.ScanID, "MOCK")
+	assert.Equal(t, int(streamed.TotalCount), len(expected.Results))
+	assert.Equal(t, len(streamed.Results), len(expected.Results))
+
+	content, err = os.ReadFile("cx_stream.sarif")
+	assert.NilError(t, err)
+	sarif := wrappers.SarifResultsCollection{}
+	assert.NilError(t, json.Unmarshal(content, &sarif))
+	expectedSarif := convertCxResultsToSarif(expected)
+	assert.Equal(t, sarif.Version, expectedSarif.Version)
+	assert.Equal(t, len(sarif.Runs[0].Results), len(expectedSarif.Runs[0].Results))
+	assert.Equal(t, len(sarif.Runs[0].Tool.Driver.Rules), len(expectedSarif.Runs[0].Tool.Driver.Rules))
+
+	f, err := os.Open("cx_stream.csv")
+	assert.NilError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	assert.NilError(t, err)
+	assert.Equal(t, len(records), len(expected.Results)+1)
+	assert.DeepEqual(t, records[0], resultsCSVHeader)
+}
+
+func TestCreateStreamedScanReport_PendingScan(t *testing.T) {
+	scan := &wrappers.ScanResponseModel{ID: "MOCK", Status: "Running", Engines: []string{params.SastType}}
+	summary := &wrappers.ResultSummary{ScanID: scan.ID, Status: string(scan.Status)}
+	finishSummary := func(summary *wrappers.ResultSummary) (*wrappers.ResultSummary, error) {
+		return summary, nil
+	}
+	err := createStreamedScanReport(&pagedResultsWrapper{}, &mock.ExportMockWrapper{}, scan, summary,
+		[]string{printer.FormatJSON, printer.FormatSarif, printer.FormatCSV}, "cx_stream_pending", ".", map[string]string{}, finishSummary,
+		&mock.FeatureFlagsMockWrapper{})
+	assert.NilError(t, err)
+	for _, format := range []string{printer.FormatJSON, printer.FormatSarif, printer.FormatCSV} {
+		_, err = os.Stat("cx_stream_pending." + format)
+		assert.Assert(t, os.IsNotExist(err), "no %s report is created for a pending scan", format)
+	}
+}
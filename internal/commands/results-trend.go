@@ -0,0 +1,388 @@
+package commands
+
+import (
+	"encoding/csv"
+	"html/template"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/services"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedCreatingTrend  = "Failed creating results trend"
+	trendDefaultName     = "cx_trend"
+	trendDefaultLast     = 10
+	trendAllEngines      = "all"
+	trendChartHeight     = 200
+	newResultStatus      = "NEW"
+	hoursPerDay          = 24
+	trendDaysPrecision   = 100
+	trendFoundAtLayout   = time.RFC3339
+	trendNoScansMessage  = "no completed scans found for project %s"
+	trendInvalidLastFlag = "--%s should be higher than 0"
+
+	failedGettingScanSummaries = "Failed getting scan summaries"
+	trendIncludeStatusCounters = "include-status-counters"
+	trendIncludeQueries        = "include-queries"
+	trendIncludeFiles          = "include-files"
+	trendApplyPredicates       = "apply-predicates"
+)
+
+func resultTrendSubCommand(resultsWrapper wrappers.ResultsWrapper, scanWrapper wrappers.ScansWrapper) *cobra.Command {
+	trendCmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Report the results trend of a project across scans",
+		Long: "The trend command walks the latest completed scans of a project branch and reports the severity counts per engine, " +
+			"the new and fixed issues of every scan and the mean time to remediate. The counts come from the scan summaries, " +
+			"the results are only read for the scans fixing issues.",
+		Example: heredoc.Doc(
+			`
+			$ cx results trend --project-id <project Id> --branch main --last 12 --report-format html,json
+		`,
+		),
+		RunE: runResultsTrendCommand(resultsWrapper, scanWrapper),
+	}
+	trendCmd.PersistentFlags().String(commonParams.ProjectIDFlag, "", "ID of the project")
+	err := trendCmd.MarkPersistentFlagRequired(commonParams.ProjectIDFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	trendCmd.PersistentFlags().String(commonParams.BranchFlag, "", "Branch of the scans, all branches when empty")
+	trendCmd.PersistentFlags().Int(commonParams.LastFlag, trendDefaultLast, "Number of latest scans to include")
+	addResultFormatFlag(trendCmd, printer.FormatJSON, printer.FormatHTML, printer.FormatCSV)
+	trendCmd.PersistentFlags().String(commonParams.TargetFlag, trendDefaultName, "Output file")
+	trendCmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
+	return trendCmd
+}
+
+func runResultsTrendCommand(resultsWrapper wrappers.ResultsWrapper, scanWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		branch, _ := cmd.Flags().GetString(commonParams.BranchFlag)
+		last, _ := cmd.Flags().GetInt(commonParams.LastFlag)
+		targetFile, _ := cmd.Flags().GetString(commonParams.TargetFlag)
+		targetPath, _ := cmd.Flags().GetString(commonParams.TargetPathFlag)
+		reportFormats, _ := cmd.Flags().GetString(commonParams.TargetFormatFlag)
+		if last <= 0 {
+			return errors.Errorf(trendInvalidLastFlag, commonParams.LastFlag)
+		}
+
+		scans, err := getLatestScans(scanWrapper, projectID, branch, last)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingTrend)
+		}
+		if len(scans) == 0 {
+			return errors.Errorf(trendNoScansMessage, projectID)
+		}
+		logger.PrintfIfVerbose("Results trend contains %d scans", len(scans))
+
+		trend, err := buildResultsTrend(projectID, branch, scans, resultsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedCreatingTrend)
+		}
+
+		err = createDirectory(targetPath)
+		if err != nil {
+			return err
+		}
+		for _, reportFormat := range strings.Split(reportFormats, ",") {
+			err = createTrendReport(strings.TrimSpace(reportFormat), targetFile, targetPath, trend)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// getLatestScans returns up to last completed scans of the branch, oldest first
+func getLatestScans(scanWrapper wrappers.ScansWrapper, projectID, branch string, last int) ([]wrappers.ScanResponseModel, error) {
+	params := map[string]string{
+		commonParams.ProjectIDQueryParam: projectID,
+		commonParams.StatusesQueryParam:  latestScanStatusesFilter,
+		commonParams.SortQueryParam:      latestScanSort,
+		commonParams.LimitQueryParam:     strconv.Itoa(last),
+	}
+	if branch != "" {
+		params[commonParams.BranchQueryParam] = branch
+	}
+	scansModel, errorModel, err := scanWrapper.Get(params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingAll)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+	}
+	if scansModel == nil {
+		return nil, nil
+	}
+	scans := scansModel.Scans
+	if len(scans) > last {
+		scans = scans[:last]
+	}
+	for i, j := 0, len(scans)-1; i < j; i, j = i+1, j-1 {
+		scans[i], scans[j] = scans[j], scans[i]
+	}
+	return scans, nil
+}
+
+// buildResultsTrend counts the issues of every scan from the scan summaries. The results of two consecutive scans are
+// only read when issues were fixed between them, to measure the time they took to remediate.
+func buildResultsTrend(
+	projectID, branch string,
+	scans []wrappers.ScanResponseModel,
+	resultsWrapper wrappers.ResultsWrapper,
+) (*wrappers.ResultsTrend, error) {
+	trend := &wrappers.ResultsTrend{
+		CreatedAt:  time.Now().Format(summaryCreatedAtLayout),
+		ProjectID:  projectID,
+		BranchName: branch,
+	}
+	summaries, err := getTrendScanSummaries(resultsWrapper, scans)
+	if err != nil {
+		return nil, err
+	}
+	var remediationTime time.Duration
+	var previous *wrappers.ResultsTrendScan
+	var previousResults map[string]string
+	for i := range scans {
+		scan := &scans[i]
+		trendScan := countTrendScanSummary(scan, summaries[scan.ID])
+		var currentResults map[string]string
+		if previous != nil {
+			// The issues of the previous scan which didn't recur were fixed
+			trendScan.FixedIssues = previous.TotalIssues - (trendScan.TotalIssues - trendScan.NewIssues)
+			if trendScan.FixedIssues < 0 {
+				trendScan.FixedIssues = 0
+			}
+		}
+		if trendScan.FixedIssues > 0 {
+			if previousResults == nil {
+				previousResults, err = getTrendScanResults(resultsWrapper, previous.ScanID)
+				if err != nil {
+					return nil, err
+				}
+			}
+			currentResults, err = getTrendScanResults(resultsWrapper, scan.ID)
+			if err != nil {
+				return nil, err
+			}
+			trendScan.FixedIssues = 0
+			for key, foundAt := range previousResults {
+				if _, ok := currentResults[key]; ok {
+					continue
+				}
+				trendScan.FixedIssues++
+				firstFoundAt, parseErr := time.Parse(trendFoundAtLayout, foundAt)
+				if parseErr != nil || scan.CreatedAt.Before(firstFoundAt) {
+					continue
+				}
+				remediationTime += scan.CreatedAt.Sub(firstFoundAt)
+				trend.RemediatedIssues++
+			}
+		}
+		if trendScan.TotalIssues > trend.MaxIssues {
+			trend.MaxIssues = trendScan.TotalIssues
+		}
+		trend.Scans = append(trend.Scans, trendScan)
+		previous = trendScan
+		previousResults = currentResults
+	}
+	if trend.RemediatedIssues > 0 {
+		days := remediationTime.Hours() / hoursPerDay / float64(trend.RemediatedIssues)
+		trend.MeanTimeToRemediateDays = float64(int(days*trendDaysPrecision)) / trendDaysPrecision
+	}
+	return trend, nil
+}
+
+// getTrendScanSummaries reads the summaries of all the scans at once, indexed by scan id
+func getTrendScanSummaries(resultsWrapper wrappers.ResultsWrapper, scans []wrappers.ScanResponseModel) (map[string]*wrappers.ScanSumaries, error) {
+	scanIDs := make([]string, len(scans))
+	for i := range scans {
+		scanIDs[i] = scans[i].ID
+	}
+	summariesModel, errorModel, err := resultsWrapper.GetScanSummariesByScanIDS(map[string]string{
+		commonParams.ScanIDsQueryParam: strings.Join(scanIDs, ","),
+		trendIncludeStatusCounters:     "true",
+		trendIncludeQueries:            "false",
+		trendIncludeFiles:              "false",
+		trendApplyPredicates:           "true",
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingScanSummaries)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(services.ErrorCodeFormat, failedGettingScanSummaries, errorModel.Code, errorModel.Message)
+	}
+	summaries := make(map[string]*wrappers.ScanSumaries)
+	if summariesModel != nil {
+		for i := range summariesModel.ScansSummaries {
+			summaries[summariesModel.ScansSummaries[i].ScanID] = &summariesModel.ScansSummaries[i]
+		}
+	}
+	return summaries, nil
+}
+
+// countTrendScanSummary counts the issues of a scan per engine and severity from its summary
+func countTrendScanSummary(scan *wrappers.ScanResponseModel, summary *wrappers.ScanSumaries) *wrappers.ResultsTrendScan {
+	trendScan := &wrappers.ResultsTrendScan{
+		ScanID:        scan.ID,
+		ScanStatus:    string(scan.Status),
+		ScanCreatedAt: scan.CreatedAt.Format(summaryCreatedAtLayout),
+		EnginesIssues: make(map[string]*wrappers.ResultsTrendSeverities),
+	}
+	if summary == nil {
+		return trendScan
+	}
+	countTrendEngine(trendScan, commonParams.SastType, summary.SastCounters.SeverityCounters, summary.SastCounters.StatusCounters)
+	countTrendEngine(trendScan, commonParams.KicsType, summary.KicsCounters.SeverityCounters, summary.KicsCounters.StatusCounters)
+	countTrendEngine(trendScan, commonParams.ScaType, summary.ScaCounters.SeverityCounters, summary.ScaCounters.StatusCounters)
+	countTrendEngine(trendScan, commonParams.ContainersType, summary.ScaContainersCounters.SeverityCounters,
+		summary.ScaContainersCounters.StatusCounters)
+	return trendScan
+}
+
+func countTrendEngine(
+	trendScan *wrappers.ResultsTrendScan,
+	engine string,
+	severityCounters []wrappers.SeverityCounters,
+	statusCounters []wrappers.StatusCounters,
+) {
+	if len(severityCounters) == 0 {
+		return
+	}
+	severities := &wrappers.ResultsTrendSeverities{}
+	trendScan.EnginesIssues[engine] = severities
+	for _, counter := range severityCounters {
+		trendScan.TotalIssues += counter.Counter
+		switch strings.ToLower(counter.Severity) {
+		case criticalLabel:
+			trendScan.CriticalIssues += counter.Counter
+			severities.Critical += counter.Counter
+		case highLabel:
+			trendScan.HighIssues += counter.Counter
+			severities.High += counter.Counter
+		case mediumLabel:
+			trendScan.MediumIssues += counter.Counter
+			severities.Medium += counter.Counter
+		case lowLabel:
+			trendScan.LowIssues += counter.Counter
+			severities.Low += counter.Counter
+		case infoLabel:
+			trendScan.InfoIssues += counter.Counter
+			severities.Info += counter.Counter
+		}
+	}
+	for _, counter := range statusCounters {
+		if strings.EqualFold(counter.Status, newResultStatus) {
+			trendScan.NewIssues += counter.Counter
+		}
+	}
+}
+
+// getTrendScanResults returns when the exploitable results of a scan were first found, indexed by engine and similarity id
+func getTrendScanResults(resultsWrapper wrappers.ResultsWrapper, scanID string) (map[string]string, error) {
+	firstFoundAt := make(map[string]string)
+	err := forEachResultsPage(resultsWrapper, map[string]string{commonParams.ScanIDQueryParam: scanID}, func(page *wrappers.ScanResultsCollection) error {
+		for _, result := range page.Results {
+			if !isExploitable(result.State) {
+				continue
+			}
+			key := result.SimilarityID
+			if key == "" {
+				key = result.ID
+			}
+			firstFoundAt[strings.TrimSpace(result.Type)+"/"+key] = result.FirstFoundAt
+		}
+		return nil
+	})
+	return firstFoundAt, err
+}
+
+func createTrendReport(format, targetFile, targetPath string, trend *wrappers.ResultsTrend) error {
+	if printer.IsFormat(format, printer.FormatJSON) {
+		return writeJSONReport(createTargetName(targetFile, targetPath, printer.FormatJSON), trend)
+	}
+	if printer.IsFormat(format, printer.FormatHTML) {
+		return writeTrendHTML(createTargetName(targetFile, targetPath, printer.FormatHTML), trend)
+	}
+	if printer.IsFormat(format, printer.FormatCSV) {
+		return writeTrendCSV(createTargetName(targetFile, targetPath, printer.FormatCSV), trend)
+	}
+	return errors.Errorf("bad report format %s", format)
+}
+
+func writeTrendHTML(targetFile string, trend *wrappers.ResultsTrend) error {
+	log.Println("Creating Trend Report: ", targetFile)
+	funcMap := template.FuncMap{
+		"barHeight": func(count, maxCount int) int {
+			if maxCount == 0 {
+				return 0
+			}
+			return count * trendChartHeight / maxCount
+		},
+	}
+	trendTemplate, err := template.New("trendTemplate").Funcs(funcMap).Parse(wrappers.ResultsTrendTemplate)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return trendTemplate.ExecuteTemplate(f, "ResultsTrendTemplate", trend)
+}
+
+func writeTrendCSV(targetFile string, trend *wrappers.ResultsTrend) error {
+	log.Println("Creating Trend Report: ", targetFile)
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	writer := csv.NewWriter(f)
+	_ = writer.Write([]string{
+		"Scan ID", "Scan Created At", "Scan Status", "Engine", "Critical", "High", "Medium", "Low", "Info", "Total", "New", "Fixed",
+	})
+	for _, scan := range trend.Scans {
+		_ = writer.Write([]string{
+			scan.ScanID, scan.ScanCreatedAt, scan.ScanStatus, trendAllEngines,
+			strconv.Itoa(scan.CriticalIssues), strconv.Itoa(scan.HighIssues), strconv.Itoa(scan.MediumIssues),
+			strconv.Itoa(scan.LowIssues), strconv.Itoa(scan.InfoIssues), strconv.Itoa(scan.TotalIssues),
+			strconv.Itoa(scan.NewIssues), strconv.Itoa(scan.FixedIssues),
+		})
+		for _, engine := range sortedTrendEngines(scan.EnginesIssues) {
+			severities := scan.EnginesIssues[engine]
+			total := severities.Critical + severities.High + severities.Medium + severities.Low + severities.Info
+			_ = writer.Write([]string{
+				scan.ScanID, scan.ScanCreatedAt, scan.ScanStatus, engine,
+				strconv.Itoa(severities.Critical), strconv.Itoa(severities.High), strconv.Itoa(severities.Medium),
+				strconv.Itoa(severities.Low), strconv.Itoa(severities.Info), strconv.Itoa(total), "", "",
+			})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func sortedTrendEngines(enginesIssues map[string]*wrappers.ResultsTrendSeverities) []string {
+	engines := make([]string, 0, len(enginesIssues))
+	for engine := range enginesIssues {
+		engines = append(engines, engine)
+	}
+	sort.Strings(engines)
+	return engines
+}
@@ -0,0 +1,462 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+const (
+	invalidWhereExpression = "Invalid where expression"
+	whereFileField         = "file"
+	whereOpEquals          = "=="
+	whereOpNotEquals       = "!="
+	whereOpLower           = "<"
+	whereOpLowerEqual      = "<="
+	whereOpGreater         = ">"
+	whereOpGreaterEqual    = ">="
+	whereOpIn              = "in"
+	whereOpMatches         = "matches"
+	whereOpContains        = "contains"
+)
+
+// whereExpression is a compiled client side filter over ScanResult fields, e.g.
+// severity in (HIGH,CRITICAL) && data.languageName == "Java" && !(file matches "test/**")
+type whereExpression interface {
+	evaluate(fields *whereFields) bool
+}
+
+type whereAnd struct {
+	left, right whereExpression
+}
+
+type whereOr struct {
+	left, right whereExpression
+}
+
+type whereNot struct {
+	expression whereExpression
+}
+
+type whereComparison struct {
+	field    string
+	operator string
+	values   []string
+	pattern  *regexp.Regexp
+}
+
+func (e *whereAnd) evaluate(fields *whereFields) bool {
+	return e.left.evaluate(fields) && e.right.evaluate(fields)
+}
+
+func (e *whereOr) evaluate(fields *whereFields) bool {
+	return e.left.evaluate(fields) || e.right.evaluate(fields)
+}
+
+func (e *whereNot) evaluate(fields *whereFields) bool {
+	return !e.expression.evaluate(fields)
+}
+
+// evaluate is true when any value of the field matches, != is the negation of == so it also holds for a missing field
+func (e *whereComparison) evaluate(fields *whereFields) bool {
+	matched := false
+	for _, actual := range fields.lookup(e.field) {
+		if e.matchValue(actual) {
+			matched = true
+			break
+		}
+	}
+	if e.operator == whereOpNotEquals {
+		return !matched
+	}
+	return matched
+}
+
+func (e *whereComparison) matchValue(actual string) bool {
+	switch e.operator {
+	case whereOpEquals, whereOpNotEquals:
+		return strings.EqualFold(actual, e.values[0])
+	case whereOpIn:
+		for _, value := range e.values {
+			if strings.EqualFold(actual, value) {
+				return true
+			}
+		}
+		return false
+	case whereOpContains:
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(e.values[0]))
+	case whereOpMatches:
+		return e.pattern.MatchString(strings.TrimPrefix(actual, "/"))
+	default:
+		return compareOrdered(actual, e.values[0], e.operator)
+	}
+}
+
+func compareOrdered(actual, expected, operator string) bool {
+	var cmp int
+	actualNumber, errActual := strconv.ParseFloat(actual, 64)
+	expectedNumber, errExpected := strconv.ParseFloat(expected, 64)
+	if errActual == nil && errExpected == nil {
+		switch {
+		case actualNumber < expectedNumber:
+			cmp = -1
+		case actualNumber > expectedNumber:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(actual, expected)
+	}
+	switch operator {
+	case whereOpLower:
+		return cmp < 0
+	case whereOpLowerEqual:
+		return cmp <= 0
+	case whereOpGreater:
+		return cmp > 0
+	case whereOpGreaterEqual:
+		return cmp >= 0
+	}
+	return false
+}
+
+// whereFields lazily exposes the json representation of a result, so fields are addressed
+// by the same names users see in the json report (e.g. data.languageName)
+type whereFields struct {
+	result *wrappers.ScanResult
+	values map[string]interface{}
+}
+
+func (f *whereFields) lookup(field string) []string {
+	if strings.EqualFold(field, whereFileField) {
+		return resultFileNames(f.result)
+	}
+	if f.values == nil {
+		f.values = make(map[string]interface{})
+		raw, err := json.Marshal(f.result)
+		if err == nil {
+			_ = json.Unmarshal(raw, &f.values)
+		}
+	}
+	var found []string
+	collectFieldValues(f.values, strings.Split(field, "."), &found)
+	return found
+}
+
+func collectFieldValues(value interface{}, path []string, found *[]string) {
+	switch typed := value.(type) {
+	case []interface{}:
+		for _, item := range typed {
+			collectFieldValues(item, path, found)
+		}
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return
+		}
+		for key, child := range typed {
+			if strings.EqualFold(key, path[0]) {
+				collectFieldValues(child, path[1:], found)
+			}
+		}
+	case nil:
+		return
+	default:
+		if len(path) == 0 {
+			*found = append(*found, fmt.Sprint(typed))
+		}
+	}
+}
+
+// resultFileNames returns the files a result points to, regardless of the engine
+func resultFileNames(result *wrappers.ScanResult) []string {
+	var files []string
+	for _, node := range result.ScanResultData.Nodes {
+		if node != nil && node.FileName != "" {
+			files = append(files, node.FileName)
+		}
+	}
+	if result.ScanResultData.Filename != "" {
+		files = append(files, result.ScanResultData.Filename)
+	}
+	if result.ScanResultData.ImageFilePath != "" {
+		files = append(files, result.ScanResultData.ImageFilePath)
+	}
+	return files
+}
+
+func parseWhereExpression(expression string) (whereExpression, error) {
+	tokens, err := tokenizeWhereExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	parser := &whereParser{tokens: tokens}
+	parsed, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !parser.done() {
+		return nil, errors.Errorf("%s: unexpected token '%s'", invalidWhereExpression, parser.peek().text)
+	}
+	return parsed, nil
+}
+
+func FilterResultsByWhereExpression(
+	resultsModel *wrappers.ScanResultsCollection,
+	expression string,
+) (*wrappers.ScanResultsCollection, error) {
+	compiled, err := parseWhereExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*wrappers.ScanResult
+	for _, result := range resultsModel.Results {
+		if compiled.evaluate(&whereFields{result: result}) {
+			filtered = append(filtered, result)
+		}
+	}
+	resultsModel.Results = filtered
+	resultsModel.TotalCount = uint(len(filtered))
+	return resultsModel, nil
+}
+
+type whereTokenKind int
+
+const (
+	whereTokenWord whereTokenKind = iota
+	whereTokenString
+	whereTokenSymbol
+)
+
+type whereToken struct {
+	kind whereTokenKind
+	text string
+}
+
+func tokenizeWhereExpression(expression string) ([]whereToken, error) {
+	var tokens []whereToken
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			var text strings.Builder
+			for ; end < len(runes) && runes[end] != r; end++ {
+				if runes[end] == '\\' && end+1 < len(runes) {
+					end++
+				}
+				text.WriteRune(runes[end])
+			}
+			if end >= len(runes) {
+				return nil, errors.Errorf("%s: unterminated string", invalidWhereExpression)
+			}
+			tokens = append(tokens, whereToken{kind: whereTokenString, text: text.String()})
+			i = end + 1
+		case strings.ContainsRune("()!,<>=&|", r):
+			symbol := string(r)
+			if i+1 < len(runes) {
+				pair := string(runes[i : i+2])
+				if pair == "&&" || pair == "||" || pair == whereOpEquals || pair == whereOpNotEquals ||
+					pair == whereOpLowerEqual || pair == whereOpGreaterEqual {
+					symbol = pair
+				}
+			}
+			if symbol == "&" || symbol == "|" || symbol == "=" {
+				return nil, errors.Errorf("%s: unexpected '%s'", invalidWhereExpression, symbol)
+			}
+			tokens = append(tokens, whereToken{kind: whereTokenSymbol, text: symbol})
+			i += len(symbol)
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune("()!,<>=&|\"'", runes[end]) {
+				end++
+			}
+			tokens = append(tokens, whereToken{kind: whereTokenWord, text: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type whereParser struct {
+	tokens   []whereToken
+	position int
+}
+
+func (p *whereParser) done() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *whereParser) peek() whereToken {
+	if p.done() {
+		return whereToken{}
+	}
+	return p.tokens[p.position]
+}
+
+func (p *whereParser) acceptSymbol(symbol string) bool {
+	if !p.done() && p.peek().kind == whereTokenSymbol && p.peek().text == symbol {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func (p *whereParser) parseOr() (whereExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptSymbol("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &whereOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *whereParser) parseAnd() (whereExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptSymbol("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &whereAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *whereParser) parseUnary() (whereExpression, error) {
+	if p.acceptSymbol("!") {
+		expression, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &whereNot{expression: expression}, nil
+	}
+	if p.acceptSymbol("(") {
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptSymbol(")") {
+			return nil, errors.Errorf("%s: missing ')'", invalidWhereExpression)
+		}
+		return expression, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *whereParser) parseComparison() (whereExpression, error) {
+	field := p.peek()
+	if p.done() || field.kind != whereTokenWord {
+		return nil, errors.Errorf("%s: expected a field name", invalidWhereExpression)
+	}
+	p.position++
+	operator := p.peek()
+	if p.done() {
+		return nil, errors.Errorf("%s: missing operator after '%s'", invalidWhereExpression, field.text)
+	}
+	p.position++
+	comparison := &whereComparison{field: field.text, operator: strings.ToLower(operator.text)}
+	switch {
+	case operator.kind == whereTokenWord && comparison.operator == whereOpIn:
+		values, err := p.parseValueList()
+		if err != nil {
+			return nil, err
+		}
+		comparison.values = values
+	case operator.kind == whereTokenWord && (comparison.operator == whereOpMatches || comparison.operator == whereOpContains):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		comparison.values = []string{value}
+		if comparison.operator == whereOpMatches {
+			comparison.pattern = globToRegexp(value)
+		}
+	case operator.kind == whereTokenSymbol && isWhereComparisonSymbol(operator.text):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		comparison.values = []string{value}
+	default:
+		return nil, errors.Errorf("%s: unknown operator '%s'", invalidWhereExpression, operator.text)
+	}
+	return comparison, nil
+}
+
+func (p *whereParser) parseValueList() ([]string, error) {
+	if !p.acceptSymbol("(") {
+		return nil, errors.Errorf("%s: expected '(' after '%s'", invalidWhereExpression, whereOpIn)
+	}
+	var values []string
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		if p.acceptSymbol(")") {
+			return values, nil
+		}
+		if !p.acceptSymbol(",") {
+			return nil, errors.Errorf("%s: expected ',' or ')' in list", invalidWhereExpression)
+		}
+	}
+}
+
+func (p *whereParser) parseValue() (string, error) {
+	value := p.peek()
+	if p.done() || value.kind == whereTokenSymbol {
+		return "", errors.Errorf("%s: expected a value", invalidWhereExpression)
+	}
+	p.position++
+	return value.text, nil
+}
+
+func isWhereComparisonSymbol(symbol string) bool {
+	switch symbol {
+	case whereOpEquals, whereOpNotEquals, whereOpLower, whereOpLowerEqual, whereOpGreater, whereOpGreaterEqual:
+		return true
+	}
+	return false
+}
+
+// globToRegexp converts a glob where '**' crosses directories and '*' does not
+func globToRegexp(glob string) *regexp.Regexp {
+	glob = strings.TrimPrefix(glob, "/")
+	var pattern strings.Builder
+	pattern.WriteString("(?i)^")
+	for i := 0; i < len(glob); i++ {
+		switch glob[i] {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				pattern.WriteString(".*")
+				i++
+			} else {
+				pattern.WriteString("[^/]*")
+			}
+		case '?':
+			pattern.WriteString("[^/]")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(glob[i])))
+		}
+	}
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String())
+}
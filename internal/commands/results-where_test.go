@@ -0,0 +1,104 @@
+//go:build !integration
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func createWhereTestResults() *wrappers.ScanResultsCollection {
+	return &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			{
+				Type:     "sast",
+				ID:       "1",
+				Severity: "HIGH",
+				ScanResultData: wrappers.ScanResultData{
+					LanguageName: "Java",
+					Nodes: []*wrappers.ScanResultNode{
+						{FileName: "/src/main/App.java", Line: 10},
+						{FileName: "/src/main/Util.java", Line: 20},
+					},
+				},
+			},
+			{
+				Type:     "sast",
+				ID:       "2",
+				Severity: "CRITICAL",
+				ScanResultData: wrappers.ScanResultData{
+					LanguageName: "Java",
+					Nodes:        []*wrappers.ScanResultNode{{FileName: "/test/unit/AppTest.java", Line: 3}},
+				},
+			},
+			{
+				Type:     "sast",
+				ID:       "3",
+				Severity: "MEDIUM",
+				ScanResultData: wrappers.ScanResultData{
+					LanguageName: "Go",
+					Nodes:        []*wrappers.ScanResultNode{{FileName: "/cmd/main.go", Line: 1}},
+				},
+			},
+			{
+				Type:     "kics",
+				ID:       "4",
+				Severity: "LOW",
+				ScanResultData: wrappers.ScanResultData{
+					Filename: "/deploy/Dockerfile",
+					Line:     7,
+				},
+			},
+		},
+		TotalCount: 4,
+	}
+}
+
+func TestFilterResultsByWhereExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{"Example from help", `severity in (HIGH,CRITICAL) && data.languageName == "Java" && !(file matches "test/**")`, []string{"1"}},
+		{"Case insensitive equality", "severity == medium", []string{"3"}},
+		{"Or has lower precedence than and", "type == kics || severity == high && data.languageName == Go", []string{"4"}},
+		{"Not equals", "type != sast", []string{"4"}},
+		{"Not equals on a missing field", "data.unknown != x", []string{"1", "2", "3", "4"}},
+		{"Not equals on a multi-valued field", "data.nodes.fileName != '/src/main/Util.java'", []string{"2", "3", "4"}},
+		{"Nested fields in arrays", "data.nodes.fileName contains 'main'", []string{"1", "3"}},
+		{"Numeric comparison", "data.line >= 5", []string{"4"}},
+		{"File glob on kics results", "file matches '**/Dockerfile'", []string{"4"}},
+		{"Unknown field matches nothing", "data.unknown == x", nil},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			results, err := FilterResultsByWhereExpression(createWhereTestResults(), test.expression)
+			assert.NilError(t, err)
+			var ids []string
+			for _, result := range results.Results {
+				ids = append(ids, result.ID)
+			}
+			assert.DeepEqual(t, ids, test.expected)
+			assert.Equal(t, results.TotalCount, uint(len(test.expected)))
+		})
+	}
+}
+
+func TestParseWhereExpression_InvalidExpressions(t *testing.T) {
+	for _, expression := range []string{
+		"severity",
+		"severity = high",
+		"severity == high &&",
+		"(severity == high",
+		"severity in HIGH",
+		"severity like high",
+		"file matches 'test/**",
+	} {
+		_, err := parseWhereExpression(expression)
+		assertError(t, err, invalidWhereExpression)
+	}
+}
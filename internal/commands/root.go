@@ -12,6 +12,7 @@ import (
 	"github.com/checkmarx/ast-cli/internal/logger"
 	"github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/wrappers/bitbucketserver"
+	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
 	"github.com/pkg/errors"
 
 	"github.com/checkmarx/ast-cli/internal/wrappers"
@@ -100,13 +101,25 @@ func NewAstCLI(
 
 	// This monitors and traps situations where "extra/garbage" commands
 	// are passed to Cobra.
-	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		profile := strings.TrimSpace(os.Getenv(params.ProfileEnv))
+		if cmd.Flags().Changed(params.ProfileFlag) {
+			profile, _ = cmd.Flags().GetString(params.ProfileFlag)
+		}
+		if profile != "" && profile != configuration.CurrentProfile() {
+			// configure creates the profile on its first change
+			err := configuration.LoadProfile(profile, isConfigureCommand(cmd))
+			if err != nil {
+				return err
+			}
+		}
 		PrintConfiguration()
 		// Need to check the __complete command to allow correct behavior of the autocomplete
 		if len(args) > 0 && cmd.Name() != params.Help && cmd.Name() != "__complete" {
 			_ = cmd.Help()
 			os.Exit(0)
 		}
+		return nil
 	}
 	// Link the environment variable to the CLI argument(s).
 	_ = viper.BindPFlag(params.AccessKeyIDConfigKey, rootCmd.PersistentFlags().Lookup(params.AccessKeyIDFlag))
@@ -155,8 +168,11 @@ func NewAstCLI(
 		accessManagementWrapper,
 		featureFlagsWrapper,
 		containerResolverWrapper,
+		resultsPredicatesWrapper,
+	)
+	projectCmd := NewProjectCommand(
+		applicationsWrapper, projectsWrapper, scansWrapper, resultsWrapper, resultsPredicatesWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper, gitHubWrapper, gitLabWrapper, azureWrapper, bitBucketWrapper,
 	)
-	projectCmd := NewProjectCommand(applicationsWrapper, projectsWrapper, groupsWrapper, accessManagementWrapper, featureFlagsWrapper)
 
 	resultsCmd := NewResultsCommand(
 		resultsWrapper,
@@ -169,6 +185,10 @@ func NewAstCLI(
 		scsScanOverviewWrapper,
 		policyWrapper,
 		featureFlagsWrapper,
+		projectsWrapper,
+		applicationsWrapper,
+		groupsWrapper,
+		accessManagementWrapper,
 	)
 
 	versionCmd := util.NewVersionCommand()
@@ -194,14 +214,18 @@ func NewAstCLI(
 		featureFlagsWrapper,
 	)
 
+	applicationCmd := NewApplicationCommand(applicationsWrapper, projectsWrapper, resultsWrapper, scansWrapper, policyWrapper)
+	accessCmd := NewAccessCommand(groupsWrapper, accessManagementWrapper, projectsWrapper, featureFlagsWrapper)
 	configCmd := util.NewConfigCommand()
-	triageCmd := NewResultsPredicatesCommand(resultsPredicatesWrapper, featureFlagsWrapper)
+	triageCmd := NewResultsPredicatesCommand(resultsPredicatesWrapper, featureFlagsWrapper, resultsWrapper, scansWrapper)
 
 	chatCmd := NewChatCommand(chatWrapper, tenantWrapper)
 
 	rootCmd.AddCommand(
 		scanCmd,
 		projectCmd,
+		applicationCmd,
+		accessCmd,
 		resultsCmd,
 		triageCmd,
 		versionCmd,
@@ -216,7 +240,10 @@ func NewAstCLI(
 	return rootCmd
 }
 
-const configFormatString = "%30v: %s"
+const (
+	configFormatString   = "%30v: %s"
+	configureCommandName = "configure"
+)
 
 var extraFilter = map[string]map[string]string{
 	"state": {
@@ -227,6 +254,15 @@ var extraFilter = map[string]map[string]string{
 	"sort":     {},
 }
 
+func isConfigureCommand(cmd *cobra.Command) bool {
+	for ; cmd != nil; cmd = cmd.Parent() {
+		if cmd.Name() == configureCommandName {
+			return true
+		}
+	}
+	return false
+}
+
 func PrintConfiguration() {
 	logger.PrintfIfVerbose("CLI Version: %s", params.Version)
 	logger.PrintIfVerbose("CLI Configuration:")
@@ -252,6 +288,19 @@ func getFilters(cmd *cobra.Command) (map[string]string, error) {
 	return allFilters, nil
 }
 
+// addWhereFilter validates the --where expression and carries it in the results params
+func addWhereFilter(cmd *cobra.Command, filters map[string]string) error {
+	where, _ := cmd.Flags().GetString(params.WhereFlag)
+	if strings.TrimSpace(where) == "" {
+		return nil
+	}
+	if _, err := parseWhereExpression(where); err != nil {
+		return err
+	}
+	filters[params.WhereFlag] = where
+	return nil
+}
+
 func validateExtraFilters(filterKeyVal []string) []string {
 	// Add support for state = exclude-not-exploitable, will replace all values of filter flag state to "TO_VERIFY;PROPOSED_NOT_EXPLOITABLE;CONFIRMED;URGENT"
 	if extraFilter[filterKeyVal[0]] != nil {
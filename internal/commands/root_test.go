@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
 	"github.com/spf13/viper"
@@ -116,6 +117,15 @@ func TestRootVersion(t *testing.T) {
 	assert.NilError(t, err)
 }
 
+func TestRootUnknownProfile(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "list", "--profile", "cx-cli-test-missing")
+	assert.ErrorContains(t, err, "profile cx-cli-test-missing does not exist")
+
+	t.Setenv(params.ProfileEnv, "cx-cli-test-missing")
+	err = execCmdNotNilAssertion(t, "project", "list")
+	assert.ErrorContains(t, err, "profile cx-cli-test-missing does not exist")
+}
+
 func TestFilterTag(t *testing.T) {
 	stateValues := "state=exclude_not_exploitable"
 	baseArgs := []string{"scan", "create", "--project-name", "MOCK", "-s", githubDummyRepo, "-b", "dummy_branch", "--filter", stateValues}
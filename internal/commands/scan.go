@@ -150,6 +150,7 @@ func NewScanCommand(
 	accessManagementWrapper wrappers.AccessManagementWrapper,
 	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
 	containerResolverWrapper wrappers.ContainerResolverWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
 ) *cobra.Command {
 	scanCmd := &cobra.Command{
 		Use:   "scan",
@@ -179,6 +180,7 @@ func NewScanCommand(
 		accessManagementWrapper,
 		applicationsWrapper,
 		featureFlagsWrapper,
+		resultsPredicatesWrapper,
 	)
 	containerResolver = containerResolverWrapper
 
@@ -470,6 +472,7 @@ func scanCreateSubCommand(
 	accessManagementWrapper wrappers.AccessManagementWrapper,
 	applicationsWrapper wrappers.ApplicationsWrapper,
 	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
 ) *cobra.Command {
 	createScanCmd := &cobra.Command{
 		Use:   "create",
@@ -502,6 +505,7 @@ func scanCreateSubCommand(
 			accessManagementWrapper,
 			applicationsWrapper,
 			featureFlagsWrapper,
+			resultsPredicatesWrapper,
 		),
 	}
 	createScanCmd.PersistentFlags().Bool(commonParams.AsyncFlag, false, "Do not wait for scan completion")
@@ -608,7 +612,17 @@ func scanCreateSubCommand(
 		printer.FormatSummaryMarkdown,
 		printer.FormatGLSast,
 		printer.FormatGLSca,
+		printer.FormatDOT,
+		printer.FormatMermaid,
+		printer.FormatComplianceHTML,
+		printer.FormatComplianceJSON,
+		printer.FormatCSV,
 	)
+	createScanCmd.PersistentFlags().String(commonParams.ComplianceFrameworkFlag, "", complianceFrameworkFlagDescription)
+	createScanCmd.PersistentFlags().String(commonParams.PrBaseBranchFlag, "", prBaseBranchFlagDescription)
+	createScanCmd.PersistentFlags().Bool(commonParams.StreamResultsFlag, false, streamResultsFlagDescription)
+	createScanCmd.PersistentFlags().Bool(commonParams.ApplySuppressionsFlag, false,
+		"Mark the results annotated with cx-ignore or kics-scan ignore-line comments in the local sources as not exploitable")
 	createScanCmd.PersistentFlags().String(commonParams.APIDocumentationFlag, "", apiDocumentationFlagDescription)
 	createScanCmd.PersistentFlags().String(commonParams.ExploitablePathFlag, "", exploitablePathFlagDescription)
 	createScanCmd.PersistentFlags().String(commonParams.LastSastScanTime, "", scaLastScanTimeFlagDescription)
@@ -620,6 +634,7 @@ func scanCreateSubCommand(
 	createScanCmd.PersistentFlags().String(commonParams.TargetFlag, "cx_result", "Output file")
 	createScanCmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
 	createScanCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterResultsListFlagUsage)
+	createScanCmd.PersistentFlags().String(commonParams.WhereFlag, "", commonParams.WhereFlagUsage)
 	createScanCmd.PersistentFlags().String(commonParams.ProjectGroupList, "", "List of groups to associate to project")
 	createScanCmd.PersistentFlags().String(commonParams.ProjectTagList, "", "List of tags to associate to project")
 	createScanCmd.PersistentFlags().String(
@@ -1561,6 +1576,7 @@ func runCreateScanCommand(
 	accessManagementWrapper wrappers.AccessManagementWrapper,
 	applicationsWrapper wrappers.ApplicationsWrapper,
 	featureFlagsWrapper wrappers.FeatureFlagsWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
 ) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		err := validateScanTypes(cmd, jwtWrapper, featureFlagsWrapper)
@@ -1581,6 +1597,10 @@ func runCreateScanCommand(
 		if err != nil {
 			return err
 		}
+		err = addWhereFilter(cmd, make(map[string]string))
+		if err != nil {
+			return err
+		}
 		scanModel, zipFilePath, err := createScanModel(
 			cmd,
 			uploadsWrapper,
@@ -1643,13 +1663,17 @@ func runCreateScanCommand(
 			} else {
 				logger.PrintIfVerbose("Skipping policy evaluation")
 			}
+			err = applySuppressionsAfterScan(cmd, scanResponseModel, resultsWrapper, resultsPredicatesWrapper, featureFlagsWrapper)
+			if err != nil {
+				return err
+			}
 			err = createReportsAfterScan(cmd, scanResponseModel.ID, scansWrapper, exportWrapper, resultsPdfReportsWrapper,
 				resultsWrapper, risksOverviewWrapper, scsScanOverviewWrapper, policyResponseModel, featureFlagsWrapper)
 			if err != nil {
 				return err
 			}
 
-			err = applyThreshold(cmd, resultsWrapper, exportWrapper, scanResponseModel, thresholdMap, risksOverviewWrapper)
+			err = applyThreshold(cmd, scansWrapper, resultsWrapper, exportWrapper, scanResponseModel, thresholdMap, risksOverviewWrapper)
 
 			if err != nil {
 				return err
@@ -1867,9 +1891,18 @@ func createReportsAfterScan(
 	if err != nil {
 		return err
 	}
+	err = addWhereFilter(cmd, params)
+	if err != nil {
+		return err
+	}
+	addComplianceFrameworkFilter(cmd, params)
 	if !strings.Contains(reportFormats, printer.FormatSummaryConsole) {
 		reportFormats += "," + printer.FormatSummaryConsole
 	}
+	err = addStreamResultsOption(cmd, reportFormats, params)
+	if err != nil {
+		return err
+	}
 	scan, errorModel, scanErr := scansWrapper.GetByID(scanID)
 	if scanErr != nil {
 		return errors.Wrapf(scanErr, "%s", failedGetting)
@@ -1877,6 +1910,11 @@ func createReportsAfterScan(
 	if errorModel != nil {
 		return errors.Errorf("%s: CODE: %d, %s", failedGettingScan, errorModel.Code, errorModel.Message)
 	}
+	prBaseBranch, _ := cmd.Flags().GetString(commonParams.PrBaseBranchFlag)
+	err = addNewOnlyFilter(scansWrapper, scan, prBaseBranch, params)
+	if err != nil {
+		return err
+	}
 	return CreateScanReport(
 		resultsWrapper,
 		risksOverviewWrapper,
@@ -1899,6 +1937,7 @@ func createReportsAfterScan(
 
 func applyThreshold(
 	cmd *cobra.Command,
+	scansWrapper wrappers.ScansWrapper,
 	resultsWrapper wrappers.ResultsWrapper,
 	exportWrapper wrappers.ExportWrapper,
 	scanResponseModel *wrappers.ScanResponseModel,
@@ -1914,6 +1953,19 @@ func applyThreshold(
 	if sastRedundancy {
 		params[commonParams.SastRedundancyFlag] = ""
 	}
+	err := addWhereFilter(cmd, params)
+	if err != nil {
+		return err
+	}
+	prBaseBranch, _ := cmd.Flags().GetString(commonParams.PrBaseBranchFlag)
+	err = addNewOnlyFilter(scansWrapper, scanResponseModel, prBaseBranch, params)
+	if err != nil {
+		return err
+	}
+	err = addStreamResultsOption(cmd, "", params)
+	if err != nil {
+		return err
+	}
 
 	summaryMap, err := getSummaryThresholdMap(resultsWrapper, exportWrapper, scanResponseModel, params, risksOverviewWrapper)
 
@@ -2007,16 +2059,26 @@ func getSummaryThresholdMap(
 	risksOverviewWrapper wrappers.RisksOverviewWrapper,
 ) (map[string]int, error) {
 	summaryMap := make(map[string]int)
-	results, err := ReadResults(resultsWrapper, exportWrapper, scan, params)
-
-	if err != nil {
-		return nil, err
+	countResults := func(results *wrappers.ScanResultsCollection) error {
+		for _, result := range results.Results {
+			if isExploitable(result.State) {
+				key := strings.ToLower(fmt.Sprintf("%s-%s", strings.Replace(result.Type, commonParams.KicsType, commonParams.IacType, 1), result.Severity))
+				summaryMap[key]++
+			}
+		}
+		return nil
 	}
-	for _, result := range results.Results {
-		if isExploitable(result.State) {
-			key := strings.ToLower(fmt.Sprintf("%s-%s", strings.Replace(result.Type, commonParams.KicsType, commonParams.IacType, 1), result.Severity))
-			summaryMap[key]++
+	if _, streamResults := params[commonParams.StreamResultsFlag]; streamResults {
+		err := StreamResults(resultsWrapper, exportWrapper, scan, params, countResults)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		results, err := ReadResults(resultsWrapper, exportWrapper, scan, params)
+		if err != nil {
+			return nil, err
 		}
+		_ = countResults(results)
 	}
 
 	if slices.Contains(scan.Engines, commonParams.APISecType) {
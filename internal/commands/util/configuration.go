@@ -1,10 +1,12 @@
 package util
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
 	"github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
 	"github.com/pkg/errors"
@@ -12,9 +14,13 @@ import (
 )
 
 const (
-	failedSettingProp = "Failed to set property"
-	propNameFlag      = "prop-name"
-	propValFlag       = "prop-value"
+	failedSettingProp     = "Failed to set property"
+	failedListingProfiles = "Failed listing profiles"
+	failedUsingProfile    = "Failed selecting profile"
+	failedDeletingProfile = "Failed deleting profile"
+	propNameFlag          = "prop-name"
+	propValFlag           = "prop-value"
+	profileNameFlag       = "profile-name"
 )
 
 var Properties = map[string]bool{
@@ -43,6 +49,7 @@ func NewConfigCommand() *cobra.Command {
 			AST Tenant []: organization
 			Do you want to use API Key authentication? (Y/N): Y
 			AST API Key []: myapikey
+			$ cx configure --profile eu
 		`,
 		),
 		Run: func(cmd *cobra.Command, args []string) {
@@ -72,6 +79,7 @@ func NewConfigCommand() *cobra.Command {
 			`
 			$ cx configure show
 			Current Effective Configuration
+                     Profile: default
                      BaseURI: 
               BaseAuthURIKey: 
                   AST Tenant: 
@@ -110,10 +118,93 @@ func NewConfigCommand() *cobra.Command {
 	setCmd.PersistentFlags().String(propNameFlag, "", "Name of property set")
 	setCmd.PersistentFlags().String(propValFlag, "", "Value of property set")
 
-	configureCmd.AddCommand(showCmd, setCmd)
+	configureCmd.AddCommand(showCmd, setCmd, newProfileCommand())
 	return configureCmd
 }
 
+func newProfileCommand() *cobra.Command {
+	profileCmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage named configuration profiles",
+		Long: "Profiles keep the configuration of several Checkmarx One tenants side by side. Create one with 'cx configure --profile <name>', " +
+			"select it with the global --profile flag, the CX_PROFILE environment variable or 'cx configure profile use'.",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the configuration profiles",
+		Example: heredoc.Doc(
+			`
+			$ cx configure profile list
+		`,
+		),
+		RunE: runListProfiles,
+	}
+	listCmd.PersistentFlags().String(
+		params.FormatFlag,
+		printer.FormatTable,
+		fmt.Sprintf(params.FormatFlagUsageFormat, []string{printer.FormatTable, printer.FormatJSON, printer.FormatList}),
+	)
+
+	useCmd := &cobra.Command{
+		Use:   "use",
+		Short: "Select the profile used when --profile and CX_PROFILE are not set",
+		Example: heredoc.Doc(
+			`
+			$ cx configure profile use --profile-name eu
+		`,
+		),
+		RunE: runUseProfile,
+	}
+	useCmd.PersistentFlags().String(profileNameFlag, "", "Name of the profile")
+	_ = useCmd.MarkPersistentFlagRequired(profileNameFlag)
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a profile and its configuration",
+		Example: heredoc.Doc(
+			`
+			$ cx configure profile delete --profile-name dev
+		`,
+		),
+		RunE: runDeleteProfile,
+	}
+	deleteCmd.PersistentFlags().String(profileNameFlag, "", "Name of the profile")
+	_ = deleteCmd.MarkPersistentFlagRequired(profileNameFlag)
+
+	profileCmd.AddCommand(listCmd, useCmd, deleteCmd)
+	return profileCmd
+}
+
+func runListProfiles(cmd *cobra.Command, _ []string) error {
+	profiles, err := configuration.ListProfiles()
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedListingProfiles)
+	}
+	format, _ := cmd.Flags().GetString(params.FormatFlag)
+	return printer.Print(cmd.OutOrStdout(), profiles, format)
+}
+
+func runUseProfile(cmd *cobra.Command, _ []string) error {
+	profile, _ := cmd.Flags().GetString(profileNameFlag)
+	err := configuration.UseProfile(profile)
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedUsingProfile)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Using profile [ %s ]\n", profile)
+	return nil
+}
+
+func runDeleteProfile(cmd *cobra.Command, _ []string) error {
+	profile, _ := cmd.Flags().GetString(profileNameFlag)
+	err := configuration.DeleteProfile(profile)
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedDeletingProfile)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted profile [ %s ]\n", profile)
+	return nil
+}
+
 func runSetValue() func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		propName, _ := cmd.Flags().GetString(propNameFlag)
@@ -1,8 +1,16 @@
 package util
 
 import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
+	"github.com/spf13/viper"
 	"gotest.tools/assert"
 )
 
@@ -23,3 +31,92 @@ func TestNewConfigCommand(t *testing.T) {
 	assert.Assert(t, err != nil)
 	assert.Assert(t, err.Error() == "Failed to set property: unknown property or bad value")
 }
+
+func TestConfigureProfiles(t *testing.T) {
+	const profile = "cx-cli-test-profile"
+	assert.NilError(t, configuration.LoadConfiguration())
+	active := configuration.ActiveProfile()
+	t.Cleanup(func() {
+		_ = configuration.DeleteProfile(profile)
+		_ = configuration.UseProfile(active)
+		_ = configuration.LoadProfile(active, false)
+	})
+
+	err := executeTestCommand(NewConfigCommand(), "profile", "use", "--profile-name", profile)
+	assert.ErrorContains(t, err, "profile cx-cli-test-profile does not exist")
+	err = configuration.LoadProfile(profile, false)
+	assert.ErrorContains(t, err, "profile cx-cli-test-profile does not exist")
+
+	assert.NilError(t, configuration.LoadProfile(profile, true))
+	assert.Equal(t, viper.GetString(params.TenantKey), "")
+	assert.NilError(t, executeTestCommand(NewConfigCommand(), "set", "--prop-name", params.TenantKey, "--prop-value", "eu-tenant"))
+	profiles, err := configuration.ListProfiles()
+	assert.NilError(t, err)
+	index := slices.IndexFunc(profiles, func(p configuration.Profile) bool { return p.Name == profile })
+	assert.Assert(t, index >= 0)
+	assert.Equal(t, profiles[index].Tenant, "eu-tenant")
+
+	assert.NilError(t, executeTestCommand(NewConfigCommand(), "profile", "use", "--profile-name", profile))
+	assert.NilError(t, executeTestCommand(NewConfigCommand(), "profile", "list", "--format", "json"))
+	assert.NilError(t, executeTestCommand(NewConfigCommand(), "profile", "delete", "--profile-name", profile))
+	assert.Equal(t, configuration.ActiveProfile(), configuration.DefaultProfile)
+
+	err = executeTestCommand(NewConfigCommand(), "profile", "delete", "--profile-name", configuration.DefaultProfile)
+	assert.ErrorContains(t, err, "the default profile can't be deleted")
+	err = executeTestCommand(NewConfigCommand(), "profile", "use", "--profile-name", "../eu")
+	assert.ErrorContains(t, err, "invalid profile name")
+}
+
+func TestLoadConfigurationUnknownProfileEnv(t *testing.T) {
+	t.Setenv(params.ProfileEnv, "cx-cli-test-missing")
+	err := configuration.LoadConfiguration()
+	assert.ErrorContains(t, err, "CX_PROFILE: profile cx-cli-test-missing does not exist")
+}
+
+func TestConfigureCredentialsStore(t *testing.T) {
+	const (
+		profile = "cx-cli-test-credentials"
+		apiKey  = "plaintext-api-key"
+	)
+	usr, err := user.Current()
+	assert.NilError(t, err)
+	configDir := filepath.Join(usr.HomeDir, ".checkmarx")
+	credentialsFile := filepath.Join(configDir, "credentials.enc")
+	if _, err = os.Stat(credentialsFile); err == nil {
+		t.Skip("the encrypted credentials file is in use")
+	}
+	t.Setenv(params.CredentialsStoreEnv, "file")
+	t.Setenv(params.CredentialsPassphraseEnv, "test-passphrase")
+	assert.NilError(t, configuration.LoadConfiguration())
+	active := configuration.CurrentProfile()
+	t.Cleanup(func() {
+		_ = configuration.DeleteProfile(profile)
+		_ = os.Remove(credentialsFile)
+		_ = configuration.LoadProfile(active, false)
+	})
+
+	configFile := filepath.Join(configDir, "checkmarxcli-"+profile+".yaml")
+	assert.NilError(t, os.WriteFile(configFile, []byte("cx_apikey: "+apiKey+"\nast-token: cached-token\ncx_tenant: tenant\n"), 0600))
+	assert.NilError(t, configuration.LoadProfile(profile, false))
+	content, err := os.ReadFile(configFile)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(content), apiKey))
+	assert.Assert(t, !strings.Contains(string(content), "cached-token"))
+	assert.Assert(t, strings.Contains(string(content), "tenant"))
+	content, err = os.ReadFile(credentialsFile)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(content), apiKey))
+	assert.Equal(t, configuration.GetCredential(params.AstAPIKey), apiKey)
+	assert.Equal(t, configuration.CredentialsStoreName(), "encrypted credentials file")
+
+	assert.NilError(t, executeTestCommand(NewConfigCommand(), "set", "--prop-name", params.AccessKeySecretConfigKey, "--prop-value", "secret"))
+	content, err = os.ReadFile(configFile)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(content), ": secret"))
+	assert.NilError(t, configuration.LoadProfile(profile, false))
+	assert.Equal(t, configuration.GetCredential(params.AccessKeySecretConfigKey), "secret")
+
+	t.Setenv(params.CredentialsPassphraseEnv, "wrong-passphrase")
+	assert.NilError(t, configuration.LoadProfile(profile, false))
+	assert.Equal(t, configuration.GetCredential(params.AccessKeySecretConfigKey), "")
+}
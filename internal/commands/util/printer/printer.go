@@ -30,6 +30,11 @@ const (
 	FormatXML             = "xml"
 	FormatGLSast          = "gl-sast"
 	FormatGLSca           = "gl-sca"
+	FormatCSV             = "csv"
+	FormatDOT             = "dot"
+	FormatMermaid         = "mermaid"
+	FormatComplianceHTML  = "complianceHTML"
+	FormatComplianceJSON  = "complianceJSON"
 )
 
 func Print(w io.Writer, view interface{}, format string) error {
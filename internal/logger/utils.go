@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"strings"
+	"sync"
 	"unicode/utf8"
 
 	"github.com/checkmarx/ast-cli/internal/params"
@@ -23,6 +24,22 @@ var sanitizeFlags = []string{
 	params.UploadURLEnv,
 }
 
+// sanitizeValues are secrets read outside the configuration, like the stored credentials and access token
+var (
+	sanitizeValues      []string
+	sanitizeValuesMutex sync.Mutex
+)
+
+// AddSanitizedValue masks a secret in the logs that isn't one of the configuration values
+func AddSanitizedValue(value string) {
+	if value == "" {
+		return
+	}
+	sanitizeValuesMutex.Lock()
+	defer sanitizeValuesMutex.Unlock()
+	sanitizeValues = append(sanitizeValues, value)
+}
+
 func Print(msg string) {
 	if utf8.Valid([]byte(msg)) {
 		log.Print(sanitizeLogs(msg))
@@ -72,6 +89,11 @@ func sanitizeLogs(msg string) string {
 			msg = strings.ReplaceAll(msg, value, "***")
 		}
 	}
+	sanitizeValuesMutex.Lock()
+	defer sanitizeValuesMutex.Unlock()
+	for _, value := range sanitizeValues {
+		msg = strings.ReplaceAll(msg, value, "***")
+	}
 	return msg
 }
 
@@ -25,6 +25,8 @@ var EnvVarsBinds = []struct {
 	{SastResultsPredicatesPathKey, SastResultsPredicatesPathEnv, "api/sast-results-predicates"},
 	{KicsResultsPathKey, KicsResultsPathEnv, "api/kics-results"},
 	{KicsResultsPredicatesPathKey, KicsResultsPredicatesPathEnv, "api/kics-results-predicates"},
+	{ScaResultsPredicatesPathKey, ScaResultsPredicatesPathEnv, "api/sca/management-of-risk/package-vulnerabilities"},
+	{ContainersResultsPredicatesPathKey, ContainersResultsPredicatesPathEnv, "api/containers/management-of-risk/image-vulnerabilities"},
 	{BflPathKey, BflPathEnv, "api/bfl"},
 	{PRDecorationGithubPathKey, PRDecorationGithubPathEnv, "api/flow-publisher/pr/github"},
 	{PRDecorationGitlabPathKey, PRDecorationGitlabPathEnv, "api/flow-publisher/pr/gitlab"},
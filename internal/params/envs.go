@@ -2,6 +2,9 @@ package params
 
 const (
 	TenantEnv                           = "CX_TENANT"
+	ProfileEnv                          = "CX_PROFILE"
+	CredentialsStoreEnv                 = "CX_CREDENTIALS_STORE"
+	CredentialsPassphraseEnv            = "CX_CREDENTIALS_PASSPHRASE"
 	BranchEnv                           = "CX_BRANCH"
 	BaseURIEnv                          = "CX_BASE_URI"
 	ClientTimeoutEnv                    = "CX_TIMEOUT"
@@ -27,6 +30,8 @@ const (
 	SastResultsPredicatesPathEnv        = "CX_SAST_RESULTS_PREDICATES_PATH"
 	KicsResultsPathEnv                  = "CX_KICS_RESULTS_PATH"
 	KicsResultsPredicatesPathEnv        = "CX_KICS_RESULTS_PREDICATES_PATH"
+	ScaResultsPredicatesPathEnv         = "CX_SCA_RESULTS_PREDICATES_PATH"
+	ContainersResultsPredicatesPathEnv  = "CX_CONTAINERS_RESULTS_PREDICATES_PATH"
 	BflPathEnv                          = "CX_BFL_PATH"
 	PRDecorationGithubPathEnv           = "CX_PR_DECORATION_GITHUB_PATH"
 	PRDecorationGitlabPathEnv           = "CX_PR_DECORATION_GITLAB_PATH"
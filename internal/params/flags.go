@@ -47,6 +47,17 @@ const (
 	FormatFlag                   = "format"
 	FormatFlagUsageFormat        = "Format for the output. One of %s"
 	FilterFlag                   = "filter"
+	WhereFlag                    = "where"
+	SnippetsSourceDirFlag        = "snippets-source-dir"
+	SnippetsContextFlag          = "snippets-context"
+	FromBundleFlag               = "from-bundle"
+	SaveBundleFlag               = "save-bundle"
+	ComplianceFrameworkFlag      = "compliance-framework"
+	PrBaseBranchFlag             = "pr-base-branch"
+	NewOnlyFlag                  = "new-only"
+	StreamResultsFlag            = "stream-results"
+	ApplySuppressionsFlag        = "apply-suppressions"
+	WhereFlagUsage               = "Client side filter expression over result fields, ex: \"severity in (HIGH,CRITICAL) && !(file matches 'test/**')\""
 	VorpalLatestVersion          = "vorpal-latest-version"
 	BaseURIFlag                  = "base-uri"
 	ProxyFlag                    = "proxy"
@@ -74,7 +85,7 @@ const (
 	PasswordFlag                 = "password"
 	PasswordSh                   = "p"
 	ProfileFlag                  = "profile"
-	ProfileFlagUsage             = "The default configuration profile"
+	ProfileFlagUsage             = "The configuration profile to use, overrides CX_PROFILE and the profile selected by 'cx configure profile use'"
 	Help                         = "help"
 	TargetFlag                   = "output-name"
 	TargetPathFlag               = "output-path"
@@ -99,6 +110,11 @@ const (
 	RemediationPackageVersion    = "package-version"
 	TagList                      = "tags"
 	GroupList                    = "groups"
+	AddTagsFlag                  = "add-tags"
+	RemoveTagsFlag               = "remove-tags"
+	AddGroupsFlag                = "add-groups"
+	RemoveGroupsFlag             = "remove-groups"
+	RemoveApplicationNameFlag    = "remove-application-name"
 	ProjectGroupList             = "project-groups"
 	ProjectTagList               = "project-tags"
 	IncrementalSast              = "sast-incremental"
@@ -109,9 +125,41 @@ const (
 	KeyValuePairSize         = 2
 	WaitDelayDefault         = 5
 	SimilarityIDFlag         = "similarity-id"
+	VulnerabilitiesFlag      = "vulnerabilities"
 	SeverityFlag             = "severity"
 	StateFlag                = "state"
 	CommentFlag              = "comment"
+	TriageFileFlag           = "file"
+	DryRunFlag               = "dry-run"
+	ConcurrencyFlag          = "concurrency"
+	FromProjectFlag          = "from-project"
+	ToProjectFlag            = "to-project"
+	FromBranchFlag           = "from-branch"
+	ToBranchFlag             = "to-branch"
+	OnConflictFlag           = "on-conflict"
+	ConfigKeyFlag            = "key"
+	ConfigValueFlag          = "value"
+	PruneFlag                = "prune"
+	GitHubOrgFlag            = "github-org"
+	GitLabGroupFlag          = "gitlab-group"
+	AzureOrgFlag             = "azure-org"
+	AzureProjectFlag         = "azure-project"
+	BitBucketWorkspaceFlag   = "bitbucket-workspace"
+	AzureURLFlag             = "url-azure"
+	BitBucketURLFlag         = "url-bitbucket"
+	TopicTagsFlag            = "topic-tags"
+	GroupMappingFlag         = "group-mapping"
+	IncludeArchivedFlag      = "include-archived"
+	StaleDaysFlag            = "stale-days"
+	ApplicationIDFlag        = "application-id"
+	DescriptionFlag          = "description"
+	CriticalityFlag          = "criticality"
+	RuleFlag                 = "rule"
+	AddRuleFlag              = "add-rule"
+	RemoveRuleFlag           = "remove-rule"
+	RolesFlag                = "roles"
+	BackupFlag               = "backup"
+	YesFlag                  = "yes"
 	LanguageFlag             = "language"
 	VulnerabilityTypeFlag    = "vulnerability-type"
 	CweIDFlag                = "cwe-id"
@@ -139,6 +187,12 @@ const (
 	LastSastScanTime         = "sca-last-sast-scan-time"
 	ProjecPrivatePackageFlag = "project-private-package"
 	SastRedundancyFlag       = "sast-redundancy"
+	TopFlag                  = "top"
+	LastFlag                 = "last"
+	CoverageFlag             = "coverage"
+	BestFixLocationsFlag     = "best-fix-locations"
+	SLAFlag                  = "sla"
+	FailOnBreachFlag         = "fail-on-breach"
 	ContainerImagesFlag      = "container-images"
 	ContainersTypeFlag       = "container-security"
 
@@ -209,6 +263,7 @@ const (
 	StatusesQueryParam         = "statuses"
 	StatusQueryParam           = "status"
 	BranchNameQueryParam       = "branch-name"
+	BranchQueryParam           = "branch"
 	ProjectIDQueryParam        = "project-id"
 	FromDateQueryParam         = "from-date"
 	ToDateQueryParam           = "to-date"
@@ -33,12 +33,20 @@ func NewAccessManagementHTTPWrapper(path string) AccessManagementWrapper {
 	}
 }
 func (a *AccessManagementHTTPWrapper) CreateGroupsAssignment(projectID, projectName string, groups []*Group) error {
+	return a.CreateGroupsAssignmentWithRoles(projectID, projectName, groups, nil)
+}
+
+func (a *AccessManagementHTTPWrapper) CreateGroupsAssignmentWithRoles(projectID, projectName string, groups []*Group, roles []string) error {
 	var resp *http.Response
+	var entityRoles []interface{}
+	for _, role := range roles {
+		entityRoles = append(entityRoles, role)
+	}
 	for _, group := range groups {
 		assignment := AssignmentPayload{
-			EntityID:   group.ID,
-			EntityType: groupEntityType,
-			//EntityRoles:  nil, // be used in the access-management phase 2
+			EntityID:     group.ID,
+			EntityType:   groupEntityType,
+			EntityRoles:  entityRoles,
 			ResourceID:   projectID,
 			ResourceType: projectResourceType,
 		}
@@ -58,7 +66,41 @@ func (a *AccessManagementHTTPWrapper) CreateGroupsAssignment(projectID, projectN
 	return nil
 }
 
+func (a *AccessManagementHTTPWrapper) DeleteGroupsAssignment(projectID string, groups []*Group) error {
+	for _, group := range groups {
+		path := fmt.Sprintf("%s?entity-id=%s&resource-id=%s", a.path, group.ID, projectID)
+		resp, err := SendHTTPRequest(http.MethodDelete, path, nil, true, a.clientTimeout)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete groups assignment")
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+			return errors.Errorf("Failed to delete group '%s' assignment, status code: %d", group.Name, resp.StatusCode)
+		}
+		logger.PrintfIfVerbose("group '%s' assignment for project %s deleted", group.Name, projectID)
+	}
+	return nil
+}
+
 func (a *AccessManagementHTTPWrapper) GetGroups(projectID string) ([]*Group, error) {
+	assignments, err := a.GetAssignments(projectID)
+	if err != nil {
+		return nil, err
+	}
+	var groups []*Group
+	for _, assignment := range assignments {
+		if assignment.EntityType == groupEntityType {
+			group := &Group{
+				ID:   assignment.EntityID,
+				Name: assignment.EntityName,
+			}
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+func (a *AccessManagementHTTPWrapper) GetAssignments(projectID string) ([]*AssignmentResponse, error) {
 	path := fmt.Sprintf("%s/%s?resource-id=%s&resource-type=project", a.path, entitiesForPath, projectID)
 	resp, err := SendHTTPRequest(http.MethodGet, path, nil, true, a.clientTimeout)
 	if err != nil {
@@ -69,20 +111,10 @@ func (a *AccessManagementHTTPWrapper) GetGroups(projectID string) ([]*Group, err
 		return nil, errors.Errorf("Failed to get groups, status code: %d", resp.StatusCode)
 	}
 	var assignments []*AssignmentResponse
-	var groups []*Group
 	decoder := json.NewDecoder(resp.Body)
 	err = decoder.Decode(&assignments)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to parse response body")
 	}
-	for _, assignment := range assignments {
-		if assignment.EntityType == groupEntityType {
-			group := &Group{
-				ID:   assignment.EntityID,
-				Name: assignment.EntityName,
-			}
-			groups = append(groups, group)
-		}
-	}
-	return groups, nil
+	return assignments, nil
 }
@@ -12,7 +12,10 @@ type AssignmentResponse struct {
 
 type AccessManagementWrapper interface {
 	CreateGroupsAssignment(projectID, projectName string, groups []*Group) error
+	CreateGroupsAssignmentWithRoles(projectID, projectName string, groups []*Group, roles []string) error
 	GetGroups(projectID string) ([]*Group, error)
+	GetAssignments(projectID string) ([]*AssignmentResponse, error)
+	DeleteGroupsAssignment(projectID string, groups []*Group) error
 }
 
 type AssignmentPayload struct {
@@ -22,3 +25,20 @@ type AssignmentPayload struct {
 	ResourceType string        `json:"resourceType"`
 	ResourceID   string        `json:"resourceID"`
 }
+
+// AccessAssignmentFile is the layout of the access export and apply files
+type AccessAssignmentFile struct {
+	Projects []AccessAssignmentEntry `json:"projects" yaml:"projects"`
+}
+
+type AccessAssignmentEntry struct {
+	ProjectID   string             `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	ProjectName string             `json:"projectName,omitempty" yaml:"projectName,omitempty"`
+	Groups      []AccessGroupEntry `json:"groups" yaml:"groups"`
+}
+
+type AccessGroupEntry struct {
+	ID    string   `json:"id,omitempty" yaml:"id,omitempty"`
+	Name  string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Roles []string `json:"roles,omitempty" yaml:"roles,omitempty"`
+}
@@ -1,7 +1,9 @@
 package wrappers
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	errorConstants "github.com/checkmarx/ast-cli/internal/constants/errors"
@@ -58,3 +60,101 @@ func (a *ApplicationsHTTPWrapper) Get(params map[string]string) (*ApplicationsRe
 		return nil, errors.Errorf("response status code %d", resp.StatusCode)
 	}
 }
+
+func (a *ApplicationsHTTPWrapper) GetByID(applicationID string) (*Application, *ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	resp, err := SendHTTPRequest(http.MethodGet, fmt.Sprintf("%s/%s", a.path, applicationID), http.NoBody, true, clientTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return handleApplicationResponseWithBody(resp, http.StatusOK)
+}
+
+func (a *ApplicationsHTTPWrapper) Create(application *ApplicationConfiguration) (*Application, *ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	jsonBytes, err := json.Marshal(application)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := SendHTTPRequest(http.MethodPost, a.path, bytes.NewBuffer(jsonBytes), true, clientTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return handleApplicationResponseWithBody(resp, http.StatusCreated)
+}
+
+func (a *ApplicationsHTTPWrapper) Update(applicationID string, application *ApplicationConfiguration) (*ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	jsonBytes, err := json.Marshal(application)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := SendHTTPRequest(http.MethodPut, fmt.Sprintf("%s/%s", a.path, applicationID), bytes.NewBuffer(jsonBytes), true, clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return handleApplicationResponseWithNoBody(resp, http.StatusNoContent)
+}
+
+func (a *ApplicationsHTTPWrapper) Delete(applicationID string) (*ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	resp, err := SendHTTPRequest(http.MethodDelete, fmt.Sprintf("%s/%s", a.path, applicationID), http.NoBody, true, clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return handleApplicationResponseWithNoBody(resp, http.StatusNoContent)
+}
+
+func handleApplicationResponseWithBody(resp *http.Response, successStatusCode int) (*Application, *ErrorModel, error) {
+	decoder := json.NewDecoder(resp.Body)
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusInternalServerError:
+		errorModel := ErrorModel{}
+		err := decoder.Decode(&errorModel)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, failedToParseErr)
+		}
+		return nil, &errorModel, nil
+	case http.StatusForbidden, http.StatusNotFound:
+		return nil, nil, errors.Errorf(errorConstants.ApplicationDoesntExistOrNoPermission)
+	case successStatusCode:
+		model := Application{}
+		err := decoder.Decode(&model)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, errorConstants.FailedToGetApplication)
+		}
+		return &model, nil, nil
+	default:
+		return nil, nil, errors.Errorf("response status code %d", resp.StatusCode)
+	}
+}
+
+func handleApplicationResponseWithNoBody(resp *http.Response, successStatusCode int) (*ErrorModel, error) {
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusInternalServerError:
+		errorModel := ErrorModel{}
+		err := json.NewDecoder(resp.Body).Decode(&errorModel)
+		if err != nil {
+			return nil, errors.Wrapf(err, failedToParseErr)
+		}
+		return &errorModel, nil
+	case http.StatusForbidden, http.StatusNotFound:
+		return nil, errors.Errorf(errorConstants.ApplicationDoesntExistOrNoPermission)
+	case successStatusCode:
+		return nil, nil
+	default:
+		return nil, errors.Errorf("response status code %d", resp.StatusCode)
+	}
+}
@@ -9,28 +9,36 @@ type ApplicationsResponseModel struct {
 }
 
 type Application struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Criticality int       `json:"criticality"`
-	Rules       []Rule    `json:"rules"`
-	ProjectIds  []string  `json:"projectIds"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	Tags        Tags      `json:"tags"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Criticality int               `json:"criticality"`
+	Rules       []Rule            `json:"rules"`
+	ProjectIds  []string          `json:"projectIds"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+	Tags        map[string]string `json:"tags"`
+}
+
+// ApplicationConfiguration is the body of the application create and update requests
+type ApplicationConfiguration struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Criticality int               `json:"criticality"`
+	Rules       []Rule            `json:"rules"`
+	Tags        map[string]string `json:"tags"`
 }
 
 type Rule struct {
-	ID    string `json:"id"`
+	ID    string `json:"id,omitempty"`
 	Type  string `json:"type"`
 	Value string `json:"value"`
 }
 
-type Tags struct {
-	Test     string `json:"test"`
-	Priority string `json:"priority"`
-}
-
 type ApplicationsWrapper interface {
 	Get(params map[string]string) (*ApplicationsResponseModel, error)
+	GetByID(applicationID string) (*Application, *ErrorModel, error)
+	Create(application *ApplicationConfiguration) (*Application, *ErrorModel, error)
+	Update(applicationID string, application *ApplicationConfiguration) (*ErrorModel, error)
+	Delete(applicationID string) (*ErrorModel, error)
 }
@@ -67,18 +67,23 @@ func (g *AzureHTTPWrapper) GetCommits(url, organizationName, projectName, reposi
 
 // GetRepositories we have to fetch all the repos because Azure DevOps does not support pagination for repositories
 func (g *AzureHTTPWrapper) GetRepositories(url, organizationName, projectName, token string) (AzureRootRepo, error) {
-	var err error
+	rootRepo, err := g.GetAllRepositories(url, organizationName, projectName, token)
+	if err != nil {
+		return rootRepo, err
+	}
+	return rootRepo.GetEnabledRepos(), err
+}
+
+// GetAllRepositories returns the repos of the project, the disabled ones included
+func (g *AzureHTTPWrapper) GetAllRepositories(url, organizationName, projectName, token string) (AzureRootRepo, error) {
 	var rootRepo AzureRootRepo
 	var queryParams = make(map[string]string)
 
 	reposURL := fmt.Sprintf(azureBaseReposURL, url, organizationName, projectName)
 	queryParams[azureAPIVersion] = azureAPIVersionValue
 
-	_, err = g.get(reposURL, encodeToken(token), &rootRepo, queryParams, basicFormat)
-	if err != nil {
-		return rootRepo, err
-	}
-	return rootRepo.GetEnabledRepos(), err
+	_, err := g.get(reposURL, encodeToken(token), &rootRepo, queryParams, basicFormat)
+	return rootRepo, err
 }
 
 func (g *AzureHTTPWrapper) GetProjects(url, organizationName, token string) (AzureRootProject, error) {
@@ -28,8 +28,10 @@ func (a *AzureRootRepo) GetEnabledRepos() AzureRootRepo {
 }
 
 type AzureRepo struct {
-	Name       string `json:"name"`
-	IsDisabled bool   `json:"isDisabled"`
+	Name          string `json:"name"`
+	IsDisabled    bool   `json:"isDisabled"`
+	RemoteURL     string `json:"remoteUrl"`
+	DefaultBranch string `json:"defaultBranch"`
 }
 
 type AzureRootProject struct {
@@ -45,4 +47,5 @@ type AzureWrapper interface {
 	GetProjects(url string, organizationName string, token string) (AzureRootProject, error)
 	GetCommits(url, organizationName, projectName, repositoryName, token string) (AzureRootCommit, error)
 	GetRepositories(url string, organizationName string, projectName string, token string) (AzureRootRepo, error)
+	GetAllRepositories(url string, organizationName string, projectName string, token string) (AzureRootRepo, error)
 }
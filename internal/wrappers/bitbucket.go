@@ -30,8 +30,23 @@ type BitBucketRootRepoList struct {
 }
 
 type BitBucketRepo struct {
-	Name string `json:"full_name"`
-	UUID string `json:"uuid"`
+	Name       string              `json:"full_name"`
+	UUID       string              `json:"uuid"`
+	MainBranch BitBucketMainBranch `json:"mainbranch"`
+	Links      BitBucketRepoLinks  `json:"links"`
+}
+
+type BitBucketMainBranch struct {
+	Name string `json:"name"`
+}
+
+type BitBucketRepoLinks struct {
+	Clone []BitBucketLink `json:"clone"`
+}
+
+type BitBucketLink struct {
+	Name string `json:"name"`
+	Href string `json:"href"`
 }
 
 type BitBucketPage struct {
@@ -2,7 +2,9 @@ package wrappers
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,6 +14,7 @@ import (
 	"net/http/httptrace"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	applicationErrors "github.com/checkmarx/ast-cli/internal/constants/errors"
@@ -22,6 +25,7 @@ import (
 	"github.com/spf13/viper"
 
 	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
 	"github.com/checkmarx/ast-cli/internal/wrappers/ntlm"
 )
 
@@ -66,8 +70,9 @@ const baseURLKey = "ast-base-url"
 
 const audienceClaimKey = "aud"
 
-var cachedAccessToken string
-var cachedAccessTime time.Time
+// cachedAccessTokens keeps the access token of every configuration profile, so a token is never sent to the tenant of another profile
+var cachedAccessTokens = make(map[string]cachedAccessToken)
+var cachedAccessTokensMutex sync.Mutex
 var Domains = make(map[string]struct{})
 
 func setAgentName(req *http.Request) {
@@ -379,23 +384,15 @@ func GetAccessToken() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	tokenExpirySeconds := viper.GetInt(commonParams.TokenExpirySecondsKey)
-	accessToken := getClientCredentialsFromCache(tokenExpirySeconds)
 	accessKeyID := viper.GetString(commonParams.AccessKeyIDConfigKey)
-	accessKeySecret := viper.GetString(commonParams.AccessKeySecretConfigKey)
-	astAPIKey := viper.GetString(commonParams.AstAPIKey)
+	accessKeySecret := configuration.GetCredential(commonParams.AccessKeySecretConfigKey)
+	astAPIKey := configuration.GetCredential(commonParams.AstAPIKey)
 	if accessKeyID == "" && astAPIKey == "" {
 		return "", errors.Errorf(fmt.Sprintf(FailedToAuth, "access key ID"))
 	} else if accessKeySecret == "" && astAPIKey == "" {
 		return "", errors.Errorf(fmt.Sprintf(FailedToAuth, "access key secret"))
 	}
-	if accessToken == "" {
-		accessToken, err = getClientCredentials(accessKeyID, accessKeySecret, astAPIKey, authURI)
-		if err != nil {
-			return "", err
-		}
-	}
-	return accessToken, nil
+	return getClientCredentials(accessKeyID, accessKeySecret, astAPIKey, authURI)
 }
 
 func enrichWithPasswordCredentials(
@@ -424,9 +421,10 @@ func enrichWithPasswordCredentials(
 func getClientCredentials(accessKeyID, accessKeySecret, astAPKey, authURI string) (string, error) {
 	logger.PrintIfVerbose("Fetching API access token.")
 	tokenExpirySeconds := viper.GetInt(commonParams.TokenExpirySecondsKey)
+	fingerprint := credentialsFingerprint(accessKeyID, accessKeySecret, astAPKey, authURI)
 
 	var err error
-	accessToken := getClientCredentialsFromCache(tokenExpirySeconds)
+	accessToken := getClientCredentialsFromCache(tokenExpirySeconds, fingerprint)
 
 	if accessToken == "" {
 		// If the token is present the default to that.
@@ -440,29 +438,76 @@ func getClientCredentials(accessKeyID, accessKeySecret, astAPKey, authURI string
 			return "", errors.Errorf("%s", err)
 		}
 
-		writeCredentialsToCache(accessToken)
+		writeCredentialsToCache(accessToken, fingerprint)
 	}
 
 	return accessToken, nil
 }
 
-func getClientCredentialsFromCache(tokenExpirySeconds int) string {
+// cachedAccessToken is kept in memory and in the secret store, next to the credentials of the profile. The fingerprint
+// of the credentials it was issued for keeps a token from being used after they change.
+type cachedAccessToken struct {
+	AccessToken string    `json:"accessToken"`
+	AccessTime  time.Time `json:"accessTime"`
+	Fingerprint string    `json:"fingerprint"`
+}
+
+func getClientCredentialsFromCache(tokenExpirySeconds int, fingerprint string) string {
 	logger.PrintIfVerbose("Checking cache for API access token.")
 
-	expired := time.Since(cachedAccessTime) > time.Duration(tokenExpirySeconds-expiryGraceSeconds)*time.Second
-	if !expired {
+	cachedAccessTokensMutex.Lock()
+	cached, ok := cachedAccessTokens[configuration.CurrentProfile()]
+	cachedAccessTokensMutex.Unlock()
+	if !ok && usesStoredCredentials() {
+		cached, ok = readStoredAccessToken()
+	}
+	expired := time.Since(cached.AccessTime) > time.Duration(tokenExpirySeconds-expiryGraceSeconds)*time.Second
+	if ok && !expired && cached.Fingerprint == fingerprint {
 		logger.PrintIfVerbose("Using cached API access token!")
-		return cachedAccessToken
+		return cached.AccessToken
 	}
 	logger.PrintIfVerbose("API access token not found in cache!")
 	return ""
 }
 
-func writeCredentialsToCache(accessToken string) {
+func writeCredentialsToCache(accessToken, fingerprint string) {
 	logger.PrintIfVerbose("Storing API access token to cache.")
-	viper.Set(commonParams.AstToken, accessToken)
-	cachedAccessToken = accessToken
-	cachedAccessTime = time.Now()
+	logger.AddSanitizedValue(accessToken)
+	cached := cachedAccessToken{AccessToken: accessToken, AccessTime: time.Now(), Fingerprint: fingerprint}
+	cachedAccessTokensMutex.Lock()
+	cachedAccessTokens[configuration.CurrentProfile()] = cached
+	cachedAccessTokensMutex.Unlock()
+	if !usesStoredCredentials() {
+		return
+	}
+	content, err := json.Marshal(cached)
+	if err == nil {
+		err = configuration.SetCredential(commonParams.AstToken, string(content))
+	}
+	if err != nil {
+		logger.PrintIfVerbose(fmt.Sprintf("Failed storing the API access token: %v", err))
+	}
+}
+
+func readStoredAccessToken() (cachedAccessToken, bool) {
+	var cached cachedAccessToken
+	content := configuration.GetCredential(commonParams.AstToken)
+	if content == "" || json.Unmarshal([]byte(content), &cached) != nil {
+		return cached, false
+	}
+	logger.AddSanitizedValue(cached.AccessToken)
+	return cached, true
+}
+
+// usesStoredCredentials tells if the credentials come from the secret store, the access tokens of credentials
+// given by flag or environment are only cached in memory
+func usesStoredCredentials() bool {
+	return viper.GetString(commonParams.AstAPIKey) == "" && viper.GetString(commonParams.AccessKeySecretConfigKey) == ""
+}
+
+func credentialsFingerprint(accessKeyID, accessKeySecret, astAPIKey, authURI string) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{authURI, accessKeyID, accessKeySecret, astAPIKey}, "\n")))
+	return hex.EncodeToString(hash[:])
 }
 
 func getNewToken(credentialsPayload, authServerURI string) (string, error) {
@@ -658,7 +703,7 @@ func getAuthURI() (string, error) {
 	var err error
 	override := viper.GetBool(commonParams.ApikeyOverrideFlag)
 
-	apiKey := viper.GetString(commonParams.AstAPIKey)
+	apiKey := configuration.GetCredential(commonParams.AstAPIKey)
 	if len(apiKey) > 0 {
 		logger.PrintIfVerbose("Base Auth URI - Extract from API KEY")
 		authURI, err = extractFromTokenClaims(apiKey, audienceClaimKey)
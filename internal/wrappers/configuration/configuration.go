@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -21,11 +22,14 @@ func PromptConfiguration() {
 	baseURI := viper.GetString(params.BaseURIKey)
 	baseURISrc := viper.GetString(params.BaseURIKey)
 	baseAuthURI := viper.GetString(params.BaseAuthURIKey)
-	accessKeySecret := viper.GetString(params.AccessKeySecretConfigKey)
+	accessKeySecret := GetCredential(params.AccessKeySecretConfigKey)
 	accessKey := viper.GetString(params.AccessKeyIDConfigKey)
-	accessAPIKey := viper.GetString(params.AstAPIKey)
+	accessAPIKey := GetCredential(params.AstAPIKey)
 	tenant := viper.GetString(params.TenantKey)
 	fmt.Print("Setup guide: https://checkmarx.com/resource/documents/en/34965-68621-checkmarx-one-cli-quick-start-guide.html\n\n")
+	if currentProfile != DefaultProfile {
+		fmt.Printf("Configuring profile [ %s ]\n", currentProfile)
+	}
 	// Prompt for Base URI
 	fmt.Printf("AST Base URI [%s]: ", baseURI)
 	baseURI, _ = reader.ReadString('\n')
@@ -99,14 +103,17 @@ func obfuscateString(str string) string {
 }
 
 func setConfigPropertyQuiet(propName, propValue string) {
-	viper.Set(propName, propValue)
-	// You should be able to  call WriteConfig() but it will fail if the
-	// config file doesn't already exist, this is a known viper bug.
-	// SafeWriteConfig() will not update files but it will create them, combined
-	// this code will successfully update files.
-	if viperErr := viper.SafeWriteConfig(); viperErr != nil {
-		_ = viper.WriteConfig()
+	if isCredentialKey(propName) {
+		// Credentials go to the secret store, the configuration file keeps them only when the store can't be written
+		if err := SetCredential(propName, propValue); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, %s is saved in plain text in the configuration file\n", err, propName)
+		} else {
+			propValue = ""
+		}
 	}
+	viper.Set(propName, propValue)
+	// The config file of the profile is always set, so WriteConfig() creates it when missing
+	_ = viper.WriteConfig()
 }
 
 func SetConfigProperty(propName, propValue string) {
@@ -114,18 +121,31 @@ func SetConfigProperty(propName, propValue string) {
 	setConfigPropertyQuiet(propName, propValue)
 }
 
-func LoadConfiguration() {
+// LoadConfiguration loads the active profile. A missing profile named by CX_PROFILE is an error, like the one of --profile,
+// while a missing profile selected by 'cx configure profile use' falls back to the default profile.
+// The default profile is loaded in both cases so the commands can still be built
+func LoadConfiguration() error {
+	verifyConfigDir(configDirPath())
+	viper.SetConfigType(configFileType)
+	profile := ActiveProfile()
+	err := LoadProfile(profile, false)
+	if err == nil {
+		return nil
+	}
+	if strings.TrimSpace(os.Getenv(params.ProfileEnv)) != "" {
+		_ = LoadProfile(DefaultProfile, false)
+		return errors.Wrapf(err, "%s", params.ProfileEnv)
+	}
+	log.Printf("Failed loading profile %s, using the %s profile: %v", profile, DefaultProfile, err)
+	return LoadProfile(DefaultProfile, false)
+}
+
+func configDirPath() string {
 	usr, err := user.Current()
 	if err != nil {
 		log.Fatal("Cannot file home directory.", err)
 	}
-	fullPath := usr.HomeDir + configDirName
-	verifyConfigDir(fullPath)
-	viper.AddConfigPath(fullPath)
-	configFile := "checkmarxcli"
-	viper.SetConfigName(configFile)
-	viper.SetConfigType("yaml")
-	_ = viper.ReadInConfig()
+	return usr.HomeDir + configDirName
 }
 
 func verifyConfigDir(fullPath string) {
@@ -141,6 +161,9 @@ func verifyConfigDir(fullPath string) {
 func ShowConfiguration() {
 	fmt.Println("Current Effective Configuration")
 
+	fmt.Printf("%30v", "Profile: ")
+	fmt.Println(currentProfile)
+
 	fmt.Printf("%30v", "BaseURI: ")
 	fmt.Println(viper.GetString(params.BaseURIKey))
 	fmt.Printf("%30v", "BaseAuthURIKey: ")
@@ -150,9 +173,11 @@ func ShowConfiguration() {
 	fmt.Printf("%30v", "Client ID: ")
 	fmt.Println(viper.GetString(params.AccessKeyIDConfigKey))
 	fmt.Printf("%30v", "Client Secret: ")
-	fmt.Println(obfuscateString(viper.GetString(params.AccessKeySecretConfigKey)))
+	fmt.Println(obfuscateString(GetCredential(params.AccessKeySecretConfigKey)))
 	fmt.Printf("%30v", "APIKey: ")
-	fmt.Println(obfuscateString(viper.GetString(params.AstAPIKey)))
+	fmt.Println(obfuscateString(GetCredential(params.AstAPIKey)))
+	fmt.Printf("%30v", "Credentials: ")
+	fmt.Println(CredentialsStoreName())
 	fmt.Printf("%30v", "Proxy: ")
 	fmt.Println(viper.GetString(params.ProxyKey))
 }
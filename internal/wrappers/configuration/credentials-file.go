@@ -0,0 +1,203 @@
+package configuration
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+const (
+	encryptedFileStoreName = "encrypted credentials file"
+	credentialsSaltSize    = 16
+	credentialsKeySize     = 32
+	scryptCost             = 32768
+	scryptBlockSize        = 8
+	scryptParallelism      = 1
+)
+
+// encryptedCredentials is the layout of the credentials file, the data is the AES-GCM encrypted json of the secrets
+type encryptedCredentials struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// fileStore keeps the secrets of every profile in one file, encrypted with a key derived from a passphrase
+type fileStore struct {
+	path       string
+	passphrase string
+	secrets    map[string]string
+}
+
+func (f *fileStore) name() string {
+	return encryptedFileStoreName
+}
+
+func (f *fileStore) get(profile, key string) (string, error) {
+	err := f.load()
+	if err != nil {
+		return "", err
+	}
+	return f.secrets[profile+"/"+key], nil
+}
+
+func (f *fileStore) set(profile, key, value string) error {
+	err := f.load()
+	if err != nil {
+		return err
+	}
+	f.secrets[profile+"/"+key] = value
+	return f.save()
+}
+
+func (f *fileStore) delete(profile, key string) error {
+	err := f.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := f.secrets[profile+"/"+key]; !ok {
+		return nil
+	}
+	delete(f.secrets, profile+"/"+key)
+	return f.save()
+}
+
+// load decrypts the file once, the passphrase is only needed when the file exists
+func (f *fileStore) load() error {
+	if f.secrets != nil {
+		return nil
+	}
+	content, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		f.secrets = make(map[string]string)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var file encryptedCredentials
+	err = json.Unmarshal(content, &file)
+	if err != nil {
+		return errors.Wrapf(err, "invalid credentials file %s", f.path)
+	}
+	gcm, err := f.cipher(file.Salt)
+	if err != nil {
+		return err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return errors.Errorf("failed decrypting %s, check the credentials passphrase", f.path)
+	}
+	secrets := make(map[string]string)
+	err = json.Unmarshal(plaintext, &secrets)
+	if err != nil {
+		return errors.Wrapf(err, "invalid credentials file %s", f.path)
+	}
+	f.secrets = secrets
+	return nil
+}
+
+// save encrypts the secrets with a new salt and nonce, replacing the file at once
+func (f *fileStore) save() error {
+	plaintext, err := json.Marshal(f.secrets)
+	if err != nil {
+		return err
+	}
+	file := encryptedCredentials{Salt: make([]byte, credentialsSaltSize)}
+	_, err = rand.Read(file.Salt)
+	if err != nil {
+		return err
+	}
+	gcm, err := f.cipher(file.Salt)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, gcm.NonceSize())
+	_, err = rand.Read(file.Nonce)
+	if err != nil {
+		return err
+	}
+	file.Data = gcm.Seal(nil, file.Nonce, plaintext, nil)
+	content, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(f.path), credentialsFileName+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	_, err = tempFile.Write(content)
+	closeErr := tempFile.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	err = os.Chmod(tempFile.Name(), configFilePermissions)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), f.path)
+}
+
+func (f *fileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	passphrase, err := f.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptCost, scryptBlockSize, scryptParallelism, credentialsKeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// getPassphrase reads CX_CREDENTIALS_PASSPHRASE, or else asks for the passphrase when running in a terminal
+func (f *fileStore) getPassphrase() (string, error) {
+	if f.passphrase != "" {
+		return f.passphrase, nil
+	}
+	f.passphrase = os.Getenv(params.CredentialsPassphraseEnv)
+	if f.passphrase != "" {
+		return f.passphrase, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.Errorf("no OS keychain found, set %s to encrypt the credentials in %s", params.CredentialsPassphraseEnv, f.path)
+	}
+	passphrase, err := readPassphrase(fd)
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("the credentials passphrase can't be empty")
+	}
+	f.passphrase = passphrase
+	return f.passphrase, nil
+}
+
+// readPassphrase reads a line from the terminal without echoing it
+func readPassphrase(fd int) (string, error) {
+	fmt.Fprintf(os.Stderr, "Credentials passphrase (%s): ", params.CredentialsPassphraseEnv)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(passphrase), nil
+}
@@ -0,0 +1,226 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	credentialsService     = "checkmarx-cli"
+	credentialsFileName    = "credentials.enc"
+	credentialsStoreFile   = "file"
+	configurationFileStore = "configuration file"
+)
+
+// credentialKeys are kept in the secret store instead of the configuration file, the access token cache is stored next to them
+var credentialKeys = []string{params.AstAPIKey, params.AccessKeySecretConfigKey}
+
+// secretStore keeps the secrets of every profile, a missing secret is read as an empty value
+type secretStore interface {
+	name() string
+	get(profile, key string) (string, error)
+	set(profile, key, value string) error
+	delete(profile, key string) error
+}
+
+var (
+	store             secretStore
+	storeDir          string
+	cachedCredentials = make(map[string]string)
+	credentialsMutex  sync.Mutex
+)
+
+// getSecretStore returns the OS keychain when there is one, or else the encrypted credentials file of the configuration directory
+func getSecretStore() secretStore {
+	dir := configDirPath()
+	if store != nil && storeDir == dir {
+		return store
+	}
+	storeDir = dir
+	if keychain, ok := newKeychainStore(); ok && !strings.EqualFold(os.Getenv(params.CredentialsStoreEnv), credentialsStoreFile) {
+		store = keychain
+	} else {
+		store = &fileStore{path: filepath.Join(dir, credentialsFileName)}
+	}
+	return store
+}
+
+// resetCredentials forgets the secrets read so far, so they are read again from the store of the loaded profile
+func resetCredentials() {
+	credentialsMutex.Lock()
+	defer credentialsMutex.Unlock()
+	store = nil
+	cachedCredentials = make(map[string]string)
+}
+
+// GetCredential returns the value of a credential set by flag, environment or configuration file, or else the stored one
+func GetCredential(key string) string {
+	if value := viper.GetString(key); value != "" {
+		return value
+	}
+	credentialsMutex.Lock()
+	defer credentialsMutex.Unlock()
+	cacheKey := currentProfile + "/" + key
+	if value, ok := cachedCredentials[cacheKey]; ok {
+		return value
+	}
+	secrets := getSecretStore()
+	value, err := secrets.get(currentProfile, key)
+	if err != nil {
+		logger.PrintIfVerbose(fmt.Sprintf("Failed reading %s from the %s: %v", key, secrets.name(), err))
+	}
+	logger.AddSanitizedValue(value)
+	cachedCredentials[cacheKey] = value
+	return value
+}
+
+// SetCredential stores a credential of the current profile, an empty value removes it
+func SetCredential(key, value string) error {
+	credentialsMutex.Lock()
+	defer credentialsMutex.Unlock()
+	secrets := getSecretStore()
+	var err error
+	if value == "" {
+		err = secrets.delete(currentProfile, key)
+	} else {
+		err = secrets.set(currentProfile, key, value)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed storing %s in the %s", key, secrets.name())
+	}
+	logger.AddSanitizedValue(value)
+	cachedCredentials[currentProfile+"/"+key] = value
+	return nil
+}
+
+// CredentialsStoreName tells where the credentials of the current profile are kept
+func CredentialsStoreName() string {
+	for _, key := range credentialKeys {
+		if viper.InConfig(key) && viper.GetString(key) != "" {
+			return configurationFileStore
+		}
+	}
+	return secretStoreName()
+}
+
+func secretStoreName() string {
+	credentialsMutex.Lock()
+	defer credentialsMutex.Unlock()
+	return getSecretStore().name()
+}
+
+func isCredentialKey(key string) bool {
+	for _, credentialKey := range credentialKeys {
+		if strings.EqualFold(key, credentialKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteProfileCredentials removes the stored credentials and access token of a profile
+func deleteProfileCredentials(profile string) error {
+	credentialsMutex.Lock()
+	defer credentialsMutex.Unlock()
+	secrets := getSecretStore()
+	for _, key := range append([]string{params.AstToken}, credentialKeys...) {
+		err := secrets.delete(profile, key)
+		if err != nil {
+			return err
+		}
+		delete(cachedCredentials, profile+"/"+key)
+	}
+	return nil
+}
+
+// migratePlaintextCredentials moves the credentials written by older versions from the configuration file of the
+// current profile to the secret store. They stay in the file, and the store is left as it was, when either can't be written.
+func migratePlaintextCredentials() {
+	file := profileFilePath(currentProfile)
+	stored := viper.New()
+	stored.SetConfigFile(file)
+	stored.SetConfigType(configFileType)
+	if stored.ReadInConfig() != nil {
+		return
+	}
+	settings := stored.AllSettings()
+	credentials := make(map[string]string)
+	migrated := false
+	for key, value := range settings {
+		if strings.EqualFold(key, params.AstToken) {
+			// The access token is cached again on the next authentication
+			delete(settings, key)
+			migrated = true
+			continue
+		}
+		secret, ok := value.(string)
+		if isCredentialKey(key) && ok && secret != "" {
+			credentials[key] = secret
+		}
+	}
+	// Every secret is stored before the file is rewritten, so a failure leaves both as they were
+	previous, err := storeCredentials(credentials)
+	if err != nil {
+		logger.PrintIfVerbose(fmt.Sprintf("Credentials kept in %s: %v", file, err))
+		return
+	}
+	for key := range credentials {
+		settings[key] = ""
+		migrated = true
+	}
+	if !migrated {
+		return
+	}
+	// viper can't remove a key, so the remaining settings are written by a new instance
+	remaining := viper.New()
+	remaining.SetConfigPermissions(configFilePermissions)
+	for key, value := range settings {
+		remaining.Set(key, value)
+	}
+	err = remaining.WriteConfigAs(file)
+	if err != nil {
+		restoreCredentials(previous)
+		logger.PrintIfVerbose(fmt.Sprintf("Failed removing the credentials from %s: %v", file, err))
+		return
+	}
+	logger.PrintIfVerbose(fmt.Sprintf("Moved the credentials of profile %s to the %s", currentProfile, secretStoreName()))
+	_ = viper.ReadInConfig()
+}
+
+// storeCredentials stores the credentials of the current profile and returns the values they replaced. When one
+// can't be stored, the ones already stored are replaced back.
+func storeCredentials(credentials map[string]string) (map[string]string, error) {
+	previous := make(map[string]string)
+	for key, value := range credentials {
+		credentialsMutex.Lock()
+		current, err := getSecretStore().get(currentProfile, key)
+		credentialsMutex.Unlock()
+		if err == nil {
+			err = SetCredential(key, value)
+		}
+		if err != nil {
+			restoreCredentials(previous)
+			return nil, err
+		}
+		previous[key] = current
+	}
+	return previous, nil
+}
+
+// restoreCredentials puts back the values replaced by storeCredentials, an empty value removes the credential
+func restoreCredentials(previous map[string]string) {
+	for key, value := range previous {
+		err := SetCredential(key, value)
+		if err != nil {
+			logger.PrintIfVerbose(fmt.Sprintf("Failed restoring %s: %v", key, err))
+		}
+	}
+}
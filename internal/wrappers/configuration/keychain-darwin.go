@@ -0,0 +1,79 @@
+//go:build darwin
+
+package configuration
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	securityCommand      = "security"
+	securityItemNotFound = 44
+)
+
+// keychainStore keeps the secrets as generic passwords of the login keychain
+type keychainStore struct{}
+
+func newKeychainStore() (secretStore, bool) {
+	if _, err := exec.LookPath(securityCommand); err != nil {
+		return nil, false
+	}
+	return &keychainStore{}, true
+}
+
+func (k *keychainStore) name() string {
+	return "macOS Keychain"
+}
+
+func (k *keychainStore) get(profile, key string) (string, error) {
+	out, err := exec.Command(securityCommand, "find-generic-password", "-s", credentialsService, "-a", keychainAccount(profile, key), "-w").Output()
+	if isKeychainItemNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// set runs the command in the interactive mode of security, with the secret hex encoded in its stdin, so the secret
+// never shows in the arguments of a process
+func (k *keychainStore) set(profile, key, value string) error {
+	cmd := exec.Command(securityCommand, "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -l \"Checkmarx One CLI %s %s\" -X %s\n",
+		credentialsService, keychainAccount(profile, key), profile, key, hex.EncodeToString([]byte(value))))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	_, err := cmd.Output()
+	if err != nil {
+		return errors.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	// The interactive mode reports the failed commands without always failing itself
+	if stderr.Len() > 0 {
+		return errors.New(strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (k *keychainStore) delete(profile, key string) error {
+	out, err := exec.Command(securityCommand, "delete-generic-password", "-s", credentialsService, "-a", keychainAccount(profile, key)).CombinedOutput()
+	if err != nil && !isKeychainItemNotFound(err) {
+		return errors.Errorf("%s: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func keychainAccount(profile, key string) string {
+	return profile + "/" + key
+}
+
+func isKeychainItemNotFound(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound
+}
@@ -0,0 +1,72 @@
+//go:build linux
+
+package configuration
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	secretToolCommand = "secret-tool"
+	dbusSessionEnv    = "DBUS_SESSION_BUS_ADDRESS"
+)
+
+// secretServiceStore keeps the secrets in the Secret Service of the desktop session (GNOME Keyring, KWallet) through libsecret
+type secretServiceStore struct{}
+
+// newKeychainStore returns the Secret Service store when secret-tool is installed and a session bus is running
+func newKeychainStore() (secretStore, bool) {
+	if _, err := exec.LookPath(secretToolCommand); err != nil || os.Getenv(dbusSessionEnv) == "" {
+		return nil, false
+	}
+	return &secretServiceStore{}, true
+}
+
+func (s *secretServiceStore) name() string {
+	return "Secret Service"
+}
+
+func (s *secretServiceStore) get(profile, key string) (string, error) {
+	args := append([]string{"lookup"}, secretServiceAttributes(profile, key)...)
+	var stderr strings.Builder
+	cmd := exec.Command(secretToolCommand, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// A missing secret exits with an error and no message
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(out) == 0 && stderr.Len() == 0 {
+			return "", nil
+		}
+		return "", errors.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func (s *secretServiceStore) set(profile, key, value string) error {
+	args := append([]string{"store", "--label", "Checkmarx One CLI " + profile + " " + key}, secretServiceAttributes(profile, key)...)
+	cmd := exec.Command(secretToolCommand, args...)
+	cmd.Stdin = strings.NewReader(value)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Errorf("%s: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (s *secretServiceStore) delete(profile, key string) error {
+	args := append([]string{"clear"}, secretServiceAttributes(profile, key)...)
+	out, err := exec.Command(secretToolCommand, args...).CombinedOutput()
+	if err != nil && len(out) > 0 {
+		return errors.Errorf("%s: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func secretServiceAttributes(profile, key string) []string {
+	return []string{"service", credentialsService, "profile", profile, "key", key}
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package configuration
+
+// newKeychainStore has no OS keychain to offer, the encrypted credentials file is used
+func newKeychainStore() (secretStore, bool) {
+	return nil, false
+}
@@ -0,0 +1,177 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	DefaultProfile        = params.Profile
+	configFileName        = "checkmarxcli"
+	configFileType        = "yaml"
+	profileFilePrefix     = configFileName + "-"
+	activeProfileFileName = "active-profile"
+	configFilePermissions = 0600
+)
+
+var (
+	profileNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+	currentProfile   = DefaultProfile
+)
+
+// Profile is a named configuration, stored in its own file next to the default one
+type Profile struct {
+	Name    string
+	Active  bool
+	BaseURI string `format:"name:Base URI"`
+	Tenant  string
+}
+
+// CurrentProfile returns the profile the configuration was loaded from
+func CurrentProfile() string {
+	return currentProfile
+}
+
+// ActiveProfile returns the profile set by CX_PROFILE, or else the one selected by 'configure profile use'
+func ActiveProfile() string {
+	if profile := strings.TrimSpace(os.Getenv(params.ProfileEnv)); profile != "" {
+		return profile
+	}
+	content, err := os.ReadFile(filepath.Join(configDirPath(), activeProfileFileName))
+	if err != nil || strings.TrimSpace(string(content)) == "" {
+		return DefaultProfile
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// LoadProfile reads the configuration of a profile, the following configuration changes are written to it.
+// A missing profile is an error unless allowNew is set, then it starts empty and is created on the first change.
+func LoadProfile(profile string, allowNew bool) error {
+	err := validateProfileName(profile)
+	if err != nil {
+		return err
+	}
+	exists := profileExists(profile)
+	if !exists && !allowNew && profile != DefaultProfile {
+		return errors.Errorf("profile %s does not exist, create it with 'cx configure --profile %s'", profile, profile)
+	}
+	viper.SetConfigFile(profileFilePath(profile))
+	viper.SetConfigType(configFileType)
+	if !exists || viper.ReadInConfig() != nil {
+		// Keeps the values of the previous profile from leaking into this one
+		_ = viper.ReadConfig(strings.NewReader(""))
+	}
+	currentProfile = profile
+	resetCredentials()
+	if exists {
+		migratePlaintextCredentials()
+	}
+	return nil
+}
+
+// ListProfiles returns the default profile and every named profile, sorted by name
+func ListProfiles() ([]Profile, error) {
+	files, err := filepath.Glob(filepath.Join(configDirPath(), profileFilePrefix+"*."+configFileType))
+	if err != nil {
+		return nil, err
+	}
+	names := []string{DefaultProfile}
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), profileFilePrefix), "."+configFileType)
+		if validateProfileName(name) == nil && name != DefaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	active := ActiveProfile()
+	profiles := make([]Profile, 0, len(names))
+	for _, name := range names {
+		profileConfig := viper.New()
+		profileConfig.SetConfigFile(profileFilePath(name))
+		_ = profileConfig.ReadInConfig()
+		profiles = append(profiles, Profile{
+			Name:    name,
+			Active:  name == active,
+			BaseURI: profileConfig.GetString(params.BaseURIKey),
+			Tenant:  profileConfig.GetString(params.TenantKey),
+		})
+	}
+	return profiles, nil
+}
+
+// UseProfile selects the profile used when neither --profile nor CX_PROFILE are set
+func UseProfile(profile string) error {
+	err := validateProfileName(profile)
+	if err != nil {
+		return err
+	}
+	if profile != DefaultProfile && !profileExists(profile) {
+		return errors.Errorf("profile %s does not exist", profile)
+	}
+	activeFile := filepath.Join(configDirPath(), activeProfileFileName)
+	if profile == DefaultProfile {
+		err = os.Remove(activeFile)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return os.WriteFile(activeFile, []byte(profile+"\n"), configFilePermissions)
+}
+
+// DeleteProfile removes the file and stored credentials of a named profile, the default profile is selected again
+// when it was the active one
+func DeleteProfile(profile string) error {
+	err := validateProfileName(profile)
+	if err != nil {
+		return err
+	}
+	if profile == DefaultProfile {
+		return errors.Errorf("the %s profile can't be deleted", DefaultProfile)
+	}
+	err = os.Remove(profileFilePath(profile))
+	if os.IsNotExist(err) {
+		return errors.Errorf("profile %s does not exist", profile)
+	}
+	if err != nil {
+		return err
+	}
+	if err = deleteProfileCredentials(profile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed removing the credentials of profile %s: %v\n", profile, err)
+	}
+	if ActiveProfile() == profile && os.Getenv(params.ProfileEnv) == "" {
+		return UseProfile(DefaultProfile)
+	}
+	return nil
+}
+
+func validateProfileName(profile string) error {
+	if !profileNameRegex.MatchString(profile) {
+		return errors.Errorf("invalid profile name %q, use letters, digits, '.', '_' and '-'", profile)
+	}
+	return nil
+}
+
+func profileConfigName(profile string) string {
+	if profile == DefaultProfile {
+		return configFileName
+	}
+	return profileFilePrefix + profile
+}
+
+func profileFilePath(profile string) string {
+	return filepath.Join(configDirPath(), profileConfigName(profile)+"."+configFileType)
+}
+
+func profileExists(profile string) bool {
+	_, err := os.Stat(profileFilePath(profile))
+	return err == nil
+}
@@ -10,8 +10,12 @@ type Organization struct {
 }
 
 type Repository struct {
-	FullName   string `json:"full_name"`
-	CommitsURL string `json:"commits_url"`
+	FullName      string   `json:"full_name"`
+	CommitsURL    string   `json:"commits_url"`
+	CloneURL      string   `json:"clone_url"`
+	DefaultBranch string   `json:"default_branch"`
+	Archived      bool     `json:"archived"`
+	Topics        []string `json:"topics"`
 }
 
 type CommitRoot struct {
@@ -8,12 +8,16 @@ type GitLabGroup struct {
 }
 
 type GitLabProject struct {
-	ID                int    `json:"id"`
-	Name              string `json:"name"`
-	PathWithNameSpace string `json:"path_with_namespace"`
-	Visibility        string `json:"visibility"`
-	EmptyRepo         bool   `json:"empty_repo"`
-	RepoAccessLevel   string `json:"repository_access_level"`
+	ID                int      `json:"id"`
+	Name              string   `json:"name"`
+	PathWithNameSpace string   `json:"path_with_namespace"`
+	Visibility        string   `json:"visibility"`
+	EmptyRepo         bool     `json:"empty_repo"`
+	RepoAccessLevel   string   `json:"repository_access_level"`
+	HTTPURLToRepo     string   `json:"http_url_to_repo"`
+	DefaultBranch     string   `json:"default_branch"`
+	Archived          bool     `json:"archived"`
+	Topics            []string `json:"topics"`
 }
 
 type GitLabUser struct {
@@ -17,3 +17,18 @@ func (a AccessManagementMockWrapper) GetGroups(projectID string) ([]*wrappers.Gr
 	fmt.Println("Called GetGroups in AccessManagementMockWrapper")
 	return nil, nil
 }
+
+func (a AccessManagementMockWrapper) DeleteGroupsAssignment(projectID string, groups []*wrappers.Group) error {
+	fmt.Println("Called DeleteGroupsAssignment in AccessManagementMockWrapper")
+	return nil
+}
+
+func (a AccessManagementMockWrapper) CreateGroupsAssignmentWithRoles(projectID, projectName string, groups []*wrappers.Group, roles []string) error {
+	fmt.Println("Called CreateGroupsAssignmentWithRoles in AccessManagementMockWrapper")
+	return nil
+}
+
+func (a AccessManagementMockWrapper) GetAssignments(projectID string) ([]*wrappers.AssignmentResponse, error) {
+	fmt.Println("Called GetAssignments in AccessManagementMockWrapper")
+	return nil, nil
+}
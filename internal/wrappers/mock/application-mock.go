@@ -39,3 +39,36 @@ func (a ApplicationsMockWrapper) Get(params map[string]string) (*wrappers.Applic
 
 	return response, nil
 }
+
+func (a ApplicationsMockWrapper) GetByID(applicationID string) (*wrappers.Application, *wrappers.ErrorModel, error) {
+	if applicationID == ApplicationDoesntExist {
+		return nil, nil, errors.Errorf(errorConstants.ApplicationDoesntExistOrNoPermission)
+	}
+	response, _ := a.Get(map[string]string{})
+	application := response.Applications[0]
+	application.ID = applicationID
+	return &application, nil, nil
+}
+
+func (a ApplicationsMockWrapper) Create(application *wrappers.ApplicationConfiguration) (*wrappers.Application, *wrappers.ErrorModel, error) {
+	if application.Name == FakeBadRequest400 {
+		return nil, &wrappers.ErrorModel{Code: 400, Message: "invalid application"}, nil
+	}
+	return &wrappers.Application{
+		ID:          "ID-" + application.Name,
+		Name:        application.Name,
+		Description: application.Description,
+		Criticality: application.Criticality,
+		Rules:       application.Rules,
+		Tags:        application.Tags,
+		CreatedAt:   time.Now(),
+	}, nil, nil
+}
+
+func (a ApplicationsMockWrapper) Update(_ string, _ *wrappers.ApplicationConfiguration) (*wrappers.ErrorModel, error) {
+	return nil, nil
+}
+
+func (a ApplicationsMockWrapper) Delete(_ string) (*wrappers.ErrorModel, error) {
+	return nil, nil
+}
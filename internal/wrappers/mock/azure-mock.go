@@ -41,3 +41,14 @@ func (g AzureMockWrapper) GetRepositories(url, organizationName, projectName, to
 	}
 	return wrappers.AzureRootRepo{}, nil
 }
+
+func (g AzureMockWrapper) GetAllRepositories(url, organizationName, projectName, token string) (wrappers.AzureRootRepo, error) {
+	rootRepo, err := g.GetRepositories(url, organizationName, projectName, token)
+	if len(projectName) > 0 {
+		rootRepo.Repos = append(rootRepo.Repos, wrappers.AzureRepo{
+			Name:       "MOCK DISABLED REPO",
+			IsDisabled: true,
+		})
+	}
+	return rootRepo, err
+}
@@ -43,3 +43,34 @@ func (r ResultsPredicatesMockWrapper) GetAllPredicatesForSimilarityID(similarity
 		},
 	}, nil, nil
 }
+
+func (r ResultsPredicatesMockWrapper) PredicateVulnerabilityState(predicate *wrappers.VulnerabilityPredicateRequest, scanType string) (
+	*wrappers.WebError, error,
+) {
+	fmt.Println("Called 'PredicateVulnerabilityState' in ResultsPredicatesMockWrapper")
+	return nil, nil
+}
+
+func (r ResultsPredicatesMockWrapper) GetAllPredicatesForVulnerability(vulnerability *wrappers.PackageVulnerability, projectID, scanType string) (
+	*wrappers.PredicatesCollectionResponseModel, *wrappers.WebError, error,
+) {
+	fmt.Println("Called 'GetAllPredicatesForVulnerability' in ResultsPredicatesMockWrapper")
+	return &wrappers.PredicatesCollectionResponseModel{
+		TotalCount: 1,
+		PredicateHistoryPerProject: []wrappers.PredicateHistory{
+			{
+				ProjectID:    projectID,
+				SimilarityID: vulnerability.VulnerabilityID,
+				TotalCount:   1,
+				Predicates: []wrappers.Predicate{
+					{
+						BasePredicate: wrappers.BasePredicate{State: "NOT_EXPLOITABLE", Comment: "MOCK"},
+						ID:            "MOCK",
+						CreatedBy:     "MOCK",
+						CreatedAt:     time.Now(),
+					},
+				},
+			},
+		},
+	}, nil, nil
+}
@@ -37,6 +37,25 @@ func (p *ProjectsMockWrapper) UpdateConfiguration(projectID string, configuratio
 	return nil, nil
 }
 
+func (p *ProjectsMockWrapper) GetConfiguration(projectID string) ([]wrappers.ProjectConfiguration, *wrappers.ErrorModel, error) {
+	fmt.Println("Called GetConfiguration for project", projectID, "in ProjectsMockWrapper")
+	return []wrappers.ProjectConfiguration{
+		{Key: "scan.config.sast.presetName", Name: "presetName", Category: "sast", OriginLevel: "Tenant", Value: "ASA Premium", ValueType: "List", AllowOverride: true},
+		{Key: "scan.config.sast.incremental", Name: "incremental", Category: "sast", OriginLevel: "Project", Value: "true", ValueType: "Bool", AllowOverride: true},
+		{Key: "scan.config.sast.filter", Name: "filter", Category: "sast", OriginLevel: "Tenant", Value: "", ValueType: "String", AllowOverride: true},
+		{Key: "scan.config.sast.languageMode", Name: "languageMode", Category: "sast", OriginLevel: "Tenant", Value: "primary", ValueType: "List", AllowOverride: true},
+		{Key: "scan.config.kics.platforms", Name: "platforms", Category: "kics", OriginLevel: "Tenant", Value: "", ValueType: "MultiList", AllowOverride: true},
+		{Key: "scan.config.sca.filter", Name: "filter", Category: "sca", OriginLevel: "Project", Value: "!**/test/**", ValueType: "String", AllowOverride: true},
+		{Key: "scan.config.sca.ExploitablePath", Name: "ExploitablePath", Category: "sca", OriginLevel: "Tenant", Value: "false", ValueType: "Bool", AllowOverride: false},
+		{Key: "scan.handler.git.sshKey", Name: "sshKey", Category: "git", OriginLevel: "Project", Value: "key", ValueType: "Secret", AllowOverride: true},
+	}, nil, nil
+}
+
+func (p *ProjectsMockWrapper) DeleteConfiguration(projectID string, keys []string) (*wrappers.ErrorModel, error) {
+	fmt.Println("Called DeleteConfiguration for project", projectID, "in ProjectsMockWrapper with the keys", keys)
+	return nil, nil
+}
+
 func (p *ProjectsMockWrapper) Get(params map[string]string) (
 	*wrappers.ProjectsCollectionResponseModel,
 	*wrappers.ErrorModel,
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/checkmarx/ast-cli/internal/wrappers"
 )
@@ -218,3 +219,23 @@ func (r ResultsMockWrapper) GetAllResultsByScanID(params map[string]string) (
 func (r ResultsMockWrapper) GetResultsURL(projectID string) (string, error) {
 	return fmt.Sprintf("projects/%s/overview", projectID), nil
 }
+
+func (r ResultsMockWrapper) GetScanSummariesByScanIDS(params map[string]string) (
+	*wrappers.ScanSummariesModel,
+	*wrappers.WebError,
+	error,
+) {
+	summaries := &wrappers.ScanSummariesModel{}
+	for _, scanID := range strings.Split(params["scan-ids"], ",") {
+		summaries.ScansSummaries = append(summaries.ScansSummaries, wrappers.ScanSumaries{
+			ScanID: scanID,
+			SastCounters: wrappers.SastCounters{
+				SeverityCounters: []wrappers.SeverityCounters{{Severity: "HIGH", Counter: 1}},
+				StatusCounters:   []wrappers.StatusCounters{{Status: "NEW", Counter: 1}},
+				TotalCounter:     1,
+			},
+		})
+	}
+	summaries.TotalCount = len(summaries.ScansSummaries)
+	return summaries, nil, nil
+}
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/wrappers"
@@ -47,8 +48,9 @@ func (m *ScansMockWrapper) Create(scanModel *wrappers.Scan) (*wrappers.ScanRespo
 	}
 
 	return &wrappers.ScanResponseModel{
-		ID:     uuid.New().String(),
-		Status: "MOCK",
+		ID:        uuid.New().String(),
+		Status:    "MOCK",
+		CreatedAt: time.Now(),
 	}, nil, nil
 }
 
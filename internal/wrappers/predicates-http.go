@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"unicode"
 
 	"github.com/checkmarx/ast-cli/internal/logger"
 	"github.com/checkmarx/ast-cli/internal/params"
@@ -92,6 +94,10 @@ func (r ResultsPredicatesHTTPWrapper) PredicateSeverityAndState(predicate *Predi
 		return nil, err
 	}
 
+	return handlePredicateUpdateResponse(resp)
+}
+
+func handlePredicateUpdateResponse(resp *http.Response) (*WebError, error) {
 	logger.PrintIfVerbose(fmt.Sprintf("Response : %s ", resp.Status))
 
 	defer func() {
@@ -115,11 +121,139 @@ func (r ResultsPredicatesHTTPWrapper) PredicateSeverityAndState(predicate *Predi
 	}
 }
 
+func (r ResultsPredicatesHTTPWrapper) PredicateVulnerabilityState(predicate *VulnerabilityPredicateRequest, scanType string) (
+	*WebError, error,
+) {
+	clientTimeout := viper.GetUint(params.ClientTimeoutKey)
+	triageAPIPath, err := vulnerabilityPredicatesPath(scanType)
+	if err != nil {
+		return nil, err
+	}
+	request := *predicate
+	request.Actions = make([]VulnerabilityPredicateAction, len(predicate.Actions))
+	for i, action := range predicate.Actions {
+		action.Value = toVulnerabilityState(action.Value)
+		request.Actions[i] = action
+	}
+	jsonBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.PrintIfVerbose(fmt.Sprintf("Sending POST request to  %s", triageAPIPath))
+	logger.PrintIfVerbose(fmt.Sprintf("Request Payload:  %s", string(jsonBytes)))
+
+	resp, err := SendHTTPRequest(http.MethodPost, triageAPIPath, bytes.NewBuffer(jsonBytes), true, clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return handlePredicateUpdateResponse(resp)
+}
+
+func (r ResultsPredicatesHTTPWrapper) GetAllPredicatesForVulnerability(vulnerability *PackageVulnerability, projectID, scanType string) (
+	*PredicatesCollectionResponseModel, *WebError, error,
+) {
+	clientTimeout := viper.GetUint(params.ClientTimeoutKey)
+	triageAPIPath, err := vulnerabilityPredicatesPath(scanType)
+	if err != nil {
+		return nil, nil, err
+	}
+	query := url.Values{}
+	query.Set("projectId", projectID)
+	query.Set("packageName", vulnerability.PackageName)
+	query.Set("packageVersion", vulnerability.PackageVersion)
+	query.Set("vulnerabilityId", vulnerability.VulnerabilityID)
+	if vulnerability.PackageManager != "" {
+		query.Set("packageManager", vulnerability.PackageManager)
+	}
+	if vulnerability.ImageName != "" {
+		query.Set("imageName", vulnerability.ImageName)
+		query.Set("imageTag", vulnerability.ImageTag)
+	}
+
+	logger.PrintIfVerbose(fmt.Sprintf("Fetching the predicate history for vulnerability : %s", vulnerability.VulnerabilityID))
+	logger.PrintIfVerbose(fmt.Sprintf("Sending GET request to %s", triageAPIPath+"?"+query.Encode()))
+	resp, err := SendHTTPRequest(http.MethodGet, triageAPIPath+"?"+query.Encode(), http.NoBody, true, clientTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	history := VulnerabilityPredicateHistory{}
+	webError, err := decodePredicatesResponse(resp, &history)
+	if err != nil || webError != nil {
+		return nil, webError, err
+	}
+
+	predicates := make([]Predicate, 0, len(history.Actions))
+	for _, action := range history.Actions {
+		if action.ActionType != ChangeStateAction {
+			continue
+		}
+		predicates = append(predicates, Predicate{
+			BasePredicate: BasePredicate{
+				SimilarityID: vulnerability.VulnerabilityID,
+				ProjectID:    projectID,
+				State:        fromVulnerabilityState(action.Value),
+				Comment:      action.Comment,
+			},
+			ID:        action.ID,
+			CreatedBy: action.CreatedBy,
+			CreatedAt: action.CreatedAt,
+		})
+	}
+	return &PredicatesCollectionResponseModel{
+		PredicateHistoryPerProject: []PredicateHistory{
+			{ProjectID: projectID, SimilarityID: vulnerability.VulnerabilityID, Predicates: predicates, TotalCount: len(predicates)},
+		},
+		TotalCount: len(predicates),
+	}, nil, nil
+}
+
+func vulnerabilityPredicatesPath(scanType string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(scanType)) {
+	case params.ScaType:
+		return viper.GetString(params.ScaResultsPredicatesPathKey), nil
+	case params.ContainersType, params.ContainersTypeFlag:
+		return viper.GetString(params.ContainersResultsPredicatesPathKey), nil
+	default:
+		return "", errors.Errorf(invalidScanType, scanType)
+	}
+}
+
+// toVulnerabilityState converts a triage state like NOT_EXPLOITABLE to the NotExploitable value of the management of risk API
+func toVulnerabilityState(state string) string {
+	var value strings.Builder
+	for _, word := range strings.Split(strings.ToLower(state), "_") {
+		if word != "" {
+			value.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return value.String()
+}
+
+func fromVulnerabilityState(value string) string {
+	var state strings.Builder
+	for i, char := range value {
+		if i > 0 && unicode.IsUpper(char) {
+			state.WriteRune('_')
+		}
+		state.WriteRune(unicode.ToUpper(char))
+	}
+	return state.String()
+}
+
 func handleResponseWithBody(resp *http.Response, err error) (*PredicatesCollectionResponseModel, *WebError, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	model := PredicatesCollectionResponseModel{}
+	webError, err := decodePredicatesResponse(resp, &model)
+	if err != nil || webError != nil {
+		return nil, webError, err
+	}
+	return &model, nil, nil
+}
 
+func decodePredicatesResponse(resp *http.Response, model interface{}) (*WebError, error) {
 	logger.PrintIfVerbose(fmt.Sprintf("Response : %s", resp.Status))
 
 	decoder := json.NewDecoder(resp.Body)
@@ -131,27 +265,22 @@ func handleResponseWithBody(resp *http.Response, err error) (*PredicatesCollecti
 	switch resp.StatusCode {
 	case http.StatusBadRequest, http.StatusInternalServerError:
 		errorModel := WebError{}
-		err = decoder.Decode(&errorModel)
+		err := decoder.Decode(&errorModel)
 		if err != nil {
-			return responsePredicateParsingFailed(err)
+			return nil, errors.Wrapf(err, failedToParsePredicates)
 		}
-		return nil, &errorModel, nil
+		return &errorModel, nil
 	case http.StatusOK:
-		model := PredicatesCollectionResponseModel{}
-		err = decoder.Decode(&model)
+		err := decoder.Decode(model)
 		if err != nil {
-			return responsePredicateParsingFailed(err)
+			return nil, errors.Wrapf(err, failedToParsePredicates)
 		}
-		return &model, nil, nil
+		return nil, nil
 	case http.StatusForbidden:
-		return nil, nil, errors.Errorf("No permission to show predicate.")
+		return nil, errors.Errorf("No permission to show predicate.")
 	case http.StatusNotFound:
-		return nil, nil, errors.Errorf("Predicate not found.")
+		return nil, errors.Errorf("Predicate not found.")
 	default:
-		return nil, nil, errors.Errorf("response status code %d", resp.StatusCode)
+		return nil, errors.Errorf("response status code %d", resp.StatusCode)
 	}
 }
-
-func responsePredicateParsingFailed(err error) (*PredicatesCollectionResponseModel, *WebError, error) {
-	return nil, nil, errors.Wrapf(err, failedToParsePredicates)
-}
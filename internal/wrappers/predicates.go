@@ -4,6 +4,9 @@ import (
 	"time"
 )
 
+// ChangeStateAction is the management of risk action that triages a package vulnerability
+const ChangeStateAction = "ChangeState"
+
 type BasePredicate struct {
 	SimilarityID string `json:"similarityId"`
 	ProjectID    string `json:"projectId"`
@@ -39,9 +42,64 @@ type PredicatesCollectionResponseModel struct {
 	TotalCount                 int                `json:"totalCount"`
 }
 
+// PackageVulnerability identifies an SCA vulnerability by package and CVE, container vulnerabilities also carry their image
+type PackageVulnerability struct {
+	PackageManager  string `json:"packageManager,omitempty"`
+	PackageName     string `json:"packageName"`
+	PackageVersion  string `json:"packageVersion"`
+	VulnerabilityID string `json:"vulnerabilityId"`
+	ImageName       string `json:"imageName,omitempty"`
+	ImageTag        string `json:"imageTag,omitempty"`
+}
+
+type VulnerabilityPredicateRequest struct {
+	PackageVulnerability
+	ProjectIDs []string                       `json:"projectIds"`
+	Actions    []VulnerabilityPredicateAction `json:"actions"`
+}
+
+type VulnerabilityPredicateAction struct {
+	ActionType string `json:"actionType"`
+	Value      string `json:"value"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+// VulnerabilityPredicateHistory is the management of risk history of a package vulnerability
+type VulnerabilityPredicateHistory struct {
+	Actions []VulnerabilityPredicateHistoryAction `json:"actions"`
+}
+
+type VulnerabilityPredicateHistoryAction struct {
+	ID         string    `json:"id"`
+	ActionType string    `json:"actionType"`
+	Value      string    `json:"value"`
+	Comment    string    `json:"comment"`
+	CreatedBy  string    `json:"createdBy"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// TriageExport holds the predicate history of the triaged results of a project
+type TriageExport struct {
+	ProjectID string          `json:"projectId"`
+	ScanID    string          `json:"scanId"`
+	Branch    string          `json:"branch,omitempty"`
+	Results   []TriagedResult `json:"results"`
+}
+
+type TriagedResult struct {
+	SimilarityID  string                `json:"similarityId"`
+	ScanType      string                `json:"scanType"`
+	Vulnerability *PackageVulnerability `json:"vulnerability,omitempty"`
+	Predicates    []Predicate           `json:"predicates"`
+}
+
 type ResultsPredicatesWrapper interface {
 	PredicateSeverityAndState(predicate *PredicateRequest, scanType string) (*WebError, error)
 	GetAllPredicatesForSimilarityID(
 		similarityID string, projectID string, scannerType string,
 	) (*PredicatesCollectionResponseModel, *WebError, error)
+	PredicateVulnerabilityState(predicate *VulnerabilityPredicateRequest, scanType string) (*WebError, error)
+	GetAllPredicatesForVulnerability(
+		vulnerability *PackageVulnerability, projectID string, scanType string,
+	) (*PredicatesCollectionResponseModel, *WebError, error)
 }
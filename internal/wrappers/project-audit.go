@@ -0,0 +1,30 @@
+package wrappers
+
+// ProjectAudit reports the scanning coverage of many projects
+type ProjectAudit struct {
+	CreatedAt            string
+	StaleDays            int
+	TotalProjects        int
+	StaleProjects        int
+	MainBranchNotScanned int
+	WithoutGroups        int
+	WithoutApplication   int
+	EnginesNeverRun      map[string]int
+	Projects             []*ProjectAuditEntry
+}
+
+type ProjectAuditEntry struct {
+	ProjectID         string
+	ProjectName       string
+	MainBranch        string
+	LastScanID        string
+	LastScanCreatedAt string
+	DaysSinceLastScan int
+	Stale             bool
+	MainBranchScanned bool
+	HasGroups         bool
+	HasApplication    bool
+	EnginesRun        []string
+	EnginesNeverRun   []string
+	Error             string `json:",omitempty"`
+}
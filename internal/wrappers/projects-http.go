@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -13,6 +14,11 @@ import (
 	commonParams "github.com/checkmarx/ast-cli/internal/params"
 )
 
+const (
+	projectConfigurationPath = "api/configuration/project"
+	configKeysQueryParam     = "config-keys"
+)
+
 type ProjectsHTTPWrapper struct {
 	path string
 }
@@ -79,7 +85,7 @@ func (p *ProjectsHTTPWrapper) UpdateConfiguration(projectID string, configuratio
 		commonParams.ProjectIDFlag: projectID,
 	}
 
-	resp, err := SendHTTPRequestWithQueryParams(http.MethodPatch, "api/configuration/project", params, bytes.NewBuffer(jsonBytes), clientTimeout)
+	resp, err := SendHTTPRequestWithQueryParams(http.MethodPatch, projectConfigurationPath, params, bytes.NewBuffer(jsonBytes), clientTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +97,57 @@ func (p *ProjectsHTTPWrapper) UpdateConfiguration(projectID string, configuratio
 	return handleProjectResponseWithNoBody(resp, err, http.StatusNoContent)
 }
 
+func (p *ProjectsHTTPWrapper) GetConfiguration(projectID string) ([]ProjectConfiguration, *ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	params := map[string]string{
+		commonParams.ProjectIDFlag: projectID,
+	}
+	resp, err := SendHTTPRequestWithQueryParams(http.MethodGet, projectConfigurationPath, params, http.NoBody, clientTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	decoder := json.NewDecoder(resp.Body)
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusInternalServerError:
+		errorModel := ErrorModel{}
+		err = decoder.Decode(&errorModel)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, failedToParseErr)
+		}
+		return nil, &errorModel, nil
+	case http.StatusOK:
+		var configuration []ProjectConfiguration
+		err = decoder.Decode(&configuration)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "Failed to parse project configuration")
+		}
+		return configuration, nil, nil
+	case http.StatusNotFound:
+		return nil, nil, errors.Errorf("project not found")
+	default:
+		return nil, nil, errors.Errorf("response status code %d", resp.StatusCode)
+	}
+}
+
+func (p *ProjectsHTTPWrapper) DeleteConfiguration(projectID string, keys []string) (*ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	params := map[string]string{
+		commonParams.ProjectIDFlag: projectID,
+		configKeysQueryParam:       strings.Join(keys, ","),
+	}
+	resp, err := SendHTTPRequestWithQueryParams(http.MethodDelete, projectConfigurationPath, params, http.NoBody, clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return handleProjectResponseWithNoBody(resp, err, http.StatusNoContent)
+}
+
 func (p *ProjectsHTTPWrapper) Get(params map[string]string) (
 	*ProjectsCollectionResponseModel,
 	*ErrorModel, error) {
@@ -33,6 +33,7 @@ type ProjectResponseModel struct {
 	MainBranch     string            `json:"mainBranch"`
 	Origin         string            `json:"origin,omitempty"`
 	ScmRepoID      string            `json:"scmRepoId,omitempty"`
+	PrivatePackage bool              `json:"privatePackage,omitempty"`
 	ApplicationIds []string          `json:"applicationIds"`
 }
 
@@ -56,4 +57,18 @@ type ProjectsWrapper interface {
 	Delete(projectID string) (*ErrorModel, error)
 	Tags() (map[string][]string, *ErrorModel, error)
 	UpdateConfiguration(projectID string, configuration []ProjectConfiguration) (*ErrorModel, error)
+	GetConfiguration(projectID string) ([]ProjectConfiguration, *ErrorModel, error)
+	DeleteConfiguration(projectID string, keys []string) (*ErrorModel, error)
+}
+
+// ProjectConfigurationFile is the layout of the project config export and import files, the defaults apply to every project
+type ProjectConfigurationFile struct {
+	Defaults map[string]string           `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+	Projects []ProjectConfigurationEntry `json:"projects" yaml:"projects"`
+}
+
+type ProjectConfigurationEntry struct {
+	ProjectID     string            `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	ProjectName   string            `json:"projectName,omitempty" yaml:"projectName,omitempty"`
+	Configuration map[string]string `json:"configuration" yaml:"configuration"`
 }
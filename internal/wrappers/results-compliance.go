@@ -0,0 +1,73 @@
+package wrappers
+
+// ComplianceReport groups the findings of a scan by compliance framework and category
+type ComplianceReport struct {
+	ScanID      string
+	ProjectName string
+	BranchName  string
+	CreatedAt   string
+	Frameworks  []*ComplianceFramework
+}
+
+type ComplianceFramework struct {
+	Name        string
+	Status      string
+	TotalIssues int
+	Categories  []*ComplianceCategory
+}
+
+type ComplianceCategory struct {
+	Name           string
+	Status         string
+	TotalIssues    int
+	CriticalIssues int
+	HighIssues     int
+	MediumIssues   int
+	LowIssues      int
+	InfoIssues     int
+	Queries        []string
+}
+
+const ComplianceTemplate = `{{define "ComplianceTemplate"}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta http-equiv="Content-type" content="text/html; charset=utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Checkmarx Compliance Report</title>
+    <style type="text/css">
+        body { font-family: Roboto, Arial, sans-serif; color: #231f20; margin: 24px; }
+        h1 { font-size: 24px; margin-bottom: 8px; }
+        h2 { font-size: 18px; margin: 24px 0 8px 0; }
+        table { border-collapse: collapse; width: 100%; font-size: 13px; }
+        th, td { border: 1px solid #e0e0e0; padding: 6px 8px; text-align: left; }
+        th { background-color: #f5f5f5; }
+        .PASS { color: #43a047; font-weight: bold; }
+        .FAIL { color: #f1605d; font-weight: bold; }
+    </style>
+</head>
+<body>
+<h1>Checkmarx Compliance Report</h1>
+<div>Project {{.ProjectName}}{{if .BranchName}} - branch {{.BranchName}}{{end}} - scan {{.ScanID}} - created at {{.CreatedAt}}</div>
+{{range .Frameworks}}
+<h2>{{.Name}} - <span class="{{.Status}}">{{.Status}}</span> ({{.TotalIssues}} issues)</h2>
+<table>
+    <tr><th>Category</th><th>Status</th><th>Critical</th><th>High</th><th>Medium</th><th>Low</th><th>Info</th><th>Total</th><th>Queries</th></tr>
+    {{range .Categories}}
+    <tr>
+        <td>{{.Name}}</td>
+        <td class="{{.Status}}">{{.Status}}</td>
+        <td>{{.CriticalIssues}}</td>
+        <td>{{.HighIssues}}</td>
+        <td>{{.MediumIssues}}</td>
+        <td>{{.LowIssues}}</td>
+        <td>{{.InfoIssues}}</td>
+        <td>{{.TotalIssues}}</td>
+        <td>{{range $index, $query := .Queries}}{{if $index}}, {{end}}{{$query}}{{end}}</td>
+    </tr>
+    {{end}}
+</table>
+{{end}}
+</body>
+</html>
+{{end}}`
@@ -0,0 +1,39 @@
+package wrappers
+
+// FixPlan ranks the code locations shared by most SAST flows, across all the queries of a scan
+type FixPlan struct {
+	ScanID             string
+	TotalResults       int
+	CoveredResults     int
+	TargetCoverage     float64
+	CoveragePercentage float64
+	BestFixLocations   bool
+	Locations          []*FixPlanLocation
+}
+
+type FixPlanLocation struct {
+	Rank               int
+	FileName           string
+	Line               uint
+	Column             uint
+	Name               string
+	Method             string
+	BestFixLocation    bool
+	Queries            []string
+	EliminatedResults  int
+	SharedResults      int
+	CumulativeCoverage float64
+}
+
+// nolint: lll
+const FixPlanMarkdownTemplate = `# Checkmarx One Fix Plan
+
+Fix {{len .Locations}} locations to eliminate {{printf "%.1f" .CoveragePercentage}}% of the findings ({{.CoveredResults}} of {{.TotalResults}} SAST results, target {{printf "%.0f" .TargetCoverage}}%).
+
+| Rank | Location | Name | Queries | Eliminated | Cumulative Coverage |
+|:----:|:---------|:-----|:--------|:----------:|:-------------------:|
+{{range .Locations}}| {{.Rank}} | ` + "`{{.FileName}}:{{.Line}}`" + `{{if .BestFixLocation}} ⭐{{end}} | {{.Name}} | {{range $index, $query := .Queries}}{{if $index}}, {{end}}{{$query}}{{end}} | {{.EliminatedResults}} | {{printf "%.1f" .CumulativeCoverage}}% |
+{{end}}
+{{- if .BestFixLocations}}
+⭐ Best fix location reported by Checkmarx One
+{{end}}`
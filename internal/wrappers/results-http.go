@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"sync"
 
 	commonParams "github.com/checkmarx/ast-cli/internal/params"
 	"github.com/spf13/viper"
@@ -12,13 +14,15 @@ import (
 )
 
 const (
-	failedToParseGetResults = "Failed to parse list results"
-	respStatusCode          = "response status code %d"
-	sort                    = "sort"
-	sortResultsDefault      = "-severity"
-	offset                  = "offset"
-	astAPIPageLen           = 1000
-	astAPIPagingValue       = "1000"
+	failedToParseGetResults    = "Failed to parse list results"
+	failedToParseScanSummaries = "Failed to parse scan summaries"
+	respStatusCode             = "response status code %d"
+	sort                       = "sort"
+	sortResultsDefault         = "-severity"
+	offset                     = "offset"
+	astAPIPageLen              = 1000
+	astAPIPagingValue          = "1000"
+	astAPIPageWorkers          = 4
 )
 
 type ResultsHTTPWrapper struct {
@@ -38,49 +42,98 @@ func (r *ResultsHTTPWrapper) GetAllResultsByScanID(params map[string]string) (
 	*WebError,
 	error,
 ) {
-	var scanModelslice []ScanResultsCollection
 	var scanModel ScanResultsCollection
-	DefaultMapValue(params, limit, astAPIPagingValue)
-	DefaultMapValue(params, sort, sortResultsDefault)
-
-	webErr, err := getResultsWithPagination(r.resultsPath, params, &scanModelslice)
+	webErr, err := r.GetResultsPagesByScanID(params, func(page *ScanResultsCollection) error {
+		scanModel.Results = append(scanModel.Results, page.Results...)
+		scanModel.TotalCount = page.TotalCount
+		return nil
+	})
 	if err != nil {
 		return &scanModel, nil, err
 	}
 	if webErr != nil {
 		return &scanModel, webErr, nil
 	}
-	for _, resultsPage := range scanModelslice {
-		scanModel.Results = append(scanModel.Results, resultsPage.Results...)
-		scanModel.TotalCount = resultsPage.TotalCount
-	}
 	return &scanModel, nil, nil
 }
-func getResultsWithPagination(resultPath string, queryParams map[string]string, slice *[]ScanResultsCollection) (*WebError, error) {
-	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
-	var currentPage = 0
-	for {
-		queryParams[offset] = fmt.Sprintf("%d", currentPage)
-		target, hasNextPage, weberr, err := getResultsByOffset(resultPath, queryParams, clientTimeout)
-		if err != nil {
-			return nil, err
-		}
 
-		if weberr != nil {
-			return weberr, nil
-		}
+// GetResultsPagesByScanID fetches the pages of results concurrently and hands them to handlePage in order
+func (r *ResultsHTTPWrapper) GetResultsPagesByScanID(
+	params map[string]string,
+	handlePage func(page *ScanResultsCollection) error,
+) (*WebError, error) {
+	DefaultMapValue(params, limit, astAPIPagingValue)
+	DefaultMapValue(params, sort, sortResultsDefault)
+	return getResultsWithPagination(r.resultsPath, params, handlePage)
+}
 
-		*slice = append(*slice, *target)
+type resultsPage struct {
+	target      *ScanResultsCollection
+	hasNextPage bool
+	webErr      *WebError
+	err         error
+}
 
-		if !hasNextPage {
-			break
+func getResultsWithPagination(resultPath string, queryParams map[string]string, handlePage func(page *ScanResultsCollection) error) (*WebError, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	pageLen, err := strconv.Atoi(queryParams[limit])
+	if err != nil || pageLen <= 0 {
+		pageLen = astAPIPageLen
+	}
+	totalPages := 0
+	for currentPage := 0; ; {
+		// The first page gives the total count, the next ones are fetched concurrently, a batch at a time
+		batchLen := 1
+		if currentPage > 0 {
+			batchLen = astAPIPageWorkers
+			if totalPages > 0 && totalPages-currentPage < batchLen {
+				batchLen = totalPages - currentPage
+			}
+		}
+		pages := make([]resultsPage, batchLen)
+		var wg sync.WaitGroup
+		for i := range pages {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				pageParams := make(map[string]string, len(queryParams)+1)
+				for key, value := range queryParams {
+					pageParams[key] = value
+				}
+				pageParams[offset] = fmt.Sprintf("%d", currentPage+i)
+				page := &pages[i]
+				page.target, page.hasNextPage, page.webErr, page.err = getResultsByOffset(resultPath, pageParams, clientTimeout)
+			}(i)
+		}
+		wg.Wait()
+
+		for i := range pages {
+			page := pages[i]
+			if page.err != nil {
+				return nil, page.err
+			}
+			if page.webErr != nil {
+				return page.webErr, nil
+			}
+			if currentPage+i > 0 && !page.hasNextPage {
+				return nil, nil
+			}
+			err = handlePage(page.target)
+			if err != nil {
+				return nil, err
+			}
+			if !page.hasNextPage || pageLen > len(page.target.Results) {
+				return nil, nil
+			}
 		}
-		if astAPIPageLen > int(target.TotalCount) {
-			break
+		if currentPage == 0 {
+			totalPages = (int(pages[0].target.TotalCount) + pageLen - 1) / pageLen
+		}
+		currentPage += batchLen
+		if totalPages > 0 && currentPage >= totalPages {
+			return nil, nil
 		}
-		currentPage++
 	}
-	return nil, nil
 }
 func getResultsByOffset(resultPath string, params map[string]string, clientTimeout uint) (*ScanResultsCollection, bool, *WebError, error) {
 	resp, err := SendPrivateHTTPRequestWithQueryParams(http.MethodGet, resultPath, params, http.NoBody, clientTimeout)
@@ -120,6 +173,40 @@ func getResultsByOffset(resultPath string, params map[string]string, clientTimeo
 	}
 }
 
+// GetScanSummariesByScanIDS reads the severity and status counters of the scans, without reading their results
+func (r *ResultsHTTPWrapper) GetScanSummariesByScanIDS(params map[string]string) (*ScanSummariesModel, *WebError, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	resp, err := SendPrivateHTTPRequestWithQueryParams(http.MethodGet, r.scanSummaryPath, params, http.NoBody, clientTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	decoder := json.NewDecoder(resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusInternalServerError:
+		errorModel := WebError{}
+		err = decoder.Decode(&errorModel)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, failedToParseScanSummaries)
+		}
+		return nil, &errorModel, nil
+	case http.StatusOK:
+		model := ScanSummariesModel{}
+		err = decoder.Decode(&model)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, failedToParseScanSummaries)
+		}
+		return &model, nil, nil
+	default:
+		return nil, nil, errors.Errorf(respStatusCode, resp.StatusCode)
+	}
+}
+
 func (r *ResultsHTTPWrapper) GetResultsURL(projectID string) (string, error) {
 	accessToken, err := GetAccessToken()
 	if err != nil {
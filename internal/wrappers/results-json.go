@@ -66,19 +66,20 @@ type VulnerabilityCVSS struct {
 }
 
 type ScanResultNode struct {
-	ID          string `json:"id,omitempty"`
-	Line        uint   `json:"line"`
-	Name        string `json:"name,omitempty"`
-	Column      uint   `json:"column"`
-	Length      uint   `json:"length,omitempty"`
-	Method      string `json:"method,omitempty"`
-	NodeID      int    `json:"nodeID,omitempty"`
-	DomType     string `json:"domType,omitempty"`
-	FileName    string `json:"fileName,omitempty"`
-	FullName    string `json:"fullName,omitempty"`
-	TypeName    string `json:"typeName,omitempty"`
-	MethodLine  uint   `json:"methodLine,omitempty"`
-	Definitions string `json:"definitions,omitempty"`
+	ID          string         `json:"id,omitempty"`
+	Line        uint           `json:"line"`
+	Name        string         `json:"name,omitempty"`
+	Column      uint           `json:"column"`
+	Length      uint           `json:"length,omitempty"`
+	Method      string         `json:"method,omitempty"`
+	NodeID      int            `json:"nodeID,omitempty"`
+	DomType     string         `json:"domType,omitempty"`
+	FileName    string         `json:"fileName,omitempty"`
+	FullName    string         `json:"fullName,omitempty"`
+	TypeName    string         `json:"typeName,omitempty"`
+	MethodLine  uint           `json:"methodLine,omitempty"`
+	Definitions string         `json:"definitions,omitempty"`
+	Snippet     *SourceSnippet `json:"snippet,omitempty"`
 }
 
 type ScanResultPackageData struct {
@@ -102,12 +103,13 @@ type ScanResultData struct {
 	ScaPackageCollection *ScaPackageCollection    `json:"scaPackageData,omitempty"`
 	RecommendedVersion   interface{}              `json:"recommendedVersion,omitempty"`
 	// Added to support kics results
-	Line          uint   `json:"line,omitempty"`
-	Platform      string `json:"platform,omitempty"`
-	IssueType     string `json:"issueType,omitempty"`
-	ExpectedValue string `json:"expectedValue,omitempty"`
-	Value         string `json:"value,omitempty"`
-	Filename      string `json:"filename,omitempty"`
+	Line          uint           `json:"line,omitempty"`
+	Platform      string         `json:"platform,omitempty"`
+	IssueType     string         `json:"issueType,omitempty"`
+	ExpectedValue string         `json:"expectedValue,omitempty"`
+	Value         string         `json:"value,omitempty"`
+	Filename      string         `json:"filename,omitempty"`
+	Snippet       *SourceSnippet `json:"snippet,omitempty"`
 	// Added to support containers results
 	PackageName    string `json:"packageName,omitempty"`
 	PackageVersion string `json:"packageVersion,omitempty"`
@@ -0,0 +1,110 @@
+package wrappers
+
+// PortfolioSummary aggregates the latest main branch scan of many projects
+type PortfolioSummary struct {
+	CreatedAt       string
+	TotalProjects   int
+	ScannedProjects int
+	TotalIssues     int
+	CriticalIssues  int
+	HighIssues      int
+	MediumIssues    int
+	LowIssues       int
+	InfoIssues      int
+	Projects        []*PortfolioProjectSummary
+	TopQueries      []*PortfolioOccurrence
+	TopPackages     []*PortfolioOccurrence
+}
+
+type PortfolioProjectSummary struct {
+	ProjectID      string
+	ProjectName    string
+	BranchName     string
+	ScanID         string
+	ScanStatus     string
+	ScanCreatedAt  string
+	TotalIssues    int
+	CriticalIssues int
+	HighIssues     int
+	MediumIssues   int
+	LowIssues      int
+	InfoIssues     int
+	EnginesIssues  map[string]int
+	PolicyStatus   string
+	PolicyViolated bool
+	BreakBuild     bool
+	Error          string `json:",omitempty"`
+}
+
+// PortfolioOccurrence counts how often a query or package is found across the portfolio
+type PortfolioOccurrence struct {
+	Name        string
+	Engine      string
+	Occurrences int
+	Projects    int
+}
+
+const PortfolioTemplate = `{{define "PortfolioTemplate"}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta http-equiv="Content-type" content="text/html; charset=utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Checkmarx Portfolio Report</title>
+    <style type="text/css">
+        body { font-family: Roboto, Arial, sans-serif; color: #231f20; margin: 24px; }
+        h1 { font-size: 24px; margin-bottom: 8px; }
+        h2 { font-size: 18px; margin: 24px 0 8px 0; }
+        table { border-collapse: collapse; width: 100%; font-size: 13px; }
+        th, td { border: 1px solid #e0e0e0; padding: 6px 8px; text-align: left; }
+        th { background-color: #f5f5f5; }
+        .critical { color: #C54A50; font-weight: bold; }
+        .high { color: #f1605d; font-weight: bold; }
+        .medium { color: #f9ae4d; font-weight: bold; }
+        .low { color: #bdbdbd; font-weight: bold; }
+        .violated { color: #f1605d; }
+        .totals span { margin-right: 16px; }
+    </style>
+</head>
+<body>
+<h1>Checkmarx Portfolio Report</h1>
+<div>Created at {{.CreatedAt}} - {{.ScannedProjects}} of {{.TotalProjects}} projects scanned</div>
+<div class="totals">
+    <span>Total: {{.TotalIssues}}</span>
+    <span class="critical">Critical: {{.CriticalIssues}}</span>
+    <span class="high">High: {{.HighIssues}}</span>
+    <span class="medium">Medium: {{.MediumIssues}}</span>
+    <span class="low">Low: {{.LowIssues}}</span>
+    <span>Info: {{.InfoIssues}}</span>
+</div>
+<h2>Projects</h2>
+<table>
+    <tr><th>Project</th><th>Branch</th><th>Scan</th><th>Critical</th><th>High</th><th>Medium</th><th>Low</th><th>Info</th><th>Total</th><th>Policy</th></tr>
+    {{range .Projects}}
+    <tr>
+        <td>{{.ProjectName}}</td>
+        <td>{{.BranchName}}</td>
+        <td>{{if .ScanID}}{{.ScanCreatedAt}} ({{.ScanStatus}}){{else}}{{.Error}}{{end}}</td>
+        <td class="critical">{{.CriticalIssues}}</td>
+        <td class="high">{{.HighIssues}}</td>
+        <td class="medium">{{.MediumIssues}}</td>
+        <td class="low">{{.LowIssues}}</td>
+        <td>{{.InfoIssues}}</td>
+        <td>{{.TotalIssues}}</td>
+        <td{{if .PolicyViolated}} class="violated"{{end}}>{{.PolicyStatus}}{{if .BreakBuild}} (break build){{end}}</td>
+    </tr>
+    {{end}}
+</table>
+<h2>Top Recurring Queries</h2>
+<table>
+    <tr><th>Query</th><th>Engine</th><th>Occurrences</th><th>Projects</th></tr>
+    {{range .TopQueries}}<tr><td>{{.Name}}</td><td>{{.Engine}}</td><td>{{.Occurrences}}</td><td>{{.Projects}}</td></tr>{{end}}
+</table>
+<h2>Top Recurring Packages</h2>
+<table>
+    <tr><th>Package</th><th>Engine</th><th>Occurrences</th><th>Projects</th></tr>
+    {{range .TopPackages}}<tr><td>{{.Name}}</td><td>{{.Engine}}</td><td>{{.Occurrences}}</td><td>{{.Projects}}</td></tr>{{end}}
+</table>
+</body>
+</html>
+{{end}}`
@@ -0,0 +1,52 @@
+package wrappers
+
+// SLAReport compares the age of the open findings of a scan with the SLA of their severity
+type SLAReport struct {
+	ScanID          string
+	CreatedAt       string
+	TotalResults    int
+	BreachedResults int
+	UnknownAge      int
+	Severities      []*SLASeverity
+	Findings        []*SLAFinding
+}
+
+type SLASeverity struct {
+	Severity        string
+	SLADays         int
+	OpenResults     int
+	BreachedResults int
+	OldestAgeDays   int
+}
+
+// SLAFinding is an open finding older than the SLA of its severity
+type SLAFinding struct {
+	ID           string `format:"name:ID"`
+	Type         string `format:"name:Type"`
+	Severity     string `format:"name:Severity"`
+	Name         string `format:"name:Name"`
+	Location     string `format:"name:Location"`
+	FirstFoundAt string `format:"name:First found at"`
+	AgeDays      int    `format:"name:Age (days)"`
+	SLADays      int    `format:"name:SLA (days)"`
+	OverdueDays  int    `format:"name:Overdue (days)"`
+}
+
+// nolint: lll
+const SLAMarkdownTemplate = `# Checkmarx One SLA Report
+
+{{.BreachedResults}} of {{.TotalResults}} open findings breach their SLA (scan {{.ScanID}}, {{.CreatedAt}}).
+{{- if .UnknownAge}} The age of {{.UnknownAge}} findings is unknown.{{end}}
+
+| Severity | SLA (days) | Open | Breached | Oldest (days) |
+|:---------|:----------:|:----:|:--------:|:-------------:|
+{{range .Severities}}| {{.Severity}} | {{.SLADays}} | {{.OpenResults}} | {{.BreachedResults}} | {{.OldestAgeDays}} |
+{{end}}
+{{- if .Findings}}
+## Breaching Findings
+
+| Severity | Type | Name | Location | First Found | Age (days) | Overdue (days) |
+|:---------|:-----|:-----|:---------|:------------|:----------:|:--------------:|
+{{range .Findings}}| {{.Severity}} | {{.Type}} | {{.Name}} | ` + "`{{.Location}}`" + ` | {{.FirstFoundAt}} | {{.AgeDays}} | {{.OverdueDays}} |
+{{end}}
+{{- end}}`
@@ -0,0 +1,60 @@
+package wrappers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SourceSnippet holds the source lines around a result location, read from a local checkout
+type SourceSnippet struct {
+	StartLine uint     `json:"startLine"`
+	Line      uint     `json:"line"`
+	Lines     []string `json:"lines"`
+}
+
+// NumberedLines returns the snippet lines prefixed by their line number, marking the result line
+func (s *SourceSnippet) NumberedLines() []string {
+	numbered := make([]string, 0, len(s.Lines))
+	for i, line := range s.Lines {
+		lineNumber := s.StartLine + uint(i)
+		marker := " "
+		if lineNumber == s.Line {
+			marker = ">"
+		}
+		numbered = append(numbered, fmt.Sprintf("%s%5d | %s", marker, lineNumber, line))
+	}
+	return numbered
+}
+
+// ResultCodeSnippets is the code of every location of a result, for SAST the full data flow
+type ResultCodeSnippets struct {
+	Type      string
+	Severity  string
+	QueryName string
+	Locations []*CodeSnippetLocation
+}
+
+type CodeSnippetLocation struct {
+	FileName string
+	Line     uint
+	Name     string
+	Snippet  *SourceSnippet
+}
+
+// Fence returns a markdown code fence longer than any backtick run of the snippet, so the source can't close it
+func (l *CodeSnippetLocation) Fence() string {
+	const minFenceSize = 3
+	longest := 0
+	for _, line := range l.Snippet.Lines {
+		run := 0
+		for _, char := range line {
+			if char != '`' {
+				run = 0
+				continue
+			}
+			run++
+			longest = max(longest, run)
+		}
+	}
+	return strings.Repeat("`", max(minFenceSize, longest+1))
+}
@@ -37,6 +37,7 @@ type ResultSummary struct {
 	EnginesEnabled   []string
 	Policies         *PolicyResponseModel
 	EnginesResult    EnginesResultsSummary
+	CodeSnippets     []*ResultCodeSnippets `json:"-"`
 }
 
 // nolint: govet
@@ -608,6 +609,23 @@ const summaryTemplateHeader = `{{define "SummaryTemplate"}}
             align-items: center;
             text-align: center;
             margin-bottom: 10px;
+        }
+        .code-snippets {
+            padding: 16px 20px;
+        }
+        .code-snippet {
+            margin-top: 16px;
+        }
+        .code-snippet-title {
+            font-weight: bold;
+            margin-bottom: 8px;
+        }
+        .code-snippet pre {
+            background-color: #f5f5f5;
+            font-size: 12px;
+            margin: 4px 0 8px 0;
+            overflow-x: auto;
+            padding: 8px;
         }
 		#policy {
 		  	border-collapse: collapse;
@@ -801,6 +819,22 @@ const nonAsyncSummary = `<div class="top-row">
  					<div class="total">{{.APISecurity.TotalRisksCount}}</div>
                 </div>
 		</div>
+        {{end}}
+        {{if .CodeSnippets}}
+        <hr>
+        <div class="code-snippets">
+            <div class="total">Code Snippets</div>
+            {{range .CodeSnippets}}
+            <div class="code-snippet">
+                <div class="code-snippet-title">{{html .Severity}} - {{html .QueryName}} ({{html .Type}})</div>
+                {{range .Locations}}
+                <div>{{html .FileName}}:{{.Line}}{{if .Name}} {{html .Name}}{{end}}</div>
+                <pre>{{range .Snippet.NumberedLines}}{{html .}}
+{{end}}</pre>
+                {{end}}
+            </div>
+            {{end}}
+        </div>
         {{end}}`
 
 const asyncSummaryTemplate = `<div class="cx-info">
@@ -868,6 +902,18 @@ const SummaryMarkdownCompletedTemplate = `
 |:---------:|:---------:| {{if .HasAPISecurityDocumentation}}:---------:|{{end}}
 | {{.APISecurity.APICount}} | {{.APISecurity.TotalRisksCount}} | {{if .HasAPISecurityDocumentation}} {{.GetAPISecurityDocumentationTotal}} |{{end}}
 {{end}}
+{{if .CodeSnippets}}
+### Code Snippets
+{{range .CodeSnippets}}
+#### {{.Severity}} - {{.QueryName}} ({{.Type}})
+{{range $index, $location := .Locations}}
+{{if gt $index 0}}⬇️ {{end}}` + "`{{$location.FileName}}:{{$location.Line}}`" + `{{if $location.Name}} {{$location.Name}}{{end}}
+{{$location.Fence}}
+{{range $location.Snippet.NumberedLines}}{{.}}
+{{end}}{{$location.Fence}}
+{{end}}
+{{end}}
+{{end}}
 `
 
 func SummaryMarkdownTemplate(isScanPending bool) string {
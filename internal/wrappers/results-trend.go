@@ -0,0 +1,98 @@
+package wrappers
+
+// ResultsTrend is the time series of the results of the latest scans of a project branch
+type ResultsTrend struct {
+	CreatedAt               string
+	ProjectID               string
+	BranchName              string
+	MeanTimeToRemediateDays float64
+	RemediatedIssues        int
+	Scans                   []*ResultsTrendScan
+	MaxIssues               int `json:"-"`
+}
+
+type ResultsTrendScan struct {
+	ScanID         string
+	ScanStatus     string
+	ScanCreatedAt  string
+	TotalIssues    int
+	CriticalIssues int
+	HighIssues     int
+	MediumIssues   int
+	LowIssues      int
+	InfoIssues     int
+	NewIssues      int
+	FixedIssues    int
+	EnginesIssues  map[string]*ResultsTrendSeverities
+}
+
+type ResultsTrendSeverities struct {
+	Critical int
+	High     int
+	Medium   int
+	Low      int
+	Info     int
+}
+
+const ResultsTrendTemplate = `{{define "ResultsTrendTemplate"}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta http-equiv="Content-type" content="text/html; charset=utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Checkmarx Results Trend</title>
+    <style type="text/css">
+        body { font-family: Roboto, Arial, sans-serif; color: #231f20; margin: 24px; }
+        h1 { font-size: 24px; margin-bottom: 8px; }
+        h2 { font-size: 18px; margin: 24px 0 8px 0; }
+        table { border-collapse: collapse; width: 100%; font-size: 13px; }
+        th, td { border: 1px solid #e0e0e0; padding: 6px 8px; text-align: left; }
+        th { background-color: #f5f5f5; }
+        .chart { display: flex; align-items: flex-end; height: 240px; border-bottom: 1px solid #bdbdbd; }
+        .column { display: flex; flex-direction: column-reverse; width: 32px; margin-right: 8px; }
+        .critical { background-color: #C54A50; }
+        .high { background-color: #f1605d; }
+        .medium { background-color: #f9ae4d; }
+        .low { background-color: #bdbdbd; }
+        .info { background-color: #e0e0e0; }
+    </style>
+</head>
+<body>
+<h1>Checkmarx Results Trend</h1>
+<div>Project {{.ProjectID}}{{if .BranchName}} - branch {{.BranchName}}{{end}} - created at {{.CreatedAt}}</div>
+<div>Mean time to remediate: {{printf "%.1f" .MeanTimeToRemediateDays}} days ({{.RemediatedIssues}} remediated issues)</div>
+<h2>Issues per scan</h2>
+<div class="chart">
+    {{$max := .MaxIssues}}
+    {{range .Scans}}
+    <div class="column" title="{{.ScanCreatedAt}}: {{.TotalIssues}} issues">
+        <div class="critical" style="height: {{barHeight .CriticalIssues $max}}px"></div>
+        <div class="high" style="height: {{barHeight .HighIssues $max}}px"></div>
+        <div class="medium" style="height: {{barHeight .MediumIssues $max}}px"></div>
+        <div class="low" style="height: {{barHeight .LowIssues $max}}px"></div>
+        <div class="info" style="height: {{barHeight .InfoIssues $max}}px"></div>
+    </div>
+    {{end}}
+</div>
+<h2>Scans</h2>
+<table>
+    <tr><th>Scan</th><th>Created At</th><th>Status</th><th>Critical</th><th>High</th><th>Medium</th><th>Low</th><th>Info</th><th>Total</th><th>New</th><th>Fixed</th></tr>
+    {{range .Scans}}
+    <tr>
+        <td>{{.ScanID}}</td>
+        <td>{{.ScanCreatedAt}}</td>
+        <td>{{.ScanStatus}}</td>
+        <td>{{.CriticalIssues}}</td>
+        <td>{{.HighIssues}}</td>
+        <td>{{.MediumIssues}}</td>
+        <td>{{.LowIssues}}</td>
+        <td>{{.InfoIssues}}</td>
+        <td>{{.TotalIssues}}</td>
+        <td>{{.NewIssues}}</td>
+        <td>{{.FixedIssues}}</td>
+    </tr>
+    {{end}}
+</table>
+</body>
+</html>
+{{end}}`
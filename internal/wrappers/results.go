@@ -3,6 +3,13 @@ package wrappers
 type ResultsWrapper interface {
 	GetAllResultsByScanID(params map[string]string) (*ScanResultsCollection, *WebError, error)
 	GetResultsURL(projectID string) (string, error)
+	GetScanSummariesByScanIDS(params map[string]string) (*ScanSummariesModel, *WebError, error)
+}
+
+// ResultsPagesWrapper is implemented by the results wrappers able to hand the results of a scan page by page,
+// so the caller doesn't need to hold all of them in memory
+type ResultsPagesWrapper interface {
+	GetResultsPagesByScanID(params map[string]string, handlePage func(page *ScanResultsCollection) error) (*WebError, error)
 }
 
 // ScanSummariesModel model used to parse the response from the scan-summary API
@@ -12,6 +19,7 @@ type ScanSummariesModel struct {
 }
 
 type ScanSumaries struct {
+	ScanID                string                `json:"scanId,omitempty,"`
 	SastCounters          SastCounters          `json:"sastCounters,omitempty,"`
 	KicsCounters          KicsCounters          `json:"kicsCounters,omitempty,"`
 	ScaCounters           ScaCounters           `json:"scaCounters,omitempty,"`
@@ -20,23 +28,27 @@ type ScanSumaries struct {
 
 type SastCounters struct {
 	SeverityCounters    []SeverityCounters `json:"SeverityCounters,omitempty,"`
+	StatusCounters      []StatusCounters   `json:"statusCounters,omitempty,"`
 	TotalCounter        int                `json:"totalCounter,omitempty,"`
 	FilesScannedCounter int                `json:"filesScannedCounter,omitempty,"`
 }
 type KicsCounters struct {
 	SeverityCounters    []SeverityCounters `json:"SeverityCounters,omitempty,"`
+	StatusCounters      []StatusCounters   `json:"statusCounters,omitempty,"`
 	TotalCounter        int                `json:"totalCounter,omitempty,"`
 	FilesScannedCounter int                `json:"filesScannedCounter,omitempty,"`
 }
 
 type ScaCounters struct {
 	SeverityCounters    []SeverityCounters `json:"SeverityCounters,omitempty,"`
+	StatusCounters      []StatusCounters   `json:"statusCounters,omitempty,"`
 	TotalCounter        int                `json:"totalCounter,omitempty,"`
 	FilesScannedCounter int                `json:"filesScannedCounter,omitempty,"`
 }
 
 type ScaContainersCounters struct {
 	SeverityCounters            []SeverityCounters `json:"severityVulnerabilitiesCounters,omitempty,"`
+	StatusCounters              []StatusCounters   `json:"statusCounters,omitempty,"`
 	TotalPackagesCounter        int                `json:"totalPackagesCounter,omitempty,"`
 	TotalVulnerabilitiesCounter int                `json:"totalVulnerabilitiesCounter,omitempty,"`
 }
@@ -45,3 +57,8 @@ type SeverityCounters struct {
 	Severity string `json:"severity,omitempty,"`
 	Counter  int    `json:"counter,omitempty,"`
 }
+
+type StatusCounters struct {
+	Status  string `json:"status,omitempty,"`
+	Counter int    `json:"counter,omitempty,"`
+}
@@ -1875,7 +1875,7 @@ func listScanByProjectID(t *testing.T, projectID string) []wrappers.ScanResponse
 
 func TestCreateAsyncScan_CallExportServiceBeforeScanFinishWithRetry_Success(t *testing.T) {
 	createASTIntegrationTestCommand(t)
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	args := []string{
 		"scan", "create",
 		flag(params.ProjectName), GenerateRandomProjectNameForScan(),
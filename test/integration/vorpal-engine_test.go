@@ -19,7 +19,7 @@ import (
 )
 
 func TestScanVorpal_NoFileSourceSent_ReturnSuccess(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	args := []string{
 		"scan", "vorpal",
 		flag(commonParams.SourcesFlag), "",
@@ -35,7 +35,7 @@ func TestScanVorpal_NoFileSourceSent_ReturnSuccess(t *testing.T) {
 }
 
 func TestExecuteVorpalScan_VorpalLatestVersionSetTrue_Success(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	args := []string{
 		"scan", "vorpal",
 		flag(commonParams.SourcesFlag), "",
@@ -52,7 +52,7 @@ func TestExecuteVorpalScan_VorpalLatestVersionSetTrue_Success(t *testing.T) {
 }
 
 func TestExecuteVorpalScan_NoSourceAndVorpalLatestVersionSetFalse_Success(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	vorpalWrapper := grpcs.NewVorpalGrpcWrapper(viper.GetInt(commonParams.VorpalPortKey))
 	_ = vorpalWrapper.ShutDown()
 	_ = os.RemoveAll(vorpalconfig.Params.WorkingDir())
@@ -71,7 +71,7 @@ func TestExecuteVorpalScan_NoSourceAndVorpalLatestVersionSetFalse_Success(t *tes
 }
 
 func TestExecuteVorpalScan_NotExistingFile_Success(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	args := []string{
 		"scan", "vorpal",
 		flag(commonParams.SourcesFlag), "not-existing-file.py",
@@ -87,7 +87,7 @@ func TestExecuteVorpalScan_NotExistingFile_Success(t *testing.T) {
 }
 
 func TestExecuteVorpalScan_VorpalLatestVersionSetFalse_Success(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	args := []string{
 		"scan", "vorpal",
 		flag(commonParams.SourcesFlag), "data/python-vul-file.py",
@@ -105,7 +105,7 @@ func TestExecuteVorpalScan_VorpalLatestVersionSetFalse_Success(t *testing.T) {
 }
 
 func TestExecuteVorpalScan_NoEngineInstalledAndVorpalLatestVersionSetFalse_Success(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 
 	vorpalWrapper := grpcs.NewVorpalGrpcWrapper(viper.GetInt(commonParams.VorpalPortKey))
 	_ = vorpalWrapper.ShutDown()
@@ -128,7 +128,7 @@ func TestExecuteVorpalScan_NoEngineInstalledAndVorpalLatestVersionSetFalse_Succe
 }
 
 func TestExecuteVorpalScan_CorrectFlagsSent_SuccessfullyReturnMockData(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	args := []string{
 		"scan", "vorpal",
 		flag(commonParams.SourcesFlag), "data/python-vul-file.py",
@@ -146,7 +146,7 @@ func TestExecuteVorpalScan_CorrectFlagsSent_SuccessfullyReturnMockData(t *testin
 }
 
 func TestExecuteVorpalScan_UnsupportedLanguage_Fail(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	args := []string{
 		"scan", "vorpal",
 		flag(commonParams.SourcesFlag), "data/positive1.tf",
@@ -162,7 +162,7 @@ func TestExecuteVorpalScan_UnsupportedLanguage_Fail(t *testing.T) {
 }
 
 func TestExecuteVorpalScan_InitializeAndRunUpdateVersion_Success(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	vorpalWrapper := grpcs.NewVorpalGrpcWrapper(viper.GetInt(commonParams.VorpalPortKey))
 	_ = vorpalWrapper.ShutDown()
 	args := []string{
@@ -183,7 +183,7 @@ func TestExecuteVorpalScan_InitializeAndRunUpdateVersion_Success(t *testing.T) {
 }
 
 func TestExecuteVorpalScan_InitializeAndShutdown_Success(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	args := []string{
 		"scan", "vorpal",
 		flag(commonParams.SourcesFlag), "",
@@ -209,7 +209,7 @@ func TestExecuteVorpalScan_InitializeAndShutdown_Success(t *testing.T) {
 }
 
 func TestExecuteVorpalScan_EngineNotRunningWithLicense_Success(t *testing.T) {
-	configuration.LoadConfiguration()
+	assert.NilError(t, configuration.LoadConfiguration())
 	vorpalWrapper := grpcs.NewVorpalGrpcWrapper(viper.GetInt(commonParams.VorpalPortKey))
 	_ = vorpalWrapper.ShutDown()
 	_ = os.RemoveAll(vorpalconfig.Params.WorkingDir())